@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/srcndev/message-service/config"
+	"github.com/srcndev/message-service/internal/repository"
+	"github.com/srcndev/message-service/internal/service"
+	"github.com/srcndev/message-service/pkg/database"
+	"github.com/srcndev/message-service/pkg/logger"
+)
+
+func main() {
+	// Define CLI flags
+	fileFlag := flag.String("file", "", "CSV or NDJSON file to import")
+	formatFlag := flag.String("format", "", "File format: csv or ndjson (default: from the file extension)")
+	reportFlag := flag.String("report", "", "Write the rejected rows to this CSV file instead of the log")
+	helpFlag := flag.Bool("help", false, "Show help message")
+	flag.Parse()
+
+	if *helpFlag || *fileFlag == "" {
+		printHelp()
+		os.Exit(0)
+	}
+
+	format := service.ImportFormat(*formatFlag)
+	if format == "" {
+		format = service.ImportFormatFromFilename(*fileFlag)
+	}
+
+	file, err := os.Open(*fileFlag)
+	if err != nil {
+		logger.Fatal("Failed to open import file: %v", err)
+	}
+	defer file.Close()
+
+	// Load configuration
+	cfg, err := config.NewConfig()
+	if err != nil {
+		logger.Fatal("Failed to load config: %v", err)
+	}
+
+	// Connect to database
+	db, err := database.NewPostgresDB(cfg)
+	if err != nil {
+		logger.Fatal("Failed to connect to database: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		logger.Fatal("Failed to get database instance: %v", err)
+	}
+	defer sqlDB.Close()
+
+	// Rows go through the same validation as the API
+	messageService := service.NewMessageService(
+		repository.NewMessageRepository(db),
+		service.WithMaxSegments(cfg.Message.MaxSegments),
+		service.WithTemplates(service.NewTemplateService(repository.NewTemplateRepository(db))),
+		service.WithSuppressions(
+			service.NewSuppressionService(repository.NewSuppressionRepository(db), nil),
+			cfg.Suppression.Mode == config.SuppressionModeSuppress,
+		),
+	)
+	importService := service.NewMessageImportService(messageService)
+
+	logger.Info("Importing %s as %s...", *fileFlag, format)
+	report, err := importService.Import(context.Background(), format, file)
+	if report != nil {
+		logger.Info("Rows: %d, created: %d, failed: %d", report.Rows, report.Created, report.Failed)
+		if writeErr := writeReport(*reportFlag, report); writeErr != nil {
+			logger.Error("Failed to write report: %v", writeErr)
+		}
+	}
+	if err != nil && report != nil {
+		logger.Fatal("Import stopped at line %d: %v", report.StoppedAt, err)
+	}
+	if err != nil {
+		logger.Fatal("Import failed: %v", err)
+	}
+
+	logger.Info("Done!")
+}
+
+// writeReport writes the rejected rows as CSV to path, or to the log when no path is given
+func writeReport(path string, report *service.ImportReport) error {
+	if path == "" {
+		for _, rowErr := range report.Errors {
+			logger.Error("Line %d: %v", rowErr.Line, rowErr.Err)
+		}
+		return nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := writeReportCSV(file, report); err != nil {
+		return err
+	}
+	logger.Info("Rejected rows written to %s", path)
+	return nil
+}
+
+// writeReportCSV writes one line per rejected row with its error
+func writeReportCSV(w io.Writer, report *service.ImportReport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"line", "error"}); err != nil {
+		return err
+	}
+	for _, rowErr := range report.Errors {
+		if err := writer.Write([]string{strconv.Itoa(rowErr.Line), rowErr.Err.Error()}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func printHelp() {
+	fmt.Println("Message Import Tool")
+	fmt.Println("\nUsage:")
+	fmt.Println("  import -file <path> [options]")
+	fmt.Println("\nOptions:")
+	fmt.Println("  -file      CSV or NDJSON file to import")
+	fmt.Println("  -format    csv or ndjson (default: from the file extension)")
+	fmt.Println("  -report    Write the rejected rows to this CSV file")
+	fmt.Println("  -help      Show this help message")
+	fmt.Println("\nExamples:")
+	fmt.Println("  # Import a spreadsheet export")
+	fmt.Println("  ./bin/import -file recipients.csv")
+	fmt.Println("")
+	fmt.Println("  # Import NDJSON and keep the rejected rows")
+	fmt.Println("  ./bin/import -file messages.ndjson -report rejected.csv")
+}
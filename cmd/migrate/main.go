@@ -6,7 +6,6 @@ import (
 	"os"
 
 	"github.com/srcndev/message-service/config"
-	"github.com/srcndev/message-service/internal/domain"
 	"github.com/srcndev/message-service/pkg/database"
 	"github.com/srcndev/message-service/pkg/logger"
 	"github.com/srcndev/message-service/seed"
@@ -45,7 +44,7 @@ func main() {
 
 	// Run migrations
 	logger.Info("Running database migrations...")
-	if err := db.AutoMigrate(&domain.Message{}); err != nil {
+	if err := database.AutoMigrate(db); err != nil {
 		logger.Fatal("Migration failed: %v", err)
 	}
 	logger.Info("✓ Migrations completed successfully")
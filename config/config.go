@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/srcndev/message-service/internal/domain"
 )
 
 type Config struct {
@@ -16,7 +18,11 @@ type Config struct {
 	Database      DatabaseConfig
 	Redis         RedisConfig
 	Webhook       WebhookConfig
+	Message       MessageConfig
 	MessageSender MessageSenderConfig
+	Idempotency   IdempotencyConfig
+	Suppression   SuppressionConfig
+	Inbound       InboundConfig
 }
 
 // DatabaseConfig holds database connection settings
@@ -37,18 +43,111 @@ type RedisConfig struct {
 	Enabled  bool
 }
 
+// Webhook rate limiter backends
+const (
+	RateLimitBackendMemory = "memory" // Quota is local to each replica
+	RateLimitBackendRedis  = "redis"  // Quota is shared by all replicas through Redis
+)
+
+// ProviderConfig holds the endpoint and routing rule of one SMS provider
+type ProviderConfig struct {
+	Name     string
+	URL      string
+	AuthKey  string
+	Prefixes []string // Destination prefixes served by the provider (empty = all destinations)
+	Priority int      // Lower values are tried first among providers serving a destination
+	Weight   int      // Share of traffic among providers with the same prefix match and priority
+}
+
 // WebhookConfig holds webhook client settings
 type WebhookConfig struct {
 	URL        string
 	AuthKey    string
 	Timeout    time.Duration
 	MaxRetries int
+
+	RetryMaxElapsed    time.Duration // Total time spent retrying a single webhook call (0 = no limit)
+	RetryNonIdempotent bool          // Retry the webhook POST on transient failures (may deliver twice)
+
+	RateLimit        float64            // Outbound messages per second across all destinations (0 disables)
+	RateLimitBurst   int                // Messages that may be sent at once before the rate applies
+	RateLimitBackend string             // Where buckets are kept: memory or redis
+	PrefixRateLimits map[string]float64 // Messages per second per destination prefix (e.g. "+90")
+
+	BreakerFailureThreshold int           // Consecutive endpoint failures that open the circuit breaker (0 disables)
+	BreakerCoolDown         time.Duration // How long the breaker stays open before a probe call
+
+	Providers []ProviderConfig // SMS providers messages are routed to, with failover between them
 }
 
+// Idempotency key stores
+const (
+	IdempotencyBackendPostgres = "postgres" // Responses are kept in the idempotency_keys table
+	IdempotencyBackendRedis    = "redis"    // Responses are kept in Redis with a key TTL
+)
+
+// IdempotencyConfig holds Idempotency-Key settings
+type IdempotencyConfig struct {
+	Backend string        // Where responses are kept: postgres or redis
+	TTL     time.Duration // How long a response is replayed for retries with the same key
+	LockTTL time.Duration // How long a key stays reserved while its first request runs
+}
+
+// Suppression modes for new messages to suppressed numbers
+const (
+	SuppressionModeReject   = "reject"   // The request is rejected with RECIPIENT_SUPPRESSED
+	SuppressionModeSuppress = "suppress" // The message is stored as suppressed and never sent
+)
+
+// SuppressionConfig holds recipient suppression list settings
+type SuppressionConfig struct {
+	Mode     string        // What happens to new messages for a suppressed number: reject or suppress
+	CacheTTL time.Duration // How long a lookup is cached in Redis (when enabled)
+}
+
+// InboundConfig holds the keywords recognized in inbound replies and the auto-replies queued for them
+type InboundConfig struct {
+	StopKeywords  []string // Replies that add the sender to the suppression list
+	StartKeywords []string // Replies that remove an opt-out of the sender
+	HelpKeywords  []string // Replies that only get the help auto-reply
+	StopReply     string   // Auto-reply to a stop keyword (empty = no reply)
+	StartReply    string   // Auto-reply to a start keyword (empty = no reply)
+	HelpReply     string   // Auto-reply to a help keyword (empty = no reply)
+}
+
+// MessageConfig holds message content settings
+type MessageConfig struct {
+	MaxSegments int // Longest message accepted, in SMS segments (160 GSM-7 or 70 UCS-2 characters each)
+}
+
+// Message sender modes
+const (
+	SenderModeInterval = "interval" // One batch per tick (case study)
+	SenderModeDrain    = "drain"    // Keep sending full batches until the queue is empty or the budget is hit
+)
+
 // MessageSenderConfig holds message sender job settings
 type MessageSenderConfig struct {
-	Interval  time.Duration // How often to check for pending messages
-	BatchSize int           // Number of messages to send per cycle
+	Mode        string        // Sending mode: interval or drain
+	Interval    time.Duration // How often to check for pending messages
+	BatchSize   int           // Number of messages to send per cycle
+	Concurrency int           // Number of messages of a batch sent in parallel
+	MaxAttempts int           // Delivery attempts before a message is marked as failed
+
+	PriorityShares map[domain.MessagePriority]int // Percentage of each batch reserved per priority lane
+
+	BackoffBase       time.Duration // Delay before the first retry of a failed message
+	BackoffMax        time.Duration // Upper bound for the retry delay
+	BackoffMultiplier float64       // Growth factor of the retry delay per attempt
+
+	InstanceID          string        // Lease owner recorded on messages claimed by this instance
+	LeaseDuration       time.Duration // How long a claimed message stays reserved for this instance
+	LeaseReaperInterval time.Duration // How often expired leases are released back to pending
+
+	ExpirySweepInterval time.Duration // How often pending messages past their validity are expired
+
+	DrainMaxMessages int           // Messages a drain cycle may claim before waiting for the next tick
+	DrainMaxDuration time.Duration // Time a drain cycle may run before waiting for the next tick
 }
 
 func NewConfig() (*Config, error) {
@@ -79,6 +178,77 @@ func NewConfig() (*Config, error) {
 		}
 	}
 
+	// Webhook retry budget (default: retries allowed within 1 minute)
+	webhookRetryMaxElapsed := 1 * time.Minute
+	if elapsedStr := getEnv("WEBHOOK_RETRY_MAX_ELAPSED", ""); elapsedStr != "" {
+		if elapsed, err := time.ParseDuration(elapsedStr); err == nil {
+			webhookRetryMaxElapsed = elapsed
+		}
+	}
+	// Opt-in only, enable it when the provider honors the Idempotency-Key header
+	webhookRetryNonIdempotent := getEnv("WEBHOOK_RETRY_NON_IDEMPOTENT", "false") == "true"
+
+	// Circuit breaker (default: open after 5 consecutive failures, probe again after 30s)
+	webhookBreakerThreshold := 5
+	if thresholdStr := getEnv("WEBHOOK_BREAKER_FAILURE_THRESHOLD", ""); thresholdStr != "" {
+		if threshold, err := strconv.Atoi(thresholdStr); err == nil {
+			webhookBreakerThreshold = threshold
+		}
+	}
+
+	webhookBreakerCoolDown := 30 * time.Second
+	if coolDownStr := getEnv("WEBHOOK_BREAKER_COOL_DOWN", ""); coolDownStr != "" {
+		if coolDown, err := time.ParseDuration(coolDownStr); err == nil {
+			webhookBreakerCoolDown = coolDown
+		}
+	}
+
+	// Outbound rate limit (default: disabled, burst of 1, in-memory buckets)
+	webhookRateLimit := 0.0
+	if rateStr := getEnv("WEBHOOK_RATE_LIMIT", ""); rateStr != "" {
+		if rate, err := strconv.ParseFloat(rateStr, 64); err == nil {
+			webhookRateLimit = rate
+		}
+	}
+
+	webhookRateLimitBurst := 1
+	if burstStr := getEnv("WEBHOOK_RATE_LIMIT_BURST", ""); burstStr != "" {
+		if burst, err := strconv.Atoi(burstStr); err == nil {
+			webhookRateLimitBurst = burst
+		}
+	}
+
+	webhookPrefixRateLimits, err := parsePrefixRateLimits(getEnv("WEBHOOK_RATE_LIMIT_PREFIXES", ""))
+	if err != nil {
+		return nil, fmt.Errorf("config validation failed: %w", ErrWebhookRateLimitPrefixesInvalid.WithError(err))
+	}
+
+	// SMS providers (default: a single provider using WEBHOOK_URL and WEBHOOK_AUTH_KEY)
+	webhookURL := getEnv("WEBHOOK_URL", "https://webhook.site/7d2fa94f-bb3c-47d7-b787-8aaacbd5097d")
+	webhookAuthKey := getEnv("WEBHOOK_AUTH_KEY", "INS.me1x9uMcyYGlhKKQVPoc.bO3j9aZwRTOcA2Ywo")
+	webhookProviders, err := loadProviders(getEnv("WEBHOOK_PROVIDERS", ""), webhookURL, webhookAuthKey)
+	if err != nil {
+		return nil, fmt.Errorf("config validation failed: %w", ErrWebhookProviderInvalid.WithError(err))
+	}
+
+	// Message sender mode (default: interval as per case study)
+	senderMode := getEnv("MESSAGE_SENDER_MODE", SenderModeInterval)
+
+	// Drain budget per cycle (default: 1000 messages or 1 minute, whichever comes first)
+	senderDrainMaxMessages := 1000
+	if maxMessagesStr := getEnv("MESSAGE_SENDER_DRAIN_MAX_MESSAGES", ""); maxMessagesStr != "" {
+		if maxMessages, err := strconv.Atoi(maxMessagesStr); err == nil && maxMessages > 0 {
+			senderDrainMaxMessages = maxMessages
+		}
+	}
+
+	senderDrainMaxDuration := 1 * time.Minute
+	if maxDurationStr := getEnv("MESSAGE_SENDER_DRAIN_MAX_DURATION", ""); maxDurationStr != "" {
+		if maxDuration, err := time.ParseDuration(maxDurationStr); err == nil {
+			senderDrainMaxDuration = maxDuration
+		}
+	}
+
 	// Message sender batch size (default: 2 messages per cycle as per case study)
 	senderBatchSize := 2
 	if batchStr := getEnv("MESSAGE_SENDER_BATCH_SIZE", ""); batchStr != "" {
@@ -87,6 +257,106 @@ func NewConfig() (*Config, error) {
 		}
 	}
 
+	// Message sender concurrency (default: 2 so a full case study batch is sent in parallel)
+	senderConcurrency := 2
+	if concurrencyStr := getEnv("MESSAGE_SENDER_CONCURRENCY", ""); concurrencyStr != "" {
+		if concurrency, err := strconv.Atoi(concurrencyStr); err == nil && concurrency > 0 {
+			senderConcurrency = concurrency
+		}
+	}
+
+	// Share of each batch reserved per priority lane in percent (default: high=60,normal=30,low=10)
+	senderPriorityShares, err := parsePriorityShares(getEnv("MESSAGE_SENDER_PRIORITY_SHARES", "high=60,normal=30,low=10"))
+	if err != nil {
+		return nil, fmt.Errorf("config validation failed: %w", ErrSenderPrioritySharesInvalid.WithError(err))
+	}
+
+	// Message sender max attempts before a message is marked as failed (default: 5)
+	senderMaxAttempts := 5
+	if attemptsStr := getEnv("MESSAGE_SENDER_MAX_ATTEMPTS", ""); attemptsStr != "" {
+		if attempts, err := strconv.Atoi(attemptsStr); err == nil && attempts > 0 {
+			senderMaxAttempts = attempts
+		}
+	}
+
+	// Retry backoff for failed messages (default: 30s base, 1h cap, doubling per attempt)
+	senderBackoffBase := 30 * time.Second
+	if baseStr := getEnv("MESSAGE_SENDER_BACKOFF_BASE", ""); baseStr != "" {
+		if base, err := time.ParseDuration(baseStr); err == nil {
+			senderBackoffBase = base
+		}
+	}
+
+	senderBackoffMax := 1 * time.Hour
+	if maxStr := getEnv("MESSAGE_SENDER_BACKOFF_MAX", ""); maxStr != "" {
+		if max, err := time.ParseDuration(maxStr); err == nil {
+			senderBackoffMax = max
+		}
+	}
+
+	senderBackoffMultiplier := 2.0
+	if multiplierStr := getEnv("MESSAGE_SENDER_BACKOFF_MULTIPLIER", ""); multiplierStr != "" {
+		if multiplier, err := strconv.ParseFloat(multiplierStr, 64); err == nil {
+			senderBackoffMultiplier = multiplier
+		}
+	}
+
+	// Lease settings for multi-instance claiming (default: hostname-pid, 5m lease, 1m reaper)
+	senderInstanceID := getEnv("MESSAGE_SENDER_INSTANCE_ID", defaultInstanceID())
+
+	senderLeaseDuration := 5 * time.Minute
+	if leaseStr := getEnv("MESSAGE_SENDER_LEASE_DURATION", ""); leaseStr != "" {
+		if lease, err := time.ParseDuration(leaseStr); err == nil {
+			senderLeaseDuration = lease
+		}
+	}
+
+	senderLeaseReaperInterval := 1 * time.Minute
+	if reaperStr := getEnv("MESSAGE_SENDER_LEASE_REAPER_INTERVAL", ""); reaperStr != "" {
+		if reaper, err := time.ParseDuration(reaperStr); err == nil {
+			senderLeaseReaperInterval = reaper
+		}
+	}
+
+	// Expiry sweep for pending messages past their validity (default: every minute)
+	senderExpirySweepInterval := 1 * time.Minute
+	if sweepStr := getEnv("MESSAGE_EXPIRY_SWEEP_INTERVAL", ""); sweepStr != "" {
+		if sweep, err := time.ParseDuration(sweepStr); err == nil {
+			senderExpirySweepInterval = sweep
+		}
+	}
+
+	// Longest message accepted in SMS segments (default: 6)
+	messageMaxSegments := 6
+	if segmentsStr := getEnv("MESSAGE_MAX_SEGMENTS", ""); segmentsStr != "" {
+		if segments, err := strconv.Atoi(segmentsStr); err == nil {
+			messageMaxSegments = segments
+		}
+	}
+
+	// Idempotency keys (default: responses replayed for 24h, keys reserved for 1m while running)
+	idempotencyTTL := 24 * time.Hour
+	if ttlStr := getEnv("IDEMPOTENCY_TTL", ""); ttlStr != "" {
+		if ttl, err := time.ParseDuration(ttlStr); err == nil {
+			idempotencyTTL = ttl
+		}
+	}
+
+	idempotencyLockTTL := 1 * time.Minute
+	if lockStr := getEnv("IDEMPOTENCY_LOCK_TTL", ""); lockStr != "" {
+		if lock, err := time.ParseDuration(lockStr); err == nil {
+			idempotencyLockTTL = lock
+		}
+	}
+
+	// Suppression list lookups (default: cached in Redis for 1h)
+	suppressionCacheTTL := 1 * time.Hour
+	if ttlStr := getEnv("SUPPRESSION_CACHE_TTL", ""); ttlStr != "" {
+		if ttl, err := time.ParseDuration(ttlStr); err == nil {
+			suppressionCacheTTL = ttl
+		}
+	}
+
 	// Redis DB number
 	redisDB := 0
 	if dbStr := getEnv("REDIS_DB", ""); dbStr != "" {
@@ -119,15 +389,71 @@ func NewConfig() (*Config, error) {
 		},
 
 		Webhook: WebhookConfig{
-			URL:        getEnv("WEBHOOK_URL", "https://webhook.site/7d2fa94f-bb3c-47d7-b787-8aaacbd5097d"),
-			AuthKey:    getEnv("WEBHOOK_AUTH_KEY", "INS.me1x9uMcyYGlhKKQVPoc.bO3j9aZwRTOcA2Ywo"),
+			URL:        webhookURL,
+			AuthKey:    webhookAuthKey,
 			Timeout:    webhookTimeout,
 			MaxRetries: webhookMaxRetries,
+
+			RetryMaxElapsed:    webhookRetryMaxElapsed,
+			RetryNonIdempotent: webhookRetryNonIdempotent,
+
+			RateLimit:        webhookRateLimit,
+			RateLimitBurst:   webhookRateLimitBurst,
+			RateLimitBackend: getEnv("WEBHOOK_RATE_LIMIT_BACKEND", RateLimitBackendMemory),
+			PrefixRateLimits: webhookPrefixRateLimits,
+
+			BreakerFailureThreshold: webhookBreakerThreshold,
+			BreakerCoolDown:         webhookBreakerCoolDown,
+
+			Providers: webhookProviders,
+		},
+
+		Message: MessageConfig{
+			MaxSegments: messageMaxSegments,
 		},
 
 		MessageSender: MessageSenderConfig{
-			Interval:  senderInterval,
-			BatchSize: senderBatchSize,
+			Mode:        senderMode,
+			Interval:    senderInterval,
+			BatchSize:   senderBatchSize,
+			Concurrency: senderConcurrency,
+			MaxAttempts: senderMaxAttempts,
+
+			PriorityShares: senderPriorityShares,
+
+			BackoffBase:       senderBackoffBase,
+			BackoffMax:        senderBackoffMax,
+			BackoffMultiplier: senderBackoffMultiplier,
+
+			InstanceID:          senderInstanceID,
+			LeaseDuration:       senderLeaseDuration,
+			LeaseReaperInterval: senderLeaseReaperInterval,
+
+			ExpirySweepInterval: senderExpirySweepInterval,
+
+			DrainMaxMessages: senderDrainMaxMessages,
+			DrainMaxDuration: senderDrainMaxDuration,
+		},
+
+		Idempotency: IdempotencyConfig{
+			Backend: getEnv("IDEMPOTENCY_BACKEND", IdempotencyBackendPostgres),
+			TTL:     idempotencyTTL,
+			LockTTL: idempotencyLockTTL,
+		},
+
+		Suppression: SuppressionConfig{
+			Mode:     getEnv("SUPPRESSION_MODE", SuppressionModeReject),
+			CacheTTL: suppressionCacheTTL,
+		},
+
+		// Inbound keywords are matched case-insensitively against the whole reply
+		Inbound: InboundConfig{
+			StopKeywords:  parseKeywords(getEnv("INBOUND_STOP_KEYWORDS", "STOP,UNSUBSCRIBE")),
+			StartKeywords: parseKeywords(getEnv("INBOUND_START_KEYWORDS", "START")),
+			HelpKeywords:  parseKeywords(getEnv("INBOUND_HELP_KEYWORDS", "HELP")),
+			StopReply:     getEnv("INBOUND_STOP_REPLY", ""),
+			StartReply:    getEnv("INBOUND_START_REPLY", ""),
+			HelpReply:     getEnv("INBOUND_HELP_REPLY", ""),
 		},
 	}
 
@@ -166,12 +492,72 @@ func (c *Config) validate() error {
 	if c.Webhook.AuthKey == "" {
 		return ErrWebhookAuthKeyEmpty
 	}
+	if err := validateProviders(c.Webhook.Providers); err != nil {
+		return err
+	}
+	if c.Webhook.RateLimit < 0 || c.Webhook.RateLimitBurst < 1 {
+		return ErrWebhookRateLimitInvalid
+	}
+	if c.Webhook.RateLimitBackend != RateLimitBackendMemory && c.Webhook.RateLimitBackend != RateLimitBackendRedis {
+		return ErrWebhookRateLimitBackendInvalid
+	}
+	if c.Webhook.MaxRetries < 0 || c.Webhook.RetryMaxElapsed < 0 {
+		return ErrWebhookRetryInvalid
+	}
+	if c.Webhook.BreakerFailureThreshold < 0 || c.Webhook.BreakerCoolDown <= 0 {
+		return ErrWebhookBreakerInvalid
+	}
+	if c.Message.MaxSegments <= 0 {
+		return ErrMessageMaxSegmentsInvalid
+	}
+	if c.MessageSender.Mode != SenderModeInterval && c.MessageSender.Mode != SenderModeDrain {
+		return ErrSenderModeInvalid
+	}
 	if c.MessageSender.Interval <= 0 {
 		return ErrSenderIntervalInvalid
 	}
 	if c.MessageSender.BatchSize <= 0 {
 		return ErrSenderBatchSizeInvalid
 	}
+	if c.MessageSender.Concurrency <= 0 {
+		return ErrSenderConcurrencyInvalid
+	}
+	if c.MessageSender.MaxAttempts <= 0 {
+		return ErrSenderMaxAttemptsInvalid
+	}
+	if c.MessageSender.BackoffBase <= 0 || c.MessageSender.BackoffMax < c.MessageSender.BackoffBase {
+		return ErrSenderBackoffInvalid
+	}
+	if c.MessageSender.BackoffMultiplier < 1 {
+		return ErrSenderBackoffMultiplierInvalid
+	}
+	if c.MessageSender.InstanceID == "" {
+		return ErrSenderInstanceIDEmpty
+	}
+	if c.MessageSender.LeaseDuration <= 0 || c.MessageSender.LeaseReaperInterval <= 0 {
+		return ErrSenderLeaseInvalid
+	}
+	if c.MessageSender.ExpirySweepInterval <= 0 {
+		return ErrSenderExpirySweepInvalid
+	}
+	if c.MessageSender.DrainMaxMessages <= 0 || c.MessageSender.DrainMaxDuration <= 0 {
+		return ErrSenderDrainBudgetInvalid
+	}
+	if c.Idempotency.Backend != IdempotencyBackendPostgres && c.Idempotency.Backend != IdempotencyBackendRedis {
+		return ErrIdempotencyBackendInvalid
+	}
+	if c.Idempotency.TTL <= 0 || c.Idempotency.LockTTL <= 0 {
+		return ErrIdempotencyTTLInvalid
+	}
+	if c.Suppression.Mode != SuppressionModeReject && c.Suppression.Mode != SuppressionModeSuppress {
+		return ErrSuppressionModeInvalid
+	}
+	if c.Suppression.CacheTTL <= 0 {
+		return ErrSuppressionCacheTTLInvalid
+	}
+	if err := validateKeywords(c.Inbound.StopKeywords, c.Inbound.StartKeywords, c.Inbound.HelpKeywords); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -181,3 +567,168 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// parsePrefixRateLimits parses "+90=5,+1=10" into per-prefix messages per second
+func parsePrefixRateLimits(value string) (map[string]float64, error) {
+	limits := make(map[string]float64)
+	if value == "" {
+		return limits, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		prefix, rateStr, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || prefix == "" {
+			return nil, fmt.Errorf("invalid entry %q, expected prefix=rate", entry)
+		}
+		rate, err := strconv.ParseFloat(rateStr, 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid rate for prefix %q", prefix)
+		}
+		limits[prefix] = rate
+	}
+
+	return limits, nil
+}
+
+// parsePriorityShares parses "high=60,normal=30,low=10" into the percentage of a batch reserved per lane
+func parsePriorityShares(value string) (map[domain.MessagePriority]int, error) {
+	shares := make(map[domain.MessagePriority]int)
+	if strings.TrimSpace(value) == "" {
+		return shares, nil
+	}
+
+	total := 0
+	for _, entry := range strings.Split(value, ",") {
+		laneStr, shareStr, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		lane := domain.MessagePriority(strings.TrimSpace(laneStr))
+		if !ok || !lane.IsValid() {
+			return nil, fmt.Errorf("invalid entry %q, expected lane=percent with lane high, normal or low", entry)
+		}
+		share, err := strconv.Atoi(strings.TrimSpace(shareStr))
+		if err != nil || share < 0 {
+			return nil, fmt.Errorf("invalid share for lane %q", lane)
+		}
+		shares[lane] = share
+		total += share
+	}
+
+	if total > 100 {
+		return nil, fmt.Errorf("shares add up to %d%%, at most 100%% can be reserved", total)
+	}
+
+	return shares, nil
+}
+
+// parseKeywords parses "STOP, unsubscribe" into upper-case keywords
+func parseKeywords(value string) []string {
+	var keywords []string
+	for _, keyword := range strings.Split(value, ",") {
+		if keyword = strings.ToUpper(strings.TrimSpace(keyword)); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	return keywords
+}
+
+// validateKeywords checks that no keyword triggers more than one action
+func validateKeywords(lists ...[]string) error {
+	seen := make(map[string]bool)
+	for _, keywords := range lists {
+		for _, keyword := range keywords {
+			if seen[keyword] {
+				return ErrInboundKeywordsInvalid
+			}
+			seen[keyword] = true
+		}
+	}
+	return nil
+}
+
+// defaultProviderName names the provider built from WEBHOOK_URL when no providers are listed
+const defaultProviderName = "default"
+
+// loadProviders reads the providers listed in names ("primary,backup") from
+// WEBHOOK_PROVIDER_<NAME>_URL, _AUTH_KEY, _PREFIXES, _PRIORITY and _WEIGHT.
+// Without a list, a single provider is built from the default webhook URL and auth key.
+func loadProviders(names, defaultURL, defaultAuthKey string) ([]ProviderConfig, error) {
+	if strings.TrimSpace(names) == "" {
+		return []ProviderConfig{{Name: defaultProviderName, URL: defaultURL, AuthKey: defaultAuthKey, Weight: 1}}, nil
+	}
+
+	var providers []ProviderConfig
+	for i, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("empty provider name in %q", names)
+		}
+		envPrefix := "WEBHOOK_PROVIDER_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+
+		// Providers are tried in list order unless a priority is given
+		priority := i
+		if priorityStr := getEnv(envPrefix+"PRIORITY", ""); priorityStr != "" {
+			p, err := strconv.Atoi(priorityStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid priority for provider %q", name)
+			}
+			priority = p
+		}
+
+		weight := 1
+		if weightStr := getEnv(envPrefix+"WEIGHT", ""); weightStr != "" {
+			w, err := strconv.Atoi(weightStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid weight for provider %q", name)
+			}
+			weight = w
+		}
+
+		var prefixes []string
+		for _, prefix := range strings.Split(getEnv(envPrefix+"PREFIXES", ""), ",") {
+			if prefix = strings.TrimSpace(prefix); prefix != "" {
+				prefixes = append(prefixes, prefix)
+			}
+		}
+
+		providers = append(providers, ProviderConfig{
+			Name:     name,
+			URL:      getEnv(envPrefix+"URL", ""),
+			AuthKey:  getEnv(envPrefix+"AUTH_KEY", ""),
+			Prefixes: prefixes,
+			Priority: priority,
+			Weight:   weight,
+		})
+	}
+
+	return providers, nil
+}
+
+// validateProviders checks that every provider is reachable and names are unique
+func validateProviders(providers []ProviderConfig) error {
+	if len(providers) == 0 {
+		return ErrWebhookProviderInvalid
+	}
+
+	seen := make(map[string]bool, len(providers))
+	for _, p := range providers {
+		if p.URL == "" {
+			return ErrWebhookURLEmpty
+		}
+		if p.AuthKey == "" {
+			return ErrWebhookAuthKeyEmpty
+		}
+		if seen[p.Name] || p.Priority < 0 || p.Weight < 1 {
+			return ErrWebhookProviderInvalid
+		}
+		seen[p.Name] = true
+	}
+	return nil
+}
+
+// defaultInstanceID builds a lease owner that is unique per running process
+func defaultInstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "message-service"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
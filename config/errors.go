@@ -8,32 +8,76 @@ import (
 
 // Error codes
 const (
-	ErrCodeAppPortEmpty           = "APP_PORT_EMPTY"
-	ErrCodeAppURLEmpty            = "APP_URL_EMPTY"
-	ErrCodeDBHostEmpty            = "DB_HOST_EMPTY"
-	ErrCodeDBPortEmpty            = "DB_PORT_EMPTY"
-	ErrCodeDBUsernameEmpty        = "DB_USERNAME_EMPTY"
-	ErrCodeDBPasswordEmpty        = "DB_PASSWORD_EMPTY"
-	ErrCodeDBNameEmpty            = "DB_NAME_EMPTY"
-	ErrCodeWebhookURLEmpty        = "WEBHOOK_URL_EMPTY"
-	ErrCodeWebhookAuthKeyEmpty    = "WEBHOOK_AUTH_KEY_EMPTY"
-	ErrCodeSenderIntervalInvalid  = "SENDER_INTERVAL_INVALID"
-	ErrCodeSenderBatchSizeInvalid = "SENDER_BATCH_SIZE_INVALID"
+	ErrCodeAppPortEmpty                    = "APP_PORT_EMPTY"
+	ErrCodeAppURLEmpty                     = "APP_URL_EMPTY"
+	ErrCodeDBHostEmpty                     = "DB_HOST_EMPTY"
+	ErrCodeDBPortEmpty                     = "DB_PORT_EMPTY"
+	ErrCodeDBUsernameEmpty                 = "DB_USERNAME_EMPTY"
+	ErrCodeDBPasswordEmpty                 = "DB_PASSWORD_EMPTY"
+	ErrCodeDBNameEmpty                     = "DB_NAME_EMPTY"
+	ErrCodeWebhookURLEmpty                 = "WEBHOOK_URL_EMPTY"
+	ErrCodeWebhookAuthKeyEmpty             = "WEBHOOK_AUTH_KEY_EMPTY"
+	ErrCodeWebhookRetryInvalid             = "WEBHOOK_RETRY_INVALID"
+	ErrCodeWebhookRateLimitInvalid         = "WEBHOOK_RATE_LIMIT_INVALID"
+	ErrCodeWebhookRateLimitBackendInvalid  = "WEBHOOK_RATE_LIMIT_BACKEND_INVALID"
+	ErrCodeWebhookRateLimitPrefixesInvalid = "WEBHOOK_RATE_LIMIT_PREFIXES_INVALID"
+	ErrCodeWebhookBreakerInvalid           = "WEBHOOK_BREAKER_INVALID"
+	ErrCodeWebhookProviderInvalid          = "WEBHOOK_PROVIDER_INVALID"
+	ErrCodeMessageMaxSegmentsInvalid       = "MESSAGE_MAX_SEGMENTS_INVALID"
+	ErrCodeSenderModeInvalid               = "SENDER_MODE_INVALID"
+	ErrCodeSenderIntervalInvalid           = "SENDER_INTERVAL_INVALID"
+	ErrCodeSenderBatchSizeInvalid          = "SENDER_BATCH_SIZE_INVALID"
+	ErrCodeSenderConcurrencyInvalid        = "SENDER_CONCURRENCY_INVALID"
+	ErrCodeSenderMaxAttemptsInvalid        = "SENDER_MAX_ATTEMPTS_INVALID"
+	ErrCodeSenderPrioritySharesInvalid     = "SENDER_PRIORITY_SHARES_INVALID"
+	ErrCodeSenderBackoffInvalid            = "SENDER_BACKOFF_INVALID"
+	ErrCodeSenderBackoffMultiplierInvalid  = "SENDER_BACKOFF_MULTIPLIER_INVALID"
+	ErrCodeSenderInstanceIDEmpty           = "SENDER_INSTANCE_ID_EMPTY"
+	ErrCodeSenderLeaseInvalid              = "SENDER_LEASE_INVALID"
+	ErrCodeSenderExpirySweepInvalid        = "SENDER_EXPIRY_SWEEP_INVALID"
+	ErrCodeSenderDrainBudgetInvalid        = "SENDER_DRAIN_BUDGET_INVALID"
+	ErrCodeIdempotencyBackendInvalid       = "IDEMPOTENCY_BACKEND_INVALID"
+	ErrCodeIdempotencyTTLInvalid           = "IDEMPOTENCY_TTL_INVALID"
+	ErrCodeSuppressionModeInvalid          = "SUPPRESSION_MODE_INVALID"
+	ErrCodeSuppressionCacheTTLInvalid      = "SUPPRESSION_CACHE_TTL_INVALID"
+	ErrCodeInboundKeywordsInvalid          = "INBOUND_KEYWORDS_INVALID"
 )
 
 // Error messages
 const (
-	MsgAppPortEmpty           = "APP_PORT cannot be empty"
-	MsgAppURLEmpty            = "APP_URL cannot be empty"
-	MsgDBHostEmpty            = "Database host cannot be empty"
-	MsgDBPortEmpty            = "Database port cannot be empty"
-	MsgDBUsernameEmpty        = "Database username cannot be empty"
-	MsgDBPasswordEmpty        = "Database password cannot be empty"
-	MsgDBNameEmpty            = "Database name cannot be empty"
-	MsgWebhookURLEmpty        = "Webhook URL cannot be empty"
-	MsgWebhookAuthKeyEmpty    = "Webhook auth key cannot be empty"
-	MsgSenderIntervalInvalid  = "Message sender interval must be greater than 0"
-	MsgSenderBatchSizeInvalid = "Message sender batch size must be greater than 0"
+	MsgAppPortEmpty                    = "APP_PORT cannot be empty"
+	MsgAppURLEmpty                     = "APP_URL cannot be empty"
+	MsgDBHostEmpty                     = "Database host cannot be empty"
+	MsgDBPortEmpty                     = "Database port cannot be empty"
+	MsgDBUsernameEmpty                 = "Database username cannot be empty"
+	MsgDBPasswordEmpty                 = "Database password cannot be empty"
+	MsgDBNameEmpty                     = "Database name cannot be empty"
+	MsgWebhookURLEmpty                 = "Webhook URL cannot be empty"
+	MsgWebhookAuthKeyEmpty             = "Webhook auth key cannot be empty"
+	MsgWebhookRetryInvalid             = "Webhook max retries and retry max elapsed time cannot be negative"
+	MsgWebhookRateLimitInvalid         = "Webhook rate limit cannot be negative and burst must be at least 1"
+	MsgWebhookRateLimitBackendInvalid  = "Webhook rate limit backend must be memory or redis"
+	MsgWebhookRateLimitPrefixesInvalid = "Webhook rate limit prefixes must be a comma separated list of prefix=rate"
+	MsgWebhookBreakerInvalid           = "Webhook breaker failure threshold cannot be negative and cool-down must be greater than 0"
+	MsgWebhookProviderInvalid          = "Webhook providers must have unique names, a non-negative priority and a weight of at least 1"
+	MsgMessageMaxSegmentsInvalid       = "Message max segments must be greater than 0"
+	MsgSenderModeInvalid               = "Message sender mode must be interval or drain"
+	MsgSenderIntervalInvalid           = "Message sender interval must be greater than 0"
+	MsgSenderBatchSizeInvalid          = "Message sender batch size must be greater than 0"
+	MsgSenderConcurrencyInvalid        = "Message sender concurrency must be greater than 0"
+	MsgSenderMaxAttemptsInvalid        = "Message sender max attempts must be greater than 0"
+	MsgSenderPrioritySharesInvalid     = "Message sender priority shares must be a comma separated list of lane=percent adding up to at most 100"
+	MsgSenderBackoffInvalid            = "Message sender backoff base must be greater than 0 and not exceed backoff max"
+	MsgSenderBackoffMultiplierInvalid  = "Message sender backoff multiplier must be at least 1"
+	MsgSenderInstanceIDEmpty           = "Message sender instance ID cannot be empty"
+	MsgSenderLeaseInvalid              = "Message sender lease duration and reaper interval must be greater than 0"
+	MsgSenderExpirySweepInvalid        = "Message expiry sweep interval must be greater than 0"
+	MsgSenderDrainBudgetInvalid        = "Message sender drain max messages and max duration must be greater than 0"
+	MsgIdempotencyBackendInvalid       = "Idempotency backend must be postgres or redis"
+	MsgIdempotencyTTLInvalid           = "Idempotency TTL and lock TTL must be greater than 0"
+	MsgSuppressionModeInvalid          = "Suppression mode must be reject or suppress"
+	MsgSuppressionCacheTTLInvalid      = "Suppression cache TTL must be greater than 0"
+	MsgInboundKeywordsInvalid          = "An inbound keyword can only be listed for one action"
 )
 
 // Predefined errors
@@ -92,6 +136,54 @@ var (
 		http.StatusBadRequest,
 	)
 
+	ErrWebhookRetryInvalid = customerror.NewCustomError(
+		ErrCodeWebhookRetryInvalid,
+		MsgWebhookRetryInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrWebhookRateLimitInvalid = customerror.NewCustomError(
+		ErrCodeWebhookRateLimitInvalid,
+		MsgWebhookRateLimitInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrWebhookRateLimitBackendInvalid = customerror.NewCustomError(
+		ErrCodeWebhookRateLimitBackendInvalid,
+		MsgWebhookRateLimitBackendInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrWebhookRateLimitPrefixesInvalid = customerror.NewCustomError(
+		ErrCodeWebhookRateLimitPrefixesInvalid,
+		MsgWebhookRateLimitPrefixesInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrWebhookBreakerInvalid = customerror.NewCustomError(
+		ErrCodeWebhookBreakerInvalid,
+		MsgWebhookBreakerInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrWebhookProviderInvalid = customerror.NewCustomError(
+		ErrCodeWebhookProviderInvalid,
+		MsgWebhookProviderInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrMessageMaxSegmentsInvalid = customerror.NewCustomError(
+		ErrCodeMessageMaxSegmentsInvalid,
+		MsgMessageMaxSegmentsInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrSenderModeInvalid = customerror.NewCustomError(
+		ErrCodeSenderModeInvalid,
+		MsgSenderModeInvalid,
+		http.StatusBadRequest,
+	)
+
 	ErrSenderIntervalInvalid = customerror.NewCustomError(
 		ErrCodeSenderIntervalInvalid,
 		MsgSenderIntervalInvalid,
@@ -103,4 +195,88 @@ var (
 		MsgSenderBatchSizeInvalid,
 		http.StatusBadRequest,
 	)
+
+	ErrSenderConcurrencyInvalid = customerror.NewCustomError(
+		ErrCodeSenderConcurrencyInvalid,
+		MsgSenderConcurrencyInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrSenderMaxAttemptsInvalid = customerror.NewCustomError(
+		ErrCodeSenderMaxAttemptsInvalid,
+		MsgSenderMaxAttemptsInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrSenderPrioritySharesInvalid = customerror.NewCustomError(
+		ErrCodeSenderPrioritySharesInvalid,
+		MsgSenderPrioritySharesInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrSenderBackoffInvalid = customerror.NewCustomError(
+		ErrCodeSenderBackoffInvalid,
+		MsgSenderBackoffInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrSenderBackoffMultiplierInvalid = customerror.NewCustomError(
+		ErrCodeSenderBackoffMultiplierInvalid,
+		MsgSenderBackoffMultiplierInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrSenderInstanceIDEmpty = customerror.NewCustomError(
+		ErrCodeSenderInstanceIDEmpty,
+		MsgSenderInstanceIDEmpty,
+		http.StatusBadRequest,
+	)
+
+	ErrSenderLeaseInvalid = customerror.NewCustomError(
+		ErrCodeSenderLeaseInvalid,
+		MsgSenderLeaseInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrSenderExpirySweepInvalid = customerror.NewCustomError(
+		ErrCodeSenderExpirySweepInvalid,
+		MsgSenderExpirySweepInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrSenderDrainBudgetInvalid = customerror.NewCustomError(
+		ErrCodeSenderDrainBudgetInvalid,
+		MsgSenderDrainBudgetInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrIdempotencyBackendInvalid = customerror.NewCustomError(
+		ErrCodeIdempotencyBackendInvalid,
+		MsgIdempotencyBackendInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrIdempotencyTTLInvalid = customerror.NewCustomError(
+		ErrCodeIdempotencyTTLInvalid,
+		MsgIdempotencyTTLInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrSuppressionModeInvalid = customerror.NewCustomError(
+		ErrCodeSuppressionModeInvalid,
+		MsgSuppressionModeInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrSuppressionCacheTTLInvalid = customerror.NewCustomError(
+		ErrCodeSuppressionCacheTTLInvalid,
+		MsgSuppressionCacheTTLInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrInboundKeywordsInvalid = customerror.NewCustomError(
+		ErrCodeInboundKeywordsInvalid,
+		MsgInboundKeywordsInvalid,
+		http.StatusBadRequest,
+	)
 )
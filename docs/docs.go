@@ -22,32 +22,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/health": {
-            "get": {
-                "description": "Check if the service is healthy",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "health"
-                ],
-                "summary": "Health check",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/health.Status"
-                        }
-                    }
-                }
-            }
-        },
-        "/messages": {
-            "get": {
-                "description": "Get a list of messages with pagination",
+        "/callbacks/delivery": {
+            "post": {
+                "description": "Callback for SMS providers to report whether a sent message reached the handset",
                 "consumes": [
                     "application/json"
                 ],
@@ -55,23 +32,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "messages"
+                    "callbacks"
                 ],
-                "summary": "List messages",
+                "summary": "Receive delivery receipt",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Offset",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "Delivery receipt",
+                        "name": "receipt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DeliveryReceiptRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -86,16 +58,31 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.MessageResponse"
-                                            }
+                                            "$ref": "#/definitions/dto.MessageResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -103,9 +90,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/callbacks/inbound": {
             "post": {
-                "description": "Create a new message to be sent via webhook",
+                "description": "Callback for SMS providers to deliver a reply from a recipient. Replies consisting of a stop keyword\n(STOP, UNSUBSCRIBE) suppress the sender, start keywords (START) lift an opt-out, and an auto-reply\nis queued when one is configured for the keyword.",
                 "consumes": [
                     "application/json"
                 ],
@@ -113,23 +102,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "messages"
+                    "callbacks"
                 ],
-                "summary": "Create a new message",
+                "summary": "Receive inbound message",
                 "parameters": [
                     {
-                        "description": "Message details",
+                        "description": "Inbound message",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateMessageRequest"
+                            "$ref": "#/definitions/dto.InboundMessageRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -139,7 +128,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.MessageResponse"
+                                            "$ref": "#/definitions/dto.InboundMessageResponse"
                                         }
                                     }
                                 }
@@ -161,9 +150,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/messages/sent": {
+        "/health": {
             "get": {
-                "description": "Get a list of sent messages with pagination",
+                "description": "Check if the service is healthy",
                 "consumes": [
                     "application/json"
                 ],
@@ -171,10 +160,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "messages"
+                    "health"
                 ],
-                "summary": "List sent messages",
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/health.Status"
+                        }
+                    }
+                }
+            }
+        },
+        "/inbound-messages": {
+            "get": {
+                "description": "Get the replies received from recipients, most recent first, with pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "inbound"
+                ],
+                "summary": "List inbound messages",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only replies from this phone number",
+                        "name": "phoneNumber",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
                         "default": 10,
@@ -204,7 +222,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/dto.MessageResponse"
+                                                "$ref": "#/definitions/dto.InboundMessageResponse"
                                             }
                                         }
                                     }
@@ -221,9 +239,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/messages/{id}": {
+        "/messages": {
             "get": {
-                "description": "Get a single message by its ID",
+                "description": "Get a list of messages, newest first, with filters and cursor pagination on (createdAt, id)",
                 "consumes": [
                     "application/json"
                 ],
@@ -233,14 +251,86 @@ const docTemplate = `{
                 "tags": [
                     "messages"
                 ],
-                "summary": "Get message by ID",
+                "summary": "List messages",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Message ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from meta.nextCursor or meta.prevCursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also count every matching message into meta.total",
+                        "name": "withTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Deprecated offset paging, used only without a cursor and returns no meta",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "processing",
+                            "sent",
+                            "failed",
+                            "cancelled",
+                            "expired",
+                            "suppressed",
+                            "unknown",
+                            "delivered",
+                            "undelivered"
+                        ],
+                        "type": "string",
+                        "description": "Only messages in this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only messages to this E.164 number (encode + as %2B)",
+                        "name": "phoneNumber",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC3339)",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC3339)",
+                        "name": "createdTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sent at or after (RFC3339)",
+                        "name": "sentFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sent before (RFC3339)",
+                        "name": "sentTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive search in the content",
+                        "name": "q",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -255,7 +345,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.MessageResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.MessageResponse"
+                                            }
                                         }
                                     }
                                 }
@@ -268,12 +361,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/customresponse.CustomResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/customresponse.CustomResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -282,8 +369,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Update an existing message by ID",
+            "post": {
+                "description": "Create a new message to be sent via webhook\nA recipient on the suppression list is rejected with 422 RECIPIENT_SUPPRESSED, or stored as suppressed when SUPPRESSION_MODE=suppress.",
                 "consumes": [
                     "application/json"
                 ],
@@ -293,28 +380,27 @@ const docTemplate = `{
                 "tags": [
                     "messages"
                 ],
-                "summary": "Update message",
+                "summary": "Create a new message",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Message ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "description": "Message details",
                         "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateMessageRequest"
+                            "$ref": "#/definitions/dto.CreateMessageRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the first response for retries with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "allOf": [
                                 {
@@ -337,8 +423,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/customresponse.CustomResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/customresponse.CustomResponse"
                         }
@@ -350,9 +442,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Soft delete a message by ID",
+            }
+        },
+        "/messages/batch": {
+            "post": {
+                "description": "Create up to 100 messages in one request. Every item is validated on its own and the valid ones are\nstored in a single transaction. Responds 201 when all items were created, 207 with per-item errors otherwise.",
                 "consumes": [
                     "application/json"
                 ],
@@ -362,21 +456,59 @@ const docTemplate = `{
                 "tags": [
                     "messages"
                 ],
-                "summary": "Delete message",
+                "summary": "Create messages in bulk",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Message ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Messages to create",
+                        "name": "messages",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateMessageBatchRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the first response for retries with the same key",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/customresponse.CustomResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/customresponse.CustomResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MessageBatchResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "207": {
+                        "description": "Multi-Status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/customresponse.CustomResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MessageBatchResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -385,8 +517,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/customresponse.CustomResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/customresponse.CustomResponse"
                         }
@@ -400,21 +538,148 @@ const docTemplate = `{
                 }
             }
         },
-        "/sender/start": {
-            "post": {
-                "description": "Start the message sender job manually",
-                "consumes": [
-                    "application/json"
-                ],
+        "/messages/export": {
+            "get": {
+                "description": "Stream every message matching the filters of the list endpoint as CSV or NDJSON, oldest first. The export is not paginated,\nrows are read through a database cursor and written as they arrive.",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "sender"
+                    "messages"
                 ],
-                "summary": "Start message sender",
-                "responses": {
-                    "200": {
+                "summary": "Export messages",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "processing",
+                            "sent",
+                            "failed",
+                            "cancelled",
+                            "expired",
+                            "suppressed",
+                            "unknown",
+                            "delivered",
+                            "undelivered"
+                        ],
+                        "type": "string",
+                        "description": "Only messages in this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only messages to this E.164 number (encode + as %2B)",
+                        "name": "phoneNumber",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC3339)",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC3339)",
+                        "name": "createdTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sent at or after (RFC3339)",
+                        "name": "sentFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sent before (RFC3339)",
+                        "name": "sentTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive search in the content",
+                        "name": "q",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/messages/failed": {
+            "get": {
+                "description": "Get a list of messages that exhausted their delivery attempts, most recent first, with cursor pagination on (failedAt, id)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "List failed messages",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from meta.nextCursor or meta.prevCursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also count every matching message into meta.total",
+                        "name": "withTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Deprecated offset paging, used only without a cursor and returns no meta",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
                         "description": "OK",
                         "schema": {
                             "allOf": [
@@ -425,9 +690,9 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "type": "string"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.MessageResponse"
                                             }
                                         }
                                     }
@@ -450,19 +715,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/sender/status": {
-            "get": {
-                "description": "Check if the message sender job is running",
+        "/messages/import": {
+            "post": {
+                "description": "Upload a CSV (header with phoneNumber/phone and content, optional sendAt, expiresAt, ttl, priority) or\nNDJSON file (one POST /messages body per line). Rows are validated like single creates and inserted in\nchunks, the report lists the rejected rows by line. When the import aborts, the error response\nstill carries the report of the rows created so far and the line the import stopped at.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sender"
+                    "messages"
+                ],
+                "summary": "Import messages from a file",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV or NDJSON file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "File format, guessed from the file extension when omitted",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get sender status",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -475,22 +759,31 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "type": "boolean"
-                                            }
+                                            "$ref": "#/definitions/dto.MessageImportResponse"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
                     }
                 }
             }
         },
-        "/sender/stop": {
-            "post": {
-                "description": "Stop the message sender job manually",
+        "/messages/sent": {
+            "get": {
+                "description": "Get a list of sent messages, most recently sent first, with cursor pagination on (sentAt, id)",
                 "consumes": [
                     "application/json"
                 ],
@@ -498,9 +791,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "sender"
+                    "messages"
+                ],
+                "summary": "List sent messages",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from meta.nextCursor or meta.prevCursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also count every matching message into meta.total",
+                        "name": "withTotal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Deprecated offset paging, used only without a cursor and returns no meta",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Stop message sender",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -513,9 +833,9 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "type": "string"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.MessageResponse"
                                             }
                                         }
                                     }
@@ -537,64 +857,1338 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "customresponse.CustomResponse": {
-            "type": "object",
-            "properties": {
-                "data": {},
-                "error": {
-                    "$ref": "#/definitions/customresponse.ErrorInfo"
-                },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "customresponse.ErrorInfo": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "domain.MessageStatus": {
-            "type": "string",
-            "enum": [
-                "pending",
-                "sent"
-            ],
-            "x-enum-varnames": [
-                "StatusPending",
-                "StatusSent"
-            ]
         },
-        "dto.CreateMessageRequest": {
-            "type": "object",
-            "required": [
-                "content",
-                "phoneNumber"
-            ],
-            "properties": {
-                "content": {
-                    "type": "string",
-                    "maxLength": 160,
-                    "example": "Hello World"
-                },
-                "phoneNumber": {
-                    "type": "string",
-                    "example": "+905551111111"
+        "/messages/{id}": {
+            "get": {
+                "description": "Get a single message by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "Get message by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Message ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/customresponse.CustomResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MessageResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    }
                 }
-            }
+            },
+            "put": {
+                "description": "Update a pending message by ID, it can be rescheduled with sendAt or cancelled with status \"cancelled\", a message held as \"unknown\" only takes status \"pending\" (send again) or \"cancelled\", other messages that are no longer pending are rejected",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "Update message",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Message ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Message details",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateMessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/customresponse.CustomResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MessageResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft delete a message by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "messages"
+                ],
+                "summary": "Delete message",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Message ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sender/start": {
+            "post": {
+                "description": "Start the message sender job manually",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sender"
+                ],
+                "summary": "Start message sender",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/customresponse.CustomResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sender/status": {
+            "get": {
+                "description": "Check if the message sender job is running and the webhook circuit breaker state",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sender"
+                ],
+                "summary": "Get sender status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/customresponse.CustomResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": true
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/sender/stop": {
+            "post": {
+                "description": "Stop the message sender job manually",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sender"
+                ],
+                "summary": "Stop message sender",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/customresponse.CustomResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppressions": {
+            "get": {
+                "description": "Get the suppressed phone numbers, most recent first, with pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppressions"
+                ],
+                "summary": "List suppressions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/customresponse.CustomResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.SuppressionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a phone number to the suppression list, no message is sent to it until it is removed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppressions"
+                ],
+                "summary": "Suppress a phone number",
+                "parameters": [
+                    {
+                        "description": "Suppression details",
+                        "name": "suppression",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateSuppressionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/customresponse.CustomResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SuppressionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppressions/{phoneNumber}": {
+            "get": {
+                "description": "Get the suppression of a phone number, 404 when the number is not suppressed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppressions"
+                ],
+                "summary": "Get suppression by phone number",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Phone number in E.164 format (URL encode the +)",
+                        "name": "phoneNumber",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/customresponse.CustomResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SuppressionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a phone number from the suppression list so it can receive messages again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppressions"
+                ],
+                "summary": "Remove a suppression",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Phone number in E.164 format (URL encode the +)",
+                        "name": "phoneNumber",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates": {
+            "get": {
+                "description": "Get a list of templates ordered by name with pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "List templates",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/customresponse.CustomResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.TemplateResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create reusable message content with {{variable}} placeholders",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Create a new template",
+                "parameters": [
+                    {
+                        "description": "Template details",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/customresponse.CustomResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TemplateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{id}": {
+            "get": {
+                "description": "Get a single template by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Get template by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/customresponse.CustomResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TemplateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing template by ID, messages already created from it are not changed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Update template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Template details",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/customresponse.CustomResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TemplateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft delete a template by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Delete template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/customresponse.CustomResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "customresponse.CustomResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "$ref": "#/definitions/customresponse.ErrorInfo"
+                },
+                "meta": {
+                    "$ref": "#/definitions/customresponse.Meta"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "customresponse.ErrorInfo": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "customresponse.Meta": {
+            "type": "object",
+            "properties": {
+                "hasNext": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "hasPrev": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "description": "Pass as cursor to get the next page",
+                    "type": "string",
+                    "example": "eyJrIjoiY3JlYXRlZF9hdCJ9"
+                },
+                "prevCursor": {
+                    "description": "Pass as cursor to get the previous page",
+                    "type": "string",
+                    "example": "eyJrIjoiY3JlYXRlZF9hdCJ9"
+                },
+                "total": {
+                    "description": "Only when requested",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "domain.InboundAction": {
+            "type": "string",
+            "enum": [
+                "none",
+                "stop",
+                "start",
+                "help"
+            ],
+            "x-enum-comments": {
+                "InboundActionHelp": "The sender asked for help",
+                "InboundActionNone": "No keyword matched, the reply is only stored",
+                "InboundActionStart": "The opt-out of the sender was removed",
+                "InboundActionStop": "The sender was added to the suppression list"
+            },
+            "x-enum-descriptions": [
+                "No keyword matched, the reply is only stored",
+                "The sender was added to the suppression list",
+                "The opt-out of the sender was removed",
+                "The sender asked for help"
+            ],
+            "x-enum-varnames": [
+                "InboundActionNone",
+                "InboundActionStop",
+                "InboundActionStart",
+                "InboundActionHelp"
+            ]
+        },
+        "domain.MessagePriority": {
+            "type": "string",
+            "enum": [
+                "high",
+                "normal",
+                "low"
+            ],
+            "x-enum-varnames": [
+                "PriorityHigh",
+                "PriorityNormal",
+                "PriorityLow"
+            ]
+        },
+        "domain.MessageStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "processing",
+                "sent",
+                "failed",
+                "cancelled",
+                "expired",
+                "suppressed",
+                "unknown",
+                "delivered",
+                "undelivered"
+            ],
+            "x-enum-comments": {
+                "StatusSuppressed": "The recipient is on the suppression list, never sent",
+                "StatusUnknown": "A webhook call was interrupted, held for review instead of risking a duplicate"
+            },
+            "x-enum-descriptions": [
+                "",
+                "",
+                "",
+                "",
+                "",
+                "",
+                "The recipient is on the suppression list, never sent",
+                "A webhook call was interrupted, held for review instead of risking a duplicate",
+                "",
+                ""
+            ],
+            "x-enum-varnames": [
+                "StatusPending",
+                "StatusProcessing",
+                "StatusSent",
+                "StatusFailed",
+                "StatusCancelled",
+                "StatusExpired",
+                "StatusSuppressed",
+                "StatusUnknown",
+                "StatusDelivered",
+                "StatusUndelivered"
+            ]
+        },
+        "domain.SuppressionReason": {
+            "type": "string",
+            "enum": [
+                "opt_out",
+                "complaint",
+                "manual"
+            ],
+            "x-enum-comments": {
+                "SuppressionReasonComplaint": "The recipient reported the messages as unwanted",
+                "SuppressionReasonManual": "Added by an operator",
+                "SuppressionReasonOptOut": "The recipient asked to be unsubscribed"
+            },
+            "x-enum-descriptions": [
+                "The recipient asked to be unsubscribed",
+                "The recipient reported the messages as unwanted",
+                "Added by an operator"
+            ],
+            "x-enum-varnames": [
+                "SuppressionReasonOptOut",
+                "SuppressionReasonComplaint",
+                "SuppressionReasonManual"
+            ]
+        },
+        "dto.CreateMessageBatchRequest": {
+            "type": "object",
+            "required": [
+                "messages"
+            ],
+            "properties": {
+                "messages": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dto.CreateMessageRequest"
+                    }
+                }
+            }
+        },
+        "dto.CreateMessageRequest": {
+            "type": "object",
+            "required": [
+                "phoneNumber"
+            ],
+            "properties": {
+                "content": {
+                    "description": "Split into segments, at most MESSAGE_MAX_SEGMENTS",
+                    "type": "string",
+                    "example": "Hello World"
+                },
+                "expiresAt": {
+                    "description": "Not sent after this time (RFC3339)",
+                    "type": "string",
+                    "example": "2025-11-09T12:05:00Z"
+                },
+                "phoneNumber": {
+                    "type": "string",
+                    "example": "+905551111111"
+                },
+                "priority": {
+                    "description": "Sending lane, defaults to normal",
+                    "enum": [
+                        "high",
+                        "normal",
+                        "low"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.MessagePriority"
+                        }
+                    ],
+                    "example": "high"
+                },
+                "sendAt": {
+                    "description": "Not sent before this time (RFC3339)",
+                    "type": "string",
+                    "example": "2025-11-09T12:00:00Z"
+                },
+                "templateId": {
+                    "description": "Renders the content from a template instead",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "ttl": {
+                    "description": "Validity in seconds from sendAt or creation, instead of expiresAt",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 300
+                },
+                "variables": {
+                    "description": "Values for the template placeholders",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.CreateSuppressionRequest": {
+            "type": "object",
+            "required": [
+                "phoneNumber"
+            ],
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Asked to unsubscribe by phone"
+                },
+                "phoneNumber": {
+                    "type": "string",
+                    "example": "+905551111111"
+                },
+                "reason": {
+                    "description": "Defaults to manual",
+                    "enum": [
+                        "opt_out",
+                        "complaint",
+                        "manual"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.SuppressionReason"
+                        }
+                    ],
+                    "example": "opt_out"
+                }
+            }
+        },
+        "dto.CreateTemplateRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "name"
+            ],
+            "properties": {
+                "content": {
+                    "description": "Placeholders are written as {{variable}}",
+                    "type": "string",
+                    "example": "Hi {{name}}, your code is {{code}}"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "otp"
+                }
+            }
+        },
+        "dto.DeliveryReceiptRequest": {
+            "type": "object",
+            "required": [
+                "messageId",
+                "status"
+            ],
+            "properties": {
+                "errorCode": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "ABSENT_SUBSCRIBER"
+                },
+                "messageId": {
+                    "type": "string",
+                    "example": "67f2f8a8-ea58-4ed0-a6f9-ff217df4d849"
+                },
+                "status": {
+                    "enum": [
+                        "delivered",
+                        "undelivered"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.MessageStatus"
+                        }
+                    ],
+                    "example": "delivered"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2025-11-09T10:30:05Z"
+                }
+            }
+        },
+        "dto.InboundMessageRequest": {
+            "type": "object",
+            "required": [
+                "content",
+                "from"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "maxLength": 1600,
+                    "example": "STOP"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "+905551111111"
+                },
+                "messageId": {
+                    "description": "Repeated deliveries with the same ID are ignored",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "b7e2c1d4-3f5a-4e8b-9c6d-1a2b3c4d5e6f"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2025-11-09T10:30:05Z"
+                },
+                "to": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "+905550000000"
+                }
+            }
+        },
+        "dto.InboundMessageResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.InboundAction"
+                        }
+                    ],
+                    "example": "stop"
+                },
+                "content": {
+                    "type": "string",
+                    "example": "STOP"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "keyword": {
+                    "type": "string",
+                    "example": "STOP"
+                },
+                "messageId": {
+                    "type": "string",
+                    "example": "b7e2c1d4-3f5a-4e8b-9c6d-1a2b3c4d5e6f"
+                },
+                "phoneNumber": {
+                    "type": "string",
+                    "example": "+905551111111"
+                },
+                "receivedAt": {
+                    "type": "string",
+                    "example": "2025-11-09T10:30:05Z"
+                },
+                "replyMessageId": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "to": {
+                    "type": "string",
+                    "example": "+905550000000"
+                }
+            }
+        },
+        "dto.MessageBatchItemResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Why the item was rejected",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/customresponse.ErrorInfo"
+                        }
+                    ]
+                },
+                "id": {
+                    "description": "ID of the created message",
+                    "type": "integer",
+                    "example": 1
+                },
+                "index": {
+                    "description": "Position of the item in the request",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "dto.MessageBatchResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MessageBatchItemResponse"
+                    }
+                }
+            }
+        },
+        "dto.MessageImportResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "errors": {
+                    "description": "At most the first 1000 rejected rows",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MessageImportRowError"
+                    }
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "rows": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "stoppedAt": {
+                    "description": "Line an aborted import stopped at, rows from that line on were not imported",
+                    "type": "integer",
+                    "example": 201
+                }
+            }
+        },
+        "dto.MessageImportRowError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/customresponse.ErrorInfo"
+                },
+                "line": {
+                    "description": "Line of the row in the file, the CSV header is line 1",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
         },
         "dto.MessageResponse": {
             "type": "object",
             "properties": {
+                "attemptCount": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "content": {
                     "type": "string",
                     "example": "Hello"
@@ -603,18 +2197,74 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2025-11-09T10:00:00Z"
                 },
+                "encoding": {
+                    "type": "string",
+                    "example": "gsm7"
+                },
+                "expiresAt": {
+                    "type": "string",
+                    "example": "2025-11-09T12:05:00Z"
+                },
+                "failedAt": {
+                    "type": "string",
+                    "example": "2025-11-09T10:30:00Z"
+                },
                 "id": {
                     "type": "integer",
                     "example": 1
                 },
+                "lastAttemptAt": {
+                    "type": "string",
+                    "example": "2025-11-09T10:30:00Z"
+                },
+                "lastErrorCode": {
+                    "type": "string",
+                    "example": "WEBHOOK_SERVER_ERROR"
+                },
+                "lastErrorMessage": {
+                    "type": "string",
+                    "example": "[WEBHOOK_SERVER_ERROR] Webhook server error: status: 503"
+                },
                 "messageId": {
                     "type": "string",
                     "example": "67f2f8a8-ea58-4ed0-a6f9-ff217df4d849"
                 },
+                "nextAttemptAt": {
+                    "type": "string",
+                    "example": "2025-11-09T10:31:00Z"
+                },
                 "phoneNumber": {
                     "type": "string",
                     "example": "+905551111111"
                 },
+                "priority": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.MessagePriority"
+                        }
+                    ],
+                    "example": "normal"
+                },
+                "provider": {
+                    "type": "string",
+                    "example": "primary"
+                },
+                "receiptAt": {
+                    "type": "string",
+                    "example": "2025-11-09T10:30:05Z"
+                },
+                "receiptErrorCode": {
+                    "type": "string",
+                    "example": "ABSENT_SUBSCRIBER"
+                },
+                "segments": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "sendAt": {
+                    "type": "string",
+                    "example": "2025-11-09T12:00:00Z"
+                },
                 "sentAt": {
                     "type": "string",
                     "example": "2025-11-09T10:30:00Z"
@@ -627,32 +2277,120 @@ const docTemplate = `{
                     ],
                     "example": "pending"
                 },
+                "templateId": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "updatedAt": {
                     "type": "string",
                     "example": "2025-11-09T10:00:00Z"
                 }
             }
         },
-        "dto.UpdateMessageRequest": {
+        "dto.SuppressionResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-11-09T10:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Asked to unsubscribe by phone"
+                },
+                "phoneNumber": {
+                    "type": "string",
+                    "example": "+905551111111"
+                },
+                "reason": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.SuppressionReason"
+                        }
+                    ],
+                    "example": "opt_out"
+                }
+            }
+        },
+        "dto.TemplateResponse": {
             "type": "object",
             "properties": {
                 "content": {
                     "type": "string",
-                    "maxLength": 160
+                    "example": "Hi {{name}}, your code is {{code}}"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-11-09T10:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "otp"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2025-11-09T10:00:00Z"
+                },
+                "variables": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "name",
+                        "code"
+                    ]
+                }
+            }
+        },
+        "dto.UpdateMessageRequest": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
                 },
                 "phoneNumber": {
                     "type": "string"
                 },
+                "sendAt": {
+                    "description": "Reschedules a pending message",
+                    "type": "string",
+                    "example": "2025-11-09T12:00:00Z"
+                },
                 "status": {
+                    "description": "Sent and failed are only set by the sender",
                     "enum": [
                         "pending",
-                        "sent"
+                        "cancelled"
                     ],
                     "allOf": [
                         {
                             "$ref": "#/definitions/domain.MessageStatus"
                         }
-                    ]
+                    ],
+                    "example": "cancelled"
+                }
+            }
+        },
+        "dto.UpdateTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
                 }
             }
         },
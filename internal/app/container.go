@@ -2,21 +2,34 @@ package app
 
 import (
 	"context"
+	"net/http"
+	"time"
 
 	"gorm.io/gorm"
 
 	"github.com/srcndev/message-service/config"
+	"github.com/srcndev/message-service/internal/domain"
 	"github.com/srcndev/message-service/internal/handler"
 	"github.com/srcndev/message-service/internal/job"
 	"github.com/srcndev/message-service/internal/repository"
 	"github.com/srcndev/message-service/internal/service"
+	"github.com/srcndev/message-service/pkg/backoff"
+	"github.com/srcndev/message-service/pkg/circuitbreaker"
 	"github.com/srcndev/message-service/pkg/database"
 	"github.com/srcndev/message-service/pkg/health"
+	"github.com/srcndev/message-service/pkg/httpclient"
+	"github.com/srcndev/message-service/pkg/idempotency"
 	"github.com/srcndev/message-service/pkg/logger"
+	"github.com/srcndev/message-service/pkg/middleware"
+	"github.com/srcndev/message-service/pkg/provider"
+	"github.com/srcndev/message-service/pkg/ratelimit"
 	"github.com/srcndev/message-service/pkg/redis"
 	"github.com/srcndev/message-service/pkg/webhook"
 )
 
+// webhookAuthHeader carries the webhook API key and is redacted from request logs
+const webhookAuthHeader = "x-ins-auth-key"
+
 // Container holds all application dependencies
 type Container struct {
 	Config      *config.Config
@@ -24,24 +37,45 @@ type Container struct {
 	RedisClient redis.Client
 
 	// Repositories
-	MessageRepo      repository.MessageRepository
-	MessageCacheRepo repository.MessageCacheRepository
+	MessageRepo          repository.MessageRepository
+	MessageCacheRepo     repository.MessageCacheRepository
+	TemplateRepo         repository.TemplateRepository
+	SendIntentRepo       repository.SendIntentRepository
+	SuppressionRepo      repository.SuppressionRepository
+	SuppressionCacheRepo repository.SuppressionCacheRepository
+	InboundMessageRepo   repository.InboundMessageRepository
 
 	// Services
-	HealthService        health.Service
-	MessageService       service.MessageService
-	MessageSenderService service.MessageSenderService
+	HealthService          health.Service
+	MessageService         service.MessageService
+	MessageSenderService   service.MessageSenderService
+	DeliveryReceiptService service.DeliveryReceiptService
+	TemplateService        service.TemplateService
+	MessageImportService   service.MessageImportService
+	SuppressionService     service.SuppressionService
+	InboundMessageService  service.InboundMessageService
 
 	// Jobs
 	MessageSenderJob job.MessageSenderJob
+	LeaseReaperJob   job.LeaseReaperJob
+	ExpirySweeperJob job.ExpirySweeperJob
 
 	// Handlers
-	HealthHandler        health.Handler
-	MessageHandler       handler.MessageHandler
-	MessageSenderHandler handler.MessageSenderHandler
+	HealthHandler          health.Handler
+	MessageHandler         handler.MessageHandler
+	MessageSenderHandler   handler.MessageSenderHandler
+	DeliveryReceiptHandler handler.DeliveryReceiptHandler
+	TemplateHandler        handler.TemplateHandler
+	MessageImportHandler   handler.MessageImportHandler
+	SuppressionHandler     handler.SuppressionHandler
+	InboundMessageHandler  handler.InboundMessageHandler
 
 	// Clients
-	WebhookClient webhook.Client
+	WebhookClient  webhook.Client
+	WebhookBreaker circuitbreaker.Breaker
+
+	// Stores
+	IdempotencyStore idempotency.Store
 }
 
 // NewContainer creates and wires all dependencies
@@ -83,6 +117,7 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 
 	// Wire dependencies
 	container.setupClients()
+	container.setupStores()
 	container.setupRepositories()
 	container.setupServices()
 	container.setupHandlers()
@@ -95,53 +130,224 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 
 // setupClients initializes all external clients
 func (c *Container) setupClients() {
-	webhookClient := webhook.NewWebhookClient(webhook.Config{
-		URL:        c.Config.Webhook.URL,
-		AuthKey:    c.Config.Webhook.AuthKey,
+	// Each provider gets its own webhook client, the router picks one per message and fails over
+	entries := make([]provider.Entry, 0, len(c.Config.Webhook.Providers))
+	for _, p := range c.Config.Webhook.Providers {
+		entries = append(entries, provider.Entry{
+			Provider: provider.NewProvider(p.Name, c.newWebhookClient(p)),
+			Route: provider.Route{
+				Prefixes: p.Prefixes,
+				Priority: p.Priority,
+				Weight:   p.Weight,
+			},
+		})
+	}
+	logger.Info("SMS provider routing configured with %d provider(s)", len(entries))
+
+	// The breaker sits outside the limiter, so calls it rejects never take a shared quota token
+	c.WebhookClient = c.withCircuitBreaker(c.withRateLimit(provider.NewRouter(entries...)))
+}
+
+// newWebhookClient creates the webhook client of one SMS provider
+func (c *Container) newWebhookClient(p config.ProviderConfig) webhook.Client {
+	return webhook.NewWebhookClient(webhook.Config{
+		URL:        p.URL,
+		AuthKey:    p.AuthKey,
 		Timeout:    c.Config.Webhook.Timeout,
 		MaxRetries: c.Config.Webhook.MaxRetries,
+
+		RetryMaxElapsed:    c.Config.Webhook.RetryMaxElapsed,
+		RetryNonIdempotent: c.Config.Webhook.RetryNonIdempotent,
+
+		Interceptors: []httpclient.Interceptor{
+			httpclient.CorrelationIDInterceptor(),
+			httpclient.TimingInterceptor(func(req *http.Request, statusCode int, duration time.Duration, err error) {
+				logger.Debug("Webhook call to provider %s %s %s took %v (status: %d)", p.Name, req.Method, req.URL.Redacted(), duration, statusCode)
+			}),
+			httpclient.LoggingInterceptor(webhookAuthHeader),
+		},
+	})
+}
+
+// withCircuitBreaker wraps the webhook client with a circuit breaker when enabled
+func (c *Container) withCircuitBreaker(webhookClient webhook.Client) webhook.Client {
+	cfg := c.Config.Webhook
+	if cfg.BreakerFailureThreshold <= 0 {
+		return webhookClient
+	}
+
+	c.WebhookBreaker = circuitbreaker.NewBreaker(circuitbreaker.Config{
+		FailureThreshold: cfg.BreakerFailureThreshold,
+		CoolDown:         cfg.BreakerCoolDown,
 	})
-	c.WebhookClient = webhookClient
+	return webhook.NewCircuitBreakerClient(webhookClient, c.WebhookBreaker)
+}
+
+// withRateLimit wraps the webhook client with the configured outbound rate limits
+func (c *Container) withRateLimit(webhookClient webhook.Client) webhook.Client {
+	cfg := c.Config.Webhook
+	if cfg.RateLimit <= 0 && len(cfg.PrefixRateLimits) == 0 {
+		return webhookClient
+	}
+
+	backend := config.RateLimitBackendMemory
+	limiter := ratelimit.NewMemoryLimiter()
+	if cfg.RateLimitBackend == config.RateLimitBackendRedis {
+		if c.RedisClient != nil {
+			backend = config.RateLimitBackendRedis
+			limiter = ratelimit.NewRedisLimiter(c.RedisClient, "ratelimit:webhook:")
+		} else {
+			logger.Error("Redis rate limit backend requested but Redis is not available (using in-memory limiter)")
+		}
+	}
+
+	prefixes := make(map[string]ratelimit.Limit, len(cfg.PrefixRateLimits))
+	for prefix, rate := range cfg.PrefixRateLimits {
+		prefixes[prefix] = ratelimit.Limit{Rate: rate, Burst: cfg.RateLimitBurst}
+	}
+
+	logger.Info("Webhook rate limiting enabled (%s backend, %.2f msg/s, %d prefix limits)", backend, cfg.RateLimit, len(prefixes))
+	return webhook.NewRateLimitedClient(webhookClient, limiter, webhook.RateLimitConfig{
+		Global:   ratelimit.Limit{Rate: cfg.RateLimit, Burst: cfg.RateLimitBurst},
+		Prefixes: prefixes,
+	})
+}
+
+// setupStores initializes the stores shared by all replicas
+func (c *Container) setupStores() {
+	backend := config.IdempotencyBackendPostgres
+	c.IdempotencyStore = idempotency.NewPostgresStore(c.DB)
+	if c.Config.Idempotency.Backend == config.IdempotencyBackendRedis {
+		if c.RedisClient != nil {
+			backend = config.IdempotencyBackendRedis
+			c.IdempotencyStore = idempotency.NewRedisStore(c.RedisClient, "idempotency:")
+		} else {
+			logger.Error("Redis idempotency backend requested but Redis is not available (using Postgres)")
+		}
+	}
+	logger.Info("Idempotency keys stored in %s (replayed for %v)", backend, c.Config.Idempotency.TTL)
 }
 
 // setupRepositories initializes all repositories
 func (c *Container) setupRepositories() {
 	c.MessageRepo = repository.NewMessageRepository(c.DB)
+	c.TemplateRepo = repository.NewTemplateRepository(c.DB)
+	c.SendIntentRepo = repository.NewSendIntentRepository(c.DB)
+	c.SuppressionRepo = repository.NewSuppressionRepository(c.DB)
+	c.InboundMessageRepo = repository.NewInboundMessageRepository(c.DB)
 
-	// Initialize cache repository if Redis is enabled
+	// Initialize cache repositories if Redis is enabled
 	if c.Config.Redis.Enabled && c.RedisClient != nil {
 		c.MessageCacheRepo = repository.NewMessageCacheRepository(c.RedisClient)
+		c.SuppressionCacheRepo = repository.NewSuppressionCacheRepository(c.RedisClient, c.Config.Suppression.CacheTTL)
 	}
 }
 
 // setupServices initializes all services
 func (c *Container) setupServices() {
 	c.HealthService = health.NewHealthService()
-	c.MessageService = service.NewMessageService(c.MessageRepo)
+	c.TemplateService = service.NewTemplateService(c.TemplateRepo)
+	c.SuppressionService = service.NewSuppressionService(c.SuppressionRepo, c.SuppressionCacheRepo)
+	c.MessageService = service.NewMessageService(
+		c.MessageRepo,
+		service.WithMaxSegments(c.Config.Message.MaxSegments),
+		service.WithTemplates(c.TemplateService),
+		service.WithSuppressions(c.SuppressionService, c.Config.Suppression.Mode == config.SuppressionModeSuppress),
+	)
+	c.DeliveryReceiptService = service.NewDeliveryReceiptService(c.MessageRepo, c.MessageCacheRepo)
+	c.MessageImportService = service.NewMessageImportService(c.MessageService)
+	c.InboundMessageService = service.NewInboundMessageService(
+		c.InboundMessageRepo,
+		c.SuppressionService,
+		c.MessageService,
+		service.WithKeywords(domain.InboundActionStop, c.Config.Inbound.StopKeywords, c.Config.Inbound.StopReply),
+		service.WithKeywords(domain.InboundActionStart, c.Config.Inbound.StartKeywords, c.Config.Inbound.StartReply),
+		service.WithKeywords(domain.InboundActionHelp, c.Config.Inbound.HelpKeywords, c.Config.Inbound.HelpReply),
+	)
+	senderOpts := []service.MessageSenderOption{
+		service.WithMaxAttempts(c.Config.MessageSender.MaxAttempts),
+		service.WithBackoff(backoff.NewPolicy(
+			c.Config.MessageSender.BackoffBase,
+			c.Config.MessageSender.BackoffMax,
+			c.Config.MessageSender.BackoffMultiplier,
+		)),
+		service.WithInstanceID(c.Config.MessageSender.InstanceID),
+		service.WithLeaseDuration(c.Config.MessageSender.LeaseDuration),
+		service.WithConcurrency(c.Config.MessageSender.Concurrency),
+		service.WithPriorityShares(c.Config.MessageSender.PriorityShares),
+		service.WithSendIntents(c.SendIntentRepo),
+		service.WithSuppressionCheck(c.SuppressionService),
+	}
+	if c.WebhookBreaker != nil {
+		senderOpts = append(senderOpts, service.WithCircuitBreaker(c.WebhookBreaker))
+	}
 	c.MessageSenderService = service.NewMessageSenderService(
 		c.MessageService,
 		c.MessageCacheRepo,
 		c.WebhookClient,
 		c.Config.MessageSender.BatchSize,
 		c.Config.Redis.Enabled,
+		senderOpts...,
 	)
 
-	// Create scheduler job
+	// Create scheduler job, drain mode keeps sending full batches within a tick
+	var jobOpts []job.MessageSenderJobOption
+	if c.Config.MessageSender.Mode == config.SenderModeDrain {
+		jobOpts = append(jobOpts, job.WithDrain(
+			c.Config.MessageSender.DrainMaxMessages,
+			c.Config.MessageSender.DrainMaxDuration,
+		))
+	}
+
 	messageSenderJob, err := job.NewMessageSenderJob(
 		c.MessageSenderService,
 		c.Config.MessageSender.Interval,
+		jobOpts...,
 	)
 	if err != nil {
 		logger.Fatal("Failed to create message sender job: %v", err)
 	}
 	c.MessageSenderJob = messageSenderJob
+
+	// Create lease reaper job for messages stranded by crashed instances
+	leaseReaperJob, err := job.NewLeaseReaperJob(
+		c.MessageService,
+		c.MessageSenderService,
+		c.Config.MessageSender.LeaseReaperInterval,
+	)
+	if err != nil {
+		logger.Fatal("Failed to create lease reaper job: %v", err)
+	}
+	c.LeaseReaperJob = leaseReaperJob
+
+	// Create expiry sweeper job, it runs independently of the sender so stale messages expire while it is stopped
+	expirySweeperJob, err := job.NewExpirySweeperJob(
+		c.MessageService,
+		c.Config.MessageSender.ExpirySweepInterval,
+	)
+	if err != nil {
+		logger.Fatal("Failed to create expiry sweeper job: %v", err)
+	}
+	c.ExpirySweeperJob = expirySweeperJob
 }
 
 // setupHandlers initializes all HTTP handlers
 func (c *Container) setupHandlers() {
 	c.HealthHandler = health.NewHealthHandler(c.HealthService)
-	c.MessageHandler = handler.NewMessageHandler(c.MessageService)
-	c.MessageSenderHandler = handler.NewMessageSenderHandler(c.MessageSenderJob)
+	// Only creating messages is worth replaying, other endpoints are safe to retry or take large uploads
+	c.MessageHandler = handler.NewMessageHandler(c.MessageService, handler.WithCreateMiddleware(
+		middleware.Idempotency(middleware.IdempotencyConfig{
+			Store:   c.IdempotencyStore,
+			TTL:     c.Config.Idempotency.TTL,
+			LockTTL: c.Config.Idempotency.LockTTL,
+		}),
+	))
+	c.MessageSenderHandler = handler.NewMessageSenderHandler(c.MessageSenderJob, c.WebhookBreaker)
+	c.DeliveryReceiptHandler = handler.NewDeliveryReceiptHandler(c.DeliveryReceiptService)
+	c.TemplateHandler = handler.NewTemplateHandler(c.TemplateService)
+	c.MessageImportHandler = handler.NewMessageImportHandler(c.MessageImportService)
+	c.SuppressionHandler = handler.NewSuppressionHandler(c.SuppressionService)
+	c.InboundMessageHandler = handler.NewInboundMessageHandler(c.InboundMessageService)
 }
 
 // StartJobs starts all background jobs
@@ -151,10 +357,26 @@ func (c *Container) StartJobs() error {
 	// Use background context for the job lifecycle
 	ctx := context.Background()
 
+	// Reconcile webhook calls a previous run died in before new ones are made
+	if report, err := c.MessageSenderService.RecoverSendIntents(ctx); err != nil {
+		logger.Error("Failed to recover in-flight send intents: %v", err)
+	} else if report.InFlight > 0 {
+		logger.Info("Recovered %d in-flight send intents (closed: %d, held as unknown: %d, held by other instances: %d)",
+			report.InFlight, report.Closed, report.Unknown, report.Held)
+	}
+
 	if err := c.MessageSenderJob.Start(ctx); err != nil {
 		return err
 	}
 
+	if err := c.LeaseReaperJob.Start(ctx); err != nil {
+		return err
+	}
+
+	if err := c.ExpirySweeperJob.Start(ctx); err != nil {
+		return err
+	}
+
 	logger.Info("Background jobs started successfully")
 	return nil
 }
@@ -170,6 +392,18 @@ func (c *Container) Close() error {
 		}
 	}
 
+	if c.LeaseReaperJob != nil && c.LeaseReaperJob.IsRunning() {
+		if err := c.LeaseReaperJob.Stop(context.Background()); err != nil {
+			logger.Error("Failed to stop lease reaper job: %v", err)
+		}
+	}
+
+	if c.ExpirySweeperJob != nil && c.ExpirySweeperJob.IsRunning() {
+		if err := c.ExpirySweeperJob.Stop(context.Background()); err != nil {
+			logger.Error("Failed to stop expiry sweeper job: %v", err)
+		}
+	}
+
 	// Close Redis connection if exists
 	if c.RedisClient != nil {
 		if err := c.RedisClient.Close(); err != nil {
@@ -13,6 +13,9 @@ import (
 func (a *App) setupRouter() {
 	router := gin.Default()
 
+	// Correlation ID for tracing a request through logs and outgoing calls
+	router.Use(middleware.CorrelationID())
+
 	// Global error handler middleware
 	router.Use(middleware.ErrorHandler())
 
@@ -42,6 +45,11 @@ func (a *App) setupRouter() {
 	{
 		a.container.MessageHandler.RegisterRoutes(v1)
 		a.container.MessageSenderHandler.RegisterRoutes(v1)
+		a.container.DeliveryReceiptHandler.RegisterRoutes(v1)
+		a.container.TemplateHandler.RegisterRoutes(v1)
+		a.container.MessageImportHandler.RegisterRoutes(v1)
+		a.container.SuppressionHandler.RegisterRoutes(v1)
+		a.container.InboundMessageHandler.RegisterRoutes(v1)
 	}
 
 	a.router = router
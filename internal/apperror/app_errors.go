@@ -8,18 +8,22 @@ import (
 
 // Error codes for application lifecycle
 const (
-	ErrCodeContainerInitFailed = "CONTAINER_INIT_FAILED"
-	ErrCodeServerStartFailed   = "SERVER_START_FAILED"
-	ErrCodeServerStopFailed    = "SERVER_STOP_FAILED"
-	ErrCodeSchedulerInitFailed = "SCHEDULER_INIT_FAILED"
+	ErrCodeContainerInitFailed     = "CONTAINER_INIT_FAILED"
+	ErrCodeServerStartFailed       = "SERVER_START_FAILED"
+	ErrCodeServerStopFailed        = "SERVER_STOP_FAILED"
+	ErrCodeSchedulerInitFailed     = "SCHEDULER_INIT_FAILED"
+	ErrCodeLeaseReaperInitFailed   = "LEASE_REAPER_INIT_FAILED"
+	ErrCodeExpirySweeperInitFailed = "EXPIRY_SWEEPER_INIT_FAILED"
 )
 
 // Error messages
 const (
-	MsgContainerInitFailed = "Failed to initialize application container"
-	MsgServerStartFailed   = "Failed to start HTTP server"
-	MsgServerStopFailed    = "Failed to stop HTTP server gracefully"
-	MsgSchedulerInitFailed = "Failed to initialize message sender job"
+	MsgContainerInitFailed     = "Failed to initialize application container"
+	MsgServerStartFailed       = "Failed to start HTTP server"
+	MsgServerStopFailed        = "Failed to stop HTTP server gracefully"
+	MsgSchedulerInitFailed     = "Failed to initialize message sender job"
+	MsgLeaseReaperInitFailed   = "Failed to initialize lease reaper job"
+	MsgExpirySweeperInitFailed = "Failed to initialize message expiry sweeper job"
 )
 
 // Predefined errors
@@ -47,4 +51,16 @@ var (
 		MsgSchedulerInitFailed,
 		http.StatusInternalServerError,
 	)
+
+	ErrLeaseReaperInitFailed = customerror.NewCustomError(
+		ErrCodeLeaseReaperInitFailed,
+		MsgLeaseReaperInitFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrExpirySweeperInitFailed = customerror.NewCustomError(
+		ErrCodeExpirySweeperInitFailed,
+		MsgExpirySweeperInitFailed,
+		http.StatusInternalServerError,
+	)
 )
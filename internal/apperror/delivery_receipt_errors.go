@@ -0,0 +1,26 @@
+package apperror
+
+import (
+	"net/http"
+
+	"github.com/srcndev/message-service/pkg/customerror"
+)
+
+// Error codes for delivery receipts
+const (
+	ErrCodeReceiptInvalidTransition = "RECEIPT_INVALID_TRANSITION"
+)
+
+// Error messages
+const (
+	MsgReceiptInvalidTransition = "Delivery receipt does not apply to the message in its current status"
+)
+
+// Predefined errors
+var (
+	ErrReceiptInvalidTransition = customerror.NewCustomError(
+		ErrCodeReceiptInvalidTransition,
+		MsgReceiptInvalidTransition,
+		http.StatusConflict,
+	)
+)
@@ -0,0 +1,34 @@
+package apperror
+
+import (
+	"net/http"
+
+	"github.com/srcndev/message-service/pkg/customerror"
+)
+
+// Error codes
+const (
+	ErrCodeInboundMessageCreateFailed = "INBOUND_MESSAGE_CREATE_FAILED"
+	ErrCodeInboundMessageListFailed   = "INBOUND_MESSAGE_LIST_FAILED"
+)
+
+// Error messages
+const (
+	MsgInboundMessageCreateFailed = "Failed to store inbound message"
+	MsgInboundMessageListFailed   = "Failed to list inbound messages"
+)
+
+// Predefined errors
+var (
+	ErrInboundMessageCreateFailed = customerror.NewCustomError(
+		ErrCodeInboundMessageCreateFailed,
+		MsgInboundMessageCreateFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrInboundMessageListFailed = customerror.NewCustomError(
+		ErrCodeInboundMessageListFailed,
+		MsgInboundMessageListFailed,
+		http.StatusInternalServerError,
+	)
+)
@@ -13,6 +13,13 @@ const (
 	ErrCodeMessageUpdateFailed = "MESSAGE_UPDATE_FAILED"
 	ErrCodeMessageDeleteFailed = "MESSAGE_DELETE_FAILED"
 	ErrCodeMessageListFailed   = "MESSAGE_LIST_FAILED"
+	ErrCodeMessageExportFailed = "MESSAGE_EXPORT_FAILED"
+	ErrCodeSendAtInPast        = "SEND_AT_IN_PAST"
+	ErrCodeMessageNotPending   = "MESSAGE_NOT_PENDING"
+	ErrCodeMessageUnknown      = "MESSAGE_OUTCOME_UNKNOWN"
+	ErrCodeExpiresAtInvalid    = "EXPIRES_AT_INVALID"
+	ErrCodeMessageTooLong      = "MESSAGE_TOO_LONG"
+	ErrCodeInvalidCursor       = "INVALID_CURSOR"
 )
 
 // Error messages
@@ -22,6 +29,13 @@ const (
 	MsgMessageUpdateFailed = "Failed to update message"
 	MsgMessageDeleteFailed = "Failed to delete message"
 	MsgMessageListFailed   = "Failed to list messages"
+	MsgMessageExportFailed = "Failed to export messages"
+	MsgSendAtInPast        = "sendAt cannot be in the past"
+	MsgMessageNotPending   = "Only pending messages can be updated"
+	MsgMessageUnknown      = "The outcome of the last send is unknown, the message can only be set to pending or cancelled"
+	MsgExpiresAtInvalid    = "expiresAt must be after sendAt and the current time, and cannot be combined with ttl"
+	MsgMessageTooLong      = "Message content exceeds the maximum number of SMS segments"
+	MsgInvalidCursor       = "Pagination cursor is invalid or belongs to another list"
 )
 
 // Predefined errors
@@ -55,4 +69,46 @@ var (
 		MsgMessageListFailed,
 		http.StatusInternalServerError,
 	)
+
+	ErrMessageExportFailed = customerror.NewCustomError(
+		ErrCodeMessageExportFailed,
+		MsgMessageExportFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrSendAtInPast = customerror.NewCustomError(
+		ErrCodeSendAtInPast,
+		MsgSendAtInPast,
+		http.StatusBadRequest,
+	)
+
+	ErrMessageNotPending = customerror.NewCustomError(
+		ErrCodeMessageNotPending,
+		MsgMessageNotPending,
+		http.StatusConflict,
+	)
+
+	ErrMessageUnknown = customerror.NewCustomError(
+		ErrCodeMessageUnknown,
+		MsgMessageUnknown,
+		http.StatusConflict,
+	)
+
+	ErrExpiresAtInvalid = customerror.NewCustomError(
+		ErrCodeExpiresAtInvalid,
+		MsgExpiresAtInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrMessageTooLong = customerror.NewCustomError(
+		ErrCodeMessageTooLong,
+		MsgMessageTooLong,
+		http.StatusBadRequest,
+	)
+
+	ErrInvalidCursor = customerror.NewCustomError(
+		ErrCodeInvalidCursor,
+		MsgInvalidCursor,
+		http.StatusBadRequest,
+	)
 )
@@ -0,0 +1,42 @@
+package apperror
+
+import (
+	"net/http"
+
+	"github.com/srcndev/message-service/pkg/customerror"
+)
+
+// Error codes for message imports
+const (
+	ErrCodeImportFormatUnsupported = "IMPORT_FORMAT_UNSUPPORTED"
+	ErrCodeImportFileInvalid       = "IMPORT_FILE_INVALID"
+	ErrCodeImportRowInvalid        = "IMPORT_ROW_INVALID"
+)
+
+// Error messages
+const (
+	MsgImportFormatUnsupported = "Import format is not supported, use csv or ndjson"
+	MsgImportFileInvalid       = "Import file could not be read"
+	MsgImportRowInvalid        = "Import row is invalid"
+)
+
+// Predefined errors
+var (
+	ErrImportFormatUnsupported = customerror.NewCustomError(
+		ErrCodeImportFormatUnsupported,
+		MsgImportFormatUnsupported,
+		http.StatusBadRequest,
+	)
+
+	ErrImportFileInvalid = customerror.NewCustomError(
+		ErrCodeImportFileInvalid,
+		MsgImportFileInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrImportRowInvalid = customerror.NewCustomError(
+		ErrCodeImportRowInvalid,
+		MsgImportRowInvalid,
+		http.StatusBadRequest,
+	)
+)
@@ -8,18 +8,36 @@ import (
 
 // Error codes for message sender
 const (
-	ErrCodeMessageSendFailed = "MESSAGE_SEND_FAILED"
-	ErrCodeWebhookCallFailed = "WEBHOOK_CALL_FAILED"
-	ErrCodeMarkSentFailed    = "MARK_SENT_FAILED"
-	ErrCodeMarkFailedFailed  = "MARK_FAILED_FAILED"
+	ErrCodeMessageSendFailed        = "MESSAGE_SEND_FAILED"
+	ErrCodeWebhookCallFailed        = "WEBHOOK_CALL_FAILED"
+	ErrCodeMarkSentFailed           = "MARK_SENT_FAILED"
+	ErrCodeMarkFailedFailed         = "MARK_FAILED_FAILED"
+	ErrCodeRecordAttemptFailed      = "RECORD_ATTEMPT_FAILED"
+	ErrCodeMessageClaimFailed       = "MESSAGE_CLAIM_FAILED"
+	ErrCodeLeaseReleaseFailed       = "LEASE_RELEASE_FAILED"
+	ErrCodeMessageExpireFailed      = "MESSAGE_EXPIRE_FAILED"
+	ErrCodeMessageSuppressFailed    = "MESSAGE_SUPPRESS_FAILED"
+	ErrCodeMessageMarkUnknownFailed = "MESSAGE_MARK_UNKNOWN_FAILED"
+	ErrCodeSendIntentFailed         = "SEND_INTENT_FAILED"
+	ErrCodeSendIntentRecoveryFailed = "SEND_INTENT_RECOVERY_FAILED"
+	ErrCodeMessageLeaseLost         = "MESSAGE_LEASE_LOST"
 )
 
 // Error messages
 const (
-	MsgMessageSendFailed = "Failed to send message"
-	MsgWebhookCallFailed = "Webhook call failed"
-	MsgMarkSentFailed    = "Failed to mark message as sent"
-	MsgMarkFailedFailed  = "Failed to mark message as failed"
+	MsgMessageSendFailed        = "Failed to send message"
+	MsgWebhookCallFailed        = "Webhook call failed"
+	MsgMarkSentFailed           = "Failed to mark message as sent"
+	MsgMarkFailedFailed         = "Failed to mark message as failed"
+	MsgRecordAttemptFailed      = "Failed to record message delivery attempt"
+	MsgMessageClaimFailed       = "Failed to claim pending messages"
+	MsgLeaseReleaseFailed       = "Failed to release expired message leases"
+	MsgMessageExpireFailed      = "Failed to expire messages"
+	MsgMessageSuppressFailed    = "Failed to mark message as suppressed"
+	MsgMessageMarkUnknownFailed = "Failed to hold message with an unknown send outcome"
+	MsgSendIntentFailed         = "Failed to record send intent"
+	MsgSendIntentRecoveryFailed = "Failed to recover in-flight send intents"
+	MsgMessageLeaseLost         = "Message lease expired and was claimed by another instance"
 )
 
 // Predefined errors
@@ -47,4 +65,58 @@ var (
 		MsgMarkFailedFailed,
 		http.StatusInternalServerError,
 	)
+
+	ErrRecordAttemptFailed = customerror.NewCustomError(
+		ErrCodeRecordAttemptFailed,
+		MsgRecordAttemptFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrMessageClaimFailed = customerror.NewCustomError(
+		ErrCodeMessageClaimFailed,
+		MsgMessageClaimFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrLeaseReleaseFailed = customerror.NewCustomError(
+		ErrCodeLeaseReleaseFailed,
+		MsgLeaseReleaseFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrMessageExpireFailed = customerror.NewCustomError(
+		ErrCodeMessageExpireFailed,
+		MsgMessageExpireFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrMessageSuppressFailed = customerror.NewCustomError(
+		ErrCodeMessageSuppressFailed,
+		MsgMessageSuppressFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrMessageMarkUnknownFailed = customerror.NewCustomError(
+		ErrCodeMessageMarkUnknownFailed,
+		MsgMessageMarkUnknownFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrSendIntentFailed = customerror.NewCustomError(
+		ErrCodeSendIntentFailed,
+		MsgSendIntentFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrSendIntentRecoveryFailed = customerror.NewCustomError(
+		ErrCodeSendIntentRecoveryFailed,
+		MsgSendIntentRecoveryFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrMessageLeaseLost = customerror.NewCustomError(
+		ErrCodeMessageLeaseLost,
+		MsgMessageLeaseLost,
+		http.StatusConflict,
+	)
 )
@@ -0,0 +1,74 @@
+package apperror
+
+import (
+	"net/http"
+
+	"github.com/srcndev/message-service/pkg/customerror"
+)
+
+// Error codes
+const (
+	ErrCodeSuppressionNotFound     = "SUPPRESSION_NOT_FOUND"
+	ErrCodeSuppressionExists       = "SUPPRESSION_EXISTS"
+	ErrCodeSuppressionCreateFailed = "SUPPRESSION_CREATE_FAILED"
+	ErrCodeSuppressionDeleteFailed = "SUPPRESSION_DELETE_FAILED"
+	ErrCodeSuppressionListFailed   = "SUPPRESSION_LIST_FAILED"
+	ErrCodeSuppressionLookupFailed = "SUPPRESSION_LOOKUP_FAILED"
+	ErrCodeRecipientSuppressed     = "RECIPIENT_SUPPRESSED"
+)
+
+// Error messages
+const (
+	MsgSuppressionNotFound     = "Phone number is not on the suppression list"
+	MsgSuppressionExists       = "Phone number is already on the suppression list"
+	MsgSuppressionCreateFailed = "Failed to add phone number to the suppression list"
+	MsgSuppressionDeleteFailed = "Failed to remove phone number from the suppression list"
+	MsgSuppressionListFailed   = "Failed to list suppressions"
+	MsgSuppressionLookupFailed = "Failed to check the suppression list"
+	MsgRecipientSuppressed     = "Recipient has opted out and is on the suppression list"
+)
+
+// Predefined errors
+var (
+	ErrSuppressionNotFound = customerror.NewCustomError(
+		ErrCodeSuppressionNotFound,
+		MsgSuppressionNotFound,
+		http.StatusNotFound,
+	)
+
+	ErrSuppressionExists = customerror.NewCustomError(
+		ErrCodeSuppressionExists,
+		MsgSuppressionExists,
+		http.StatusConflict,
+	)
+
+	ErrSuppressionCreateFailed = customerror.NewCustomError(
+		ErrCodeSuppressionCreateFailed,
+		MsgSuppressionCreateFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrSuppressionDeleteFailed = customerror.NewCustomError(
+		ErrCodeSuppressionDeleteFailed,
+		MsgSuppressionDeleteFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrSuppressionListFailed = customerror.NewCustomError(
+		ErrCodeSuppressionListFailed,
+		MsgSuppressionListFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrSuppressionLookupFailed = customerror.NewCustomError(
+		ErrCodeSuppressionLookupFailed,
+		MsgSuppressionLookupFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrRecipientSuppressed = customerror.NewCustomError(
+		ErrCodeRecipientSuppressed,
+		MsgRecipientSuppressed,
+		http.StatusUnprocessableEntity,
+	)
+)
@@ -0,0 +1,66 @@
+package apperror
+
+import (
+	"net/http"
+
+	"github.com/srcndev/message-service/pkg/customerror"
+)
+
+// Error codes
+const (
+	ErrCodeTemplateNotFound        = "TEMPLATE_NOT_FOUND"
+	ErrCodeTemplateCreateFailed    = "TEMPLATE_CREATE_FAILED"
+	ErrCodeTemplateUpdateFailed    = "TEMPLATE_UPDATE_FAILED"
+	ErrCodeTemplateDeleteFailed    = "TEMPLATE_DELETE_FAILED"
+	ErrCodeTemplateListFailed      = "TEMPLATE_LIST_FAILED"
+	ErrCodeTemplateVariableMissing = "TEMPLATE_VARIABLE_MISSING"
+)
+
+// Error messages
+const (
+	MsgTemplateNotFound        = "Template not found"
+	MsgTemplateCreateFailed    = "Failed to create template"
+	MsgTemplateUpdateFailed    = "Failed to update template"
+	MsgTemplateDeleteFailed    = "Failed to delete template"
+	MsgTemplateListFailed      = "Failed to list templates"
+	MsgTemplateVariableMissing = "Template variables are missing"
+)
+
+// Predefined errors
+var (
+	ErrTemplateNotFound = customerror.NewCustomError(
+		ErrCodeTemplateNotFound,
+		MsgTemplateNotFound,
+		http.StatusNotFound,
+	)
+
+	ErrTemplateCreateFailed = customerror.NewCustomError(
+		ErrCodeTemplateCreateFailed,
+		MsgTemplateCreateFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrTemplateUpdateFailed = customerror.NewCustomError(
+		ErrCodeTemplateUpdateFailed,
+		MsgTemplateUpdateFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrTemplateDeleteFailed = customerror.NewCustomError(
+		ErrCodeTemplateDeleteFailed,
+		MsgTemplateDeleteFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrTemplateListFailed = customerror.NewCustomError(
+		ErrCodeTemplateListFailed,
+		MsgTemplateListFailed,
+		http.StatusInternalServerError,
+	)
+
+	ErrTemplateVariableMissing = customerror.NewCustomError(
+		ErrCodeTemplateVariableMissing,
+		MsgTemplateVariableMissing,
+		http.StatusBadRequest,
+	)
+)
@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// InboundAction is what an inbound reply triggered, based on the keyword it matched
+type InboundAction string
+
+const (
+	InboundActionNone  InboundAction = "none"  // No keyword matched, the reply is only stored
+	InboundActionStop  InboundAction = "stop"  // The sender was added to the suppression list
+	InboundActionStart InboundAction = "start" // The opt-out of the sender was removed
+	InboundActionHelp  InboundAction = "help"  // The sender asked for help
+)
+
+// InboundMessage is a reply received from a recipient through the SMS gateway
+type InboundMessage struct {
+	ID             uint          `gorm:"primaryKey" json:"id"`
+	MessageID      *string       `gorm:"type:varchar(100);uniqueIndex" json:"messageId,omitempty"` // Gateway ID, repeated deliveries are ignored
+	PhoneNumber    string        `gorm:"type:varchar(20);not null;index" json:"phoneNumber"`
+	To             string        `gorm:"type:varchar(20)" json:"to,omitempty"`
+	Content        string        `gorm:"type:text;not null" json:"content"`
+	Keyword        string        `gorm:"type:varchar(50)" json:"keyword,omitempty"`
+	Action         InboundAction `gorm:"type:varchar(10);not null;default:'none';index" json:"action"`
+	ReplyMessageID *uint         `json:"replyMessageId,omitempty"` // Auto-reply queued as an outgoing message
+	ReceivedAt     time.Time     `gorm:"not null;index" json:"receivedAt"`
+	CreatedAt      time.Time     `json:"createdAt"`
+}
+
+// TableName specifies the table name for GORM
+func (InboundMessage) TableName() string {
+	return "inbound_messages"
+}
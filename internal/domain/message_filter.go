@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// MessageFilter narrows the messages returned by list and export queries, zero values match everything
+type MessageFilter struct {
+	Status      MessageStatus
+	PhoneNumber string
+	CreatedFrom *time.Time // Inclusive
+	CreatedTo   *time.Time // Exclusive
+	SentFrom    *time.Time // Inclusive, only messages that were sent
+	SentTo      *time.Time // Exclusive, only messages that were sent
+	Search      string     // Case-insensitive substring of the content
+}
@@ -8,15 +8,33 @@ import (
 
 // Message represents a message to be sent
 type Message struct {
-	ID          uint           `gorm:"primaryKey" json:"id"`
-	PhoneNumber string         `gorm:"type:varchar(20);not null;index" json:"phoneNumber"`
-	Content     string         `gorm:"type:varchar(160);not null" json:"content"`
-	Status      MessageStatus  `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
-	MessageID   *string        `gorm:"type:varchar(100);uniqueIndex" json:"messageId,omitempty"`
-	SentAt      *time.Time     `json:"sentAt,omitempty"`
-	CreatedAt   time.Time      `json:"createdAt"`
-	UpdatedAt   time.Time      `json:"updatedAt"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	ID               uint            `gorm:"primaryKey" json:"id"`
+	PhoneNumber      string          `gorm:"type:varchar(20);not null;index" json:"phoneNumber"`
+	Content          string          `gorm:"type:text;not null;index:idx_messages_content_trgm,type:gin,expression:content gin_trgm_ops" json:"content"`
+	TemplateID       *uint           `gorm:"index" json:"templateId,omitempty"`
+	Encoding         string          `gorm:"type:varchar(10);not null;default:'gsm7'" json:"encoding"`
+	Segments         int             `gorm:"not null;default:1" json:"segments"`
+	Status           MessageStatus   `gorm:"type:varchar(20);not null;default:'pending';index;index:idx_messages_status_created_at,priority:1" json:"status"`
+	Priority         MessagePriority `gorm:"type:varchar(10);not null;default:'normal';index" json:"priority"`
+	MessageID        *string         `gorm:"type:varchar(100);uniqueIndex" json:"messageId,omitempty"`
+	Provider         *string         `gorm:"type:varchar(50);index" json:"provider,omitempty"`
+	SendAt           *time.Time      `gorm:"index" json:"sendAt,omitempty"`
+	ExpiresAt        *time.Time      `gorm:"index" json:"expiresAt,omitempty"`
+	AttemptCount     int             `gorm:"not null;default:0" json:"attemptCount"`
+	LastErrorCode    *string         `gorm:"type:varchar(100)" json:"lastErrorCode,omitempty"`
+	LastErrorMessage *string         `gorm:"type:text" json:"lastErrorMessage,omitempty"`
+	LastAttemptAt    *time.Time      `json:"lastAttemptAt,omitempty"`
+	NextAttemptAt    *time.Time      `gorm:"index" json:"nextAttemptAt,omitempty"`
+	LeaseOwner       *string         `gorm:"type:varchar(100)" json:"leaseOwner,omitempty"`
+	LeaseExpiresAt   *time.Time      `gorm:"index" json:"leaseExpiresAt,omitempty"`
+	SentAt           *time.Time      `gorm:"index" json:"sentAt,omitempty"`
+	FailedAt         *time.Time      `gorm:"index" json:"failedAt,omitempty"`
+	ReceiptAt        *time.Time      `json:"receiptAt,omitempty"`
+	ReceiptErrorCode *string         `gorm:"type:varchar(100)" json:"receiptErrorCode,omitempty"`
+	KeywordReply     bool            `gorm:"not null;default:false" json:"-"` // Auto-reply to an inbound keyword, sent even to a suppressed number
+	CreatedAt        time.Time       `gorm:"index;index:idx_messages_status_created_at,priority:2" json:"createdAt"`
+	UpdatedAt        time.Time       `json:"updatedAt"`
+	DeletedAt        gorm.DeletedAt  `gorm:"index" json:"-"`
 }
 
 // TableName specifies the table name for GORM
@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+// MessageSortKey is the time column a message list is ordered by, newest first with the ID breaking ties
+type MessageSortKey string
+
+const (
+	SortByCreatedAt MessageSortKey = "created_at"
+	SortBySentAt    MessageSortKey = "sent_at"
+	SortByFailedAt  MessageSortKey = "failed_at"
+)
+
+// IsValid reports whether the sort key is a known column
+func (k MessageSortKey) IsValid() bool {
+	switch k {
+	case SortByCreatedAt, SortBySentAt, SortByFailedAt:
+		return true
+	default:
+		return false
+	}
+}
+
+// CursorOf returns the position of the message in a list ordered by the sort key
+func (k MessageSortKey) CursorOf(m *Message) MessageCursor {
+	cursor := MessageCursor{Time: m.CreatedAt, ID: m.ID}
+	switch {
+	case k == SortBySentAt && m.SentAt != nil:
+		cursor.Time = *m.SentAt
+	case k == SortByFailedAt && m.FailedAt != nil:
+		cursor.Time = *m.FailedAt
+	}
+	return cursor
+}
+
+// MessageCursor is the position of a message in a keyset ordered list
+type MessageCursor struct {
+	Time time.Time
+	ID   uint
+}
+
+// MessagePageQuery selects a page of a keyset ordered message list, at most one of After and Before is set
+type MessagePageQuery struct {
+	SortBy    MessageSortKey
+	Limit     int
+	After     *MessageCursor // Messages older than the cursor, the next page
+	Before    *MessageCursor // Messages newer than the cursor, the previous page
+	WithTotal bool           // Also count every message matching the filter
+}
+
+// MessagePage is a page of messages, newest first, with what is needed to reach the neighbouring pages
+type MessagePage struct {
+	Messages []*Message
+	HasNext  bool
+	HasPrev  bool
+	Total    *int64 // Set when the query asked for it
+}
@@ -0,0 +1,35 @@
+package domain
+
+// MessagePriority is the lane a message is queued in, higher lanes are sent first
+type MessagePriority string
+
+const (
+	PriorityHigh   MessagePriority = "high"
+	PriorityNormal MessagePriority = "normal"
+	PriorityLow    MessagePriority = "low"
+)
+
+// Priorities lists the lanes from the most to the least urgent
+var Priorities = []MessagePriority{PriorityHigh, PriorityNormal, PriorityLow}
+
+// IsValid reports whether the priority is a known lane
+func (p MessagePriority) IsValid() bool {
+	for _, lane := range Priorities {
+		if p == lane {
+			return true
+		}
+	}
+	return false
+}
+
+// Rank orders the lanes, lower ranks are more urgent and unknown lanes rank as normal
+func (p MessagePriority) Rank() int {
+	switch p {
+	case PriorityHigh:
+		return 0
+	case PriorityLow:
+		return 2
+	default:
+		return 1
+	}
+}
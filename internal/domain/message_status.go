@@ -4,6 +4,16 @@ package domain
 type MessageStatus string
 
 const (
-	StatusPending MessageStatus = "pending"
-	StatusSent    MessageStatus = "sent"
+	StatusPending    MessageStatus = "pending"
+	StatusProcessing MessageStatus = "processing"
+	StatusSent       MessageStatus = "sent"
+	StatusFailed     MessageStatus = "failed"
+	StatusCancelled  MessageStatus = "cancelled"
+	StatusExpired    MessageStatus = "expired"
+	StatusSuppressed MessageStatus = "suppressed" // The recipient is on the suppression list, never sent
+	StatusUnknown    MessageStatus = "unknown"    // A webhook call was interrupted, held for review instead of risking a duplicate
+
+	// Final states reported by the provider through delivery receipts
+	StatusDelivered   MessageStatus = "delivered"
+	StatusUndelivered MessageStatus = "undelivered"
 )
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// SendIntentStatus represents the outcome of the last webhook call made for a message
+type SendIntentStatus string
+
+const (
+	IntentInFlight SendIntentStatus = "in_flight" // Webhook call started, its outcome is not recorded yet
+	IntentSent     SendIntentStatus = "sent"      // The provider accepted the message and it was marked as sent
+	IntentFailed   SendIntentStatus = "failed"    // The call failed and the failure was recorded on the message
+	IntentClosed   SendIntentStatus = "closed"    // The message reached a final state without this call being resolved
+	IntentUnknown  SendIntentStatus = "unknown"   // The outcome was never recorded, the message is held for review
+)
+
+// SendIntent is written before a message is handed to the webhook, so a crash between the call and
+// recording its outcome leaves a trace that startup recovery can reconcile
+type SendIntent struct {
+	MessageID      uint             `gorm:"primaryKey;autoIncrement:false" json:"messageId"`
+	IdempotencyKey string           `gorm:"type:varchar(100);not null;uniqueIndex" json:"idempotencyKey"`
+	Status         SendIntentStatus `gorm:"type:varchar(20);not null;index" json:"status"`
+	Attempt        int              `gorm:"not null" json:"attempt"`
+	InstanceID     string           `gorm:"type:varchar(100)" json:"instanceId"`
+	StartedAt      time.Time        `gorm:"not null" json:"startedAt"`
+	ResolvedAt     *time.Time       `json:"resolvedAt,omitempty"`
+	CreatedAt      time.Time        `json:"createdAt"`
+	UpdatedAt      time.Time        `json:"updatedAt"`
+}
+
+// TableName specifies the table name for GORM
+func (SendIntent) TableName() string {
+	return "send_intents"
+}
+
+// IdempotencyKey is sent to the provider with every webhook call of the message. It is the same for all
+// attempts, so a call repeated after a crash is recognized as a duplicate. The creation time keeps keys
+// unique when message IDs are reused, e.g. after the table is recreated.
+func (m *Message) IdempotencyKey() string {
+	return fmt.Sprintf("msg-%d-%d", m.ID, m.CreatedAt.UnixMicro())
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// SuppressionReason records why a number was added to the suppression list
+type SuppressionReason string
+
+const (
+	SuppressionReasonOptOut    SuppressionReason = "opt_out"   // The recipient asked to be unsubscribed
+	SuppressionReasonComplaint SuppressionReason = "complaint" // The recipient reported the messages as unwanted
+	SuppressionReasonManual    SuppressionReason = "manual"    // Added by an operator
+)
+
+// Suppression is a phone number no message is sent to, removing it subscribes the number again
+type Suppression struct {
+	ID          uint              `gorm:"primaryKey" json:"id"`
+	PhoneNumber string            `gorm:"type:varchar(20);not null;uniqueIndex" json:"phoneNumber"`
+	Reason      SuppressionReason `gorm:"type:varchar(20);not null;default:'manual'" json:"reason"`
+	Note        string            `gorm:"type:text" json:"note,omitempty"`
+	CreatedAt   time.Time         `gorm:"index" json:"createdAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+}
+
+// TableName specifies the table name for GORM
+func (Suppression) TableName() string {
+	return "suppressions"
+}
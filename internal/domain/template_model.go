@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"regexp"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// placeholderPattern matches template variables written as {{name}} or {{ name }}
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+// Template is reusable message content with {{variable}} placeholders
+type Template struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	Name      string         `gorm:"type:varchar(100);not null;index" json:"name"`
+	Content   string         `gorm:"type:text;not null" json:"content"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName specifies the table name for GORM
+func (Template) TableName() string {
+	return "templates"
+}
+
+// Variables returns the placeholder names used in the content, in order of first use
+func (t *Template) Variables() []string {
+	seen := make(map[string]bool)
+	names := []string{}
+	for _, match := range placeholderPattern.FindAllStringSubmatch(t.Content, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// Render substitutes the variables into the content and returns the names that were not provided
+func (t *Template) Render(variables map[string]string) (string, []string) {
+	var missing []string
+	seen := make(map[string]bool)
+
+	content := placeholderPattern.ReplaceAllStringFunc(t.Content, func(placeholder string) string {
+		name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		value, ok := variables[name]
+		if !ok {
+			if !seen[name] {
+				seen[name] = true
+				missing = append(missing, name)
+			}
+			return placeholder
+		}
+		return value
+	})
+
+	return content, missing
+}
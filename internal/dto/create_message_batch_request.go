@@ -0,0 +1,7 @@
+package dto
+
+// CreateMessageBatchRequest represents the request payload for creating messages in bulk.
+// At most 100 items are accepted, they are validated one by one so a bad item does not reject the whole batch.
+type CreateMessageBatchRequest struct {
+	Messages []CreateMessageRequest `json:"messages" binding:"required,min=1,max=100"`
+}
@@ -1,7 +1,19 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/srcndev/message-service/internal/domain"
+)
+
 // CreateMessageRequest represents the request payload for creating a message
 type CreateMessageRequest struct {
-	PhoneNumber string `json:"phoneNumber" binding:"required,e164" example:"+905551111111"`
-	Content     string `json:"content" binding:"required,max=160" example:"Hello World"`
+	PhoneNumber string                 `json:"phoneNumber" binding:"required,e164" example:"+905551111111"`
+	Content     string                 `json:"content,omitempty" binding:"required_without=TemplateID,excluded_with=TemplateID" example:"Hello World"` // Split into segments, at most MESSAGE_MAX_SEGMENTS
+	TemplateID  *uint                  `json:"templateId,omitempty" binding:"omitempty,min=1" example:"1"`                                             // Renders the content from a template instead
+	Variables   map[string]string      `json:"variables,omitempty"`                                                                                    // Values for the template placeholders
+	Priority    domain.MessagePriority `json:"priority,omitempty" binding:"omitempty,oneof=high normal low" example:"high"`                            // Sending lane, defaults to normal
+	SendAt      *time.Time             `json:"sendAt,omitempty" example:"2025-11-09T12:00:00Z"`                                                        // Not sent before this time (RFC3339)
+	ExpiresAt   *time.Time             `json:"expiresAt,omitempty" example:"2025-11-09T12:05:00Z"`                                                     // Not sent after this time (RFC3339)
+	TTL         *int                   `json:"ttl,omitempty" binding:"omitempty,min=1" example:"300"`                                                  // Validity in seconds from sendAt or creation, instead of expiresAt
 }
@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/srcndev/message-service/internal/domain"
+
+// CreateSuppressionRequest represents the request payload for adding a phone number to the suppression list
+type CreateSuppressionRequest struct {
+	PhoneNumber string                   `json:"phoneNumber" binding:"required,e164" example:"+905551111111"`
+	Reason      domain.SuppressionReason `json:"reason" binding:"omitempty,oneof=opt_out complaint manual" example:"opt_out"` // Defaults to manual
+	Note        string                   `json:"note" binding:"max=500" example:"Asked to unsubscribe by phone"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+// CreateTemplateRequest represents the request payload for creating a template
+type CreateTemplateRequest struct {
+	Name    string `json:"name" binding:"required,max=100" example:"otp"`
+	Content string `json:"content" binding:"required" example:"Hi {{name}}, your code is {{code}}"` // Placeholders are written as {{variable}}
+}
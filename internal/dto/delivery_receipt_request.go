@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/srcndev/message-service/internal/domain"
+)
+
+// DeliveryReceiptRequest represents a delivery receipt (DLR) posted by the SMS provider
+type DeliveryReceiptRequest struct {
+	MessageID string               `json:"messageId" binding:"required" example:"67f2f8a8-ea58-4ed0-a6f9-ff217df4d849"`
+	Status    domain.MessageStatus `json:"status" binding:"required,oneof=delivered undelivered" example:"delivered"`
+	Timestamp *time.Time           `json:"timestamp,omitempty" example:"2025-11-09T10:30:05Z"`
+	ErrorCode *string              `json:"errorCode,omitempty" binding:"omitempty,max=100" example:"ABSENT_SUBSCRIBER"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// InboundMessageRequest represents a reply from a recipient posted by the SMS gateway
+type InboundMessageRequest struct {
+	MessageID *string    `json:"messageId,omitempty" binding:"omitempty,max=100" example:"b7e2c1d4-3f5a-4e8b-9c6d-1a2b3c4d5e6f"` // Repeated deliveries with the same ID are ignored
+	From      string     `json:"from" binding:"required,e164" example:"+905551111111"`
+	To        string     `json:"to,omitempty" binding:"omitempty,max=20" example:"+905550000000"`
+	Content   string     `json:"content" binding:"required,max=1600" example:"STOP"`
+	Timestamp *time.Time `json:"timestamp,omitempty" example:"2025-11-09T10:30:05Z"`
+}
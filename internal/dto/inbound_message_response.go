@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/srcndev/message-service/internal/domain"
+)
+
+// InboundMessageResponse represents the response payload for an inbound message
+type InboundMessageResponse struct {
+	ID             uint                 `json:"id" example:"1"`
+	MessageID      *string              `json:"messageId,omitempty" example:"b7e2c1d4-3f5a-4e8b-9c6d-1a2b3c4d5e6f"`
+	PhoneNumber    string               `json:"phoneNumber" example:"+905551111111"`
+	To             string               `json:"to,omitempty" example:"+905550000000"`
+	Content        string               `json:"content" example:"STOP"`
+	Keyword        string               `json:"keyword,omitempty" example:"STOP"`
+	Action         domain.InboundAction `json:"action" example:"stop"`
+	ReplyMessageID *uint                `json:"replyMessageId,omitempty" example:"42"`
+	ReceivedAt     time.Time            `json:"receivedAt" example:"2025-11-09T10:30:05Z"`
+}
+
+// ToInboundMessageResponse converts domain model to response DTO
+func ToInboundMessageResponse(m *domain.InboundMessage) InboundMessageResponse {
+	return InboundMessageResponse{
+		ID:             m.ID,
+		MessageID:      m.MessageID,
+		PhoneNumber:    m.PhoneNumber,
+		To:             m.To,
+		Content:        m.Content,
+		Keyword:        m.Keyword,
+		Action:         m.Action,
+		ReplyMessageID: m.ReplyMessageID,
+		ReceivedAt:     m.ReceivedAt,
+	}
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "github.com/srcndev/message-service/pkg/customresponse"
+
+// MessageBatchItemResponse is the outcome of one item of a batch create
+type MessageBatchItemResponse struct {
+	Index int                       `json:"index" example:"0"`        // Position of the item in the request
+	ID    *uint                     `json:"id,omitempty" example:"1"` // ID of the created message
+	Error *customresponse.ErrorInfo `json:"error,omitempty"`          // Why the item was rejected
+}
+
+// MessageBatchResponse represents the response payload for a batch create
+type MessageBatchResponse struct {
+	Created int                        `json:"created" example:"2"`
+	Failed  int                        `json:"failed" example:"1"`
+	Results []MessageBatchItemResponse `json:"results"`
+}
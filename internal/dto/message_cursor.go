@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/srcndev/message-service/pkg/customresponse"
+)
+
+// messageCursorToken is the content of an opaque page cursor
+type messageCursorToken struct {
+	SortBy   domain.MessageSortKey `json:"k"`
+	Time     time.Time             `json:"t"`
+	ID       uint                  `json:"i"`
+	Backward bool                  `json:"b,omitempty"`
+}
+
+// EncodeMessageCursor builds the opaque cursor of a position, backward cursors point to the previous page
+func EncodeMessageCursor(sortBy domain.MessageSortKey, cursor domain.MessageCursor, backward bool) string {
+	data, _ := json.Marshal(messageCursorToken{SortBy: sortBy, Time: cursor.Time, ID: cursor.ID, Backward: backward})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ApplyMessageCursor decodes a cursor into the page query, a cursor issued for another list is rejected
+func ApplyMessageCursor(page *domain.MessagePageQuery, cursor string) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return apperror.ErrInvalidCursor
+	}
+
+	var token messageCursorToken
+	if err := json.Unmarshal(data, &token); err != nil || token.SortBy != page.SortBy || token.ID == 0 {
+		return apperror.ErrInvalidCursor
+	}
+
+	position := &domain.MessageCursor{Time: token.Time, ID: token.ID}
+	if token.Backward {
+		page.Before = position
+	} else {
+		page.After = position
+	}
+	return nil
+}
+
+// ToPageMeta builds the pagination state of a page, with cursors to its neighbours
+func ToPageMeta(page *domain.MessagePage, query domain.MessagePageQuery) *customresponse.Meta {
+	meta := &customresponse.Meta{
+		Limit: query.Limit,
+		Total: page.Total,
+	}
+	// Without a message there is no position to continue from
+	if len(page.Messages) == 0 {
+		return meta
+	}
+	meta.HasNext = page.HasNext
+	meta.HasPrev = page.HasPrev
+
+	if page.HasNext {
+		meta.NextCursor = EncodeMessageCursor(query.SortBy, query.SortBy.CursorOf(page.Messages[len(page.Messages)-1]), false)
+	}
+	if page.HasPrev {
+		meta.PrevCursor = EncodeMessageCursor(query.SortBy, query.SortBy.CursorOf(page.Messages[0]), true)
+	}
+	return meta
+}
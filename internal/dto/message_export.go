@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/srcndev/message-service/internal/domain"
+)
+
+// Export formats
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+)
+
+// MessageExportQuery represents the query parameters of a message export
+type MessageExportQuery struct {
+	MessageFilterQuery
+	Format string `form:"format" binding:"omitempty,oneof=csv ndjson" example:"csv"`
+}
+
+// MessageExportColumns is the header row of a CSV export, in the order of ToExportRecord
+var MessageExportColumns = []string{
+	"id", "phoneNumber", "content", "templateId", "encoding", "segments", "status", "priority",
+	"messageId", "provider", "sendAt", "expiresAt", "attemptCount", "lastErrorCode", "lastErrorMessage",
+	"sentAt", "failedAt", "receiptAt", "receiptErrorCode", "createdAt", "updatedAt",
+}
+
+// ToExportRecord converts a message to a CSV export row, absent values are empty and times are RFC3339
+func ToExportRecord(m *domain.Message) []string {
+	return []string{
+		strconv.FormatUint(uint64(m.ID), 10),
+		m.PhoneNumber,
+		m.Content,
+		uintValue(m.TemplateID),
+		m.Encoding,
+		strconv.Itoa(m.Segments),
+		string(m.Status),
+		string(m.Priority),
+		stringValue(m.MessageID),
+		stringValue(m.Provider),
+		timeValue(m.SendAt),
+		timeValue(m.ExpiresAt),
+		strconv.Itoa(m.AttemptCount),
+		stringValue(m.LastErrorCode),
+		stringValue(m.LastErrorMessage),
+		timeValue(m.SentAt),
+		timeValue(m.FailedAt),
+		timeValue(m.ReceiptAt),
+		stringValue(m.ReceiptErrorCode),
+		m.CreatedAt.Format(time.RFC3339),
+		m.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func uintValue(u *uint) string {
+	if u == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*u), 10)
+}
+
+func timeValue(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
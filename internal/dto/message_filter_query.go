@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"github.com/srcndev/message-service/internal/domain"
+)
+
+// MessageFilterQuery represents the query parameters filtering message lists and exports.
+// Times are RFC3339, a "+" in phoneNumber or a time offset has to be sent as %2B.
+type MessageFilterQuery struct {
+	Status      domain.MessageStatus `form:"status" binding:"omitempty,oneof=pending processing sent failed cancelled expired suppressed unknown delivered undelivered" example:"sent"`
+	PhoneNumber string               `form:"phoneNumber" binding:"omitempty,e164" example:"+905551111111"`
+	CreatedFrom *time.Time           `form:"createdFrom" time_format:"2006-01-02T15:04:05Z07:00" example:"2025-11-01T00:00:00Z"`
+	CreatedTo   *time.Time           `form:"createdTo" time_format:"2006-01-02T15:04:05Z07:00" example:"2025-12-01T00:00:00Z"`
+	SentFrom    *time.Time           `form:"sentFrom" time_format:"2006-01-02T15:04:05Z07:00" example:"2025-11-01T00:00:00Z"`
+	SentTo      *time.Time           `form:"sentTo" time_format:"2006-01-02T15:04:05Z07:00" example:"2025-12-01T00:00:00Z"`
+	Search      string               `form:"q" binding:"omitempty,max=100" example:"verification code"`
+}
+
+// ToFilter converts the query parameters to a domain filter
+func (q MessageFilterQuery) ToFilter() domain.MessageFilter {
+	return domain.MessageFilter{
+		Status:      q.Status,
+		PhoneNumber: q.PhoneNumber,
+		CreatedFrom: q.CreatedFrom,
+		CreatedTo:   q.CreatedTo,
+		SentFrom:    q.SentFrom,
+		SentTo:      q.SentTo,
+		Search:      strings.TrimSpace(q.Search),
+	}
+}
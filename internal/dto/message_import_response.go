@@ -0,0 +1,19 @@
+package dto
+
+import "github.com/srcndev/message-service/pkg/customresponse"
+
+// MessageImportRowError is a row rejected by an import
+type MessageImportRowError struct {
+	Line  int                      `json:"line" example:"3"` // Line of the row in the file, the CSV header is line 1
+	Error customresponse.ErrorInfo `json:"error"`
+}
+
+// MessageImportResponse represents the row-level report of an import
+type MessageImportResponse struct {
+	Rows    int                     `json:"rows" example:"3"`
+	Created int                     `json:"created" example:"2"`
+	Failed  int                     `json:"failed" example:"1"`
+	Errors  []MessageImportRowError `json:"errors"` // At most the first 1000 rejected rows
+	// Line an aborted import stopped at, rows from that line on were not imported
+	StoppedAt int `json:"stoppedAt,omitempty" example:"201"`
+}
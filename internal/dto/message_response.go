@@ -8,26 +8,56 @@ import (
 
 // MessageResponse represents the response payload for a message
 type MessageResponse struct {
-	ID          uint                 `json:"id" example:"1"`
-	PhoneNumber string               `json:"phoneNumber" example:"+905551111111"`
-	Content     string               `json:"content" example:"Hello"`
-	Status      domain.MessageStatus `json:"status" example:"pending"`
-	MessageID   *string              `json:"messageId,omitempty" example:"67f2f8a8-ea58-4ed0-a6f9-ff217df4d849"`
-	SentAt      *time.Time           `json:"sentAt,omitempty" example:"2025-11-09T10:30:00Z"`
-	CreatedAt   time.Time            `json:"createdAt" example:"2025-11-09T10:00:00Z"`
-	UpdatedAt   time.Time            `json:"updatedAt" example:"2025-11-09T10:00:00Z"`
+	ID               uint                   `json:"id" example:"1"`
+	PhoneNumber      string                 `json:"phoneNumber" example:"+905551111111"`
+	Content          string                 `json:"content" example:"Hello"`
+	TemplateID       *uint                  `json:"templateId,omitempty" example:"1"`
+	Encoding         string                 `json:"encoding" example:"gsm7"`
+	Segments         int                    `json:"segments" example:"1"`
+	Status           domain.MessageStatus   `json:"status" example:"pending"`
+	Priority         domain.MessagePriority `json:"priority" example:"normal"`
+	MessageID        *string                `json:"messageId,omitempty" example:"67f2f8a8-ea58-4ed0-a6f9-ff217df4d849"`
+	Provider         *string                `json:"provider,omitempty" example:"primary"`
+	SendAt           *time.Time             `json:"sendAt,omitempty" example:"2025-11-09T12:00:00Z"`
+	ExpiresAt        *time.Time             `json:"expiresAt,omitempty" example:"2025-11-09T12:05:00Z"`
+	AttemptCount     int                    `json:"attemptCount" example:"1"`
+	LastErrorCode    *string                `json:"lastErrorCode,omitempty" example:"WEBHOOK_SERVER_ERROR"`
+	LastErrorMessage *string                `json:"lastErrorMessage,omitempty" example:"[WEBHOOK_SERVER_ERROR] Webhook server error: status: 503"`
+	LastAttemptAt    *time.Time             `json:"lastAttemptAt,omitempty" example:"2025-11-09T10:30:00Z"`
+	NextAttemptAt    *time.Time             `json:"nextAttemptAt,omitempty" example:"2025-11-09T10:31:00Z"`
+	SentAt           *time.Time             `json:"sentAt,omitempty" example:"2025-11-09T10:30:00Z"`
+	FailedAt         *time.Time             `json:"failedAt,omitempty" example:"2025-11-09T10:30:00Z"`
+	ReceiptAt        *time.Time             `json:"receiptAt,omitempty" example:"2025-11-09T10:30:05Z"`
+	ReceiptErrorCode *string                `json:"receiptErrorCode,omitempty" example:"ABSENT_SUBSCRIBER"`
+	CreatedAt        time.Time              `json:"createdAt" example:"2025-11-09T10:00:00Z"`
+	UpdatedAt        time.Time              `json:"updatedAt" example:"2025-11-09T10:00:00Z"`
 }
 
 // ToResponse converts domain model to response DTO
 func ToResponse(m *domain.Message) MessageResponse {
 	return MessageResponse{
-		ID:          m.ID,
-		PhoneNumber: m.PhoneNumber,
-		Content:     m.Content,
-		Status:      m.Status,
-		MessageID:   m.MessageID,
-		SentAt:      m.SentAt,
-		CreatedAt:   m.CreatedAt,
-		UpdatedAt:   m.UpdatedAt,
+		ID:               m.ID,
+		PhoneNumber:      m.PhoneNumber,
+		Content:          m.Content,
+		TemplateID:       m.TemplateID,
+		Encoding:         m.Encoding,
+		Segments:         m.Segments,
+		Status:           m.Status,
+		Priority:         m.Priority,
+		MessageID:        m.MessageID,
+		Provider:         m.Provider,
+		SendAt:           m.SendAt,
+		ExpiresAt:        m.ExpiresAt,
+		AttemptCount:     m.AttemptCount,
+		LastErrorCode:    m.LastErrorCode,
+		LastErrorMessage: m.LastErrorMessage,
+		LastAttemptAt:    m.LastAttemptAt,
+		NextAttemptAt:    m.NextAttemptAt,
+		SentAt:           m.SentAt,
+		FailedAt:         m.FailedAt,
+		ReceiptAt:        m.ReceiptAt,
+		ReceiptErrorCode: m.ReceiptErrorCode,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
 	}
 }
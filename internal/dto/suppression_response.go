@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/srcndev/message-service/internal/domain"
+)
+
+// SuppressionResponse represents the response payload for a suppressed phone number
+type SuppressionResponse struct {
+	ID          uint                     `json:"id" example:"1"`
+	PhoneNumber string                   `json:"phoneNumber" example:"+905551111111"`
+	Reason      domain.SuppressionReason `json:"reason" example:"opt_out"`
+	Note        string                   `json:"note,omitempty" example:"Asked to unsubscribe by phone"`
+	CreatedAt   time.Time                `json:"createdAt" example:"2025-11-09T10:00:00Z"`
+}
+
+// ToSuppressionResponse converts domain model to response DTO
+func ToSuppressionResponse(s *domain.Suppression) SuppressionResponse {
+	return SuppressionResponse{
+		ID:          s.ID,
+		PhoneNumber: s.PhoneNumber,
+		Reason:      s.Reason,
+		Note:        s.Note,
+		CreatedAt:   s.CreatedAt,
+	}
+}
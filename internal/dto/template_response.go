@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/srcndev/message-service/internal/domain"
+)
+
+// TemplateResponse represents the response payload for a template
+type TemplateResponse struct {
+	ID        uint      `json:"id" example:"1"`
+	Name      string    `json:"name" example:"otp"`
+	Content   string    `json:"content" example:"Hi {{name}}, your code is {{code}}"`
+	Variables []string  `json:"variables" example:"name,code"`
+	CreatedAt time.Time `json:"createdAt" example:"2025-11-09T10:00:00Z"`
+	UpdatedAt time.Time `json:"updatedAt" example:"2025-11-09T10:00:00Z"`
+}
+
+// ToTemplateResponse converts domain model to response DTO
+func ToTemplateResponse(t *domain.Template) TemplateResponse {
+	return TemplateResponse{
+		ID:        t.ID,
+		Name:      t.Name,
+		Content:   t.Content,
+		Variables: t.Variables(),
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
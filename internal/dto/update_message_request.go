@@ -1,10 +1,15 @@
 package dto
 
-import "github.com/srcndev/message-service/internal/domain"
+import (
+	"time"
+
+	"github.com/srcndev/message-service/internal/domain"
+)
 
 // UpdateMessageRequest represents the request payload for updating a message
 type UpdateMessageRequest struct {
 	PhoneNumber *string               `json:"phoneNumber,omitempty" binding:"omitempty,e164"`
-	Content     *string               `json:"content,omitempty" binding:"omitempty,max=160"`
-	Status      *domain.MessageStatus `json:"status,omitempty" binding:"omitempty,oneof=pending sent"`
+	Content     *string               `json:"content,omitempty"`
+	Status      *domain.MessageStatus `json:"status,omitempty" binding:"omitempty,oneof=pending cancelled" example:"cancelled"` // Sent and failed are only set by the sender
+	SendAt      *time.Time            `json:"sendAt,omitempty" example:"2025-11-09T12:00:00Z"`                                  // Reschedules a pending message
 }
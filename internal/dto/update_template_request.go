@@ -0,0 +1,7 @@
+package dto
+
+// UpdateTemplateRequest represents the request payload for updating a template
+type UpdateTemplateRequest struct {
+	Name    *string `json:"name,omitempty" binding:"omitempty,min=1,max=100"`
+	Content *string `json:"content,omitempty" binding:"omitempty,min=1"`
+}
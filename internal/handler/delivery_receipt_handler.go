@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/internal/service"
+	"github.com/srcndev/message-service/pkg/customresponse"
+)
+
+// DeliveryReceiptHandler interface defines delivery receipt callback HTTP handlers
+type DeliveryReceiptHandler interface {
+	Receive(c *gin.Context)
+	RegisterRoutes(router *gin.RouterGroup)
+}
+
+// deliveryReceiptHandler is the private implementation of DeliveryReceiptHandler interface
+type deliveryReceiptHandler struct {
+	service service.DeliveryReceiptService
+}
+
+// Compile-time interface compliance check
+var _ DeliveryReceiptHandler = (*deliveryReceiptHandler)(nil)
+
+// NewDeliveryReceiptHandler creates a new delivery receipt handler
+func NewDeliveryReceiptHandler(service service.DeliveryReceiptService) DeliveryReceiptHandler {
+	return &deliveryReceiptHandler{
+		service: service,
+	}
+}
+
+// RegisterRoutes registers provider callback routes
+func (h *deliveryReceiptHandler) RegisterRoutes(router *gin.RouterGroup) {
+	callbacks := router.Group("/callbacks")
+	{
+		callbacks.POST("/delivery", h.Receive)
+	}
+}
+
+// Receive godoc
+// @Summary      Receive delivery receipt
+// @Description  Callback for SMS providers to report whether a sent message reached the handset
+// @Tags         callbacks
+// @Accept       json
+// @Produce      json
+// @Param        receipt  body      dto.DeliveryReceiptRequest  true  "Delivery receipt"
+// @Success      200      {object}  customresponse.CustomResponse{data=dto.MessageResponse}
+// @Failure      400      {object}  customresponse.CustomResponse
+// @Failure      404      {object}  customresponse.CustomResponse
+// @Failure      409      {object}  customresponse.CustomResponse
+// @Failure      500      {object}  customresponse.CustomResponse
+// @Router       /callbacks/delivery [post]
+func (h *deliveryReceiptHandler) Receive(c *gin.Context) {
+	var req dto.DeliveryReceiptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		customresponse.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	message, err := h.service.ProcessReceipt(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	customresponse.Success(c, http.StatusOK, dto.ToResponse(message))
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/pkg/customresponse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock DeliveryReceiptService
+type MockDeliveryReceiptService struct {
+	mock.Mock
+}
+
+func (m *MockDeliveryReceiptService) ProcessReceipt(ctx context.Context, req dto.DeliveryReceiptRequest) (*domain.Message, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+
+func TestDeliveryReceiptHandler_Receive(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockSetup      func(*MockDeliveryReceiptService)
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:        "success - marks message delivered",
+			requestBody: `{"messageId": "webhook-id-1", "status": "delivered"}`,
+			mockSetup: func(m *MockDeliveryReceiptService) {
+				m.On("ProcessReceipt", mock.Anything, mock.MatchedBy(func(req dto.DeliveryReceiptRequest) bool {
+					return req.MessageID == "webhook-id-1" && req.Status == domain.StatusDelivered
+				})).Return(&domain.Message{ID: 1, Status: domain.StatusDelivered}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error - unknown status",
+			requestBody:    `{"messageId": "webhook-id-1", "status": "sent"}`,
+			mockSetup:      func(m *MockDeliveryReceiptService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name:           "error - missing messageId",
+			requestBody:    `{"status": "delivered"}`,
+			mockSetup:      func(m *MockDeliveryReceiptService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name:        "error - message not found",
+			requestBody: `{"messageId": "unknown", "status": "undelivered", "errorCode": "ABSENT_SUBSCRIBER"}`,
+			mockSetup: func(m *MockDeliveryReceiptService) {
+				m.On("ProcessReceipt", mock.Anything, mock.Anything).Return(nil, apperror.ErrMessageNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   apperror.ErrCodeMessageNotFound,
+		},
+		{
+			name:        "error - invalid transition",
+			requestBody: `{"messageId": "webhook-id-1", "status": "undelivered"}`,
+			mockSetup: func(m *MockDeliveryReceiptService) {
+				m.On("ProcessReceipt", mock.Anything, mock.Anything).Return(nil, apperror.ErrReceiptInvalidTransition)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   apperror.ErrCodeReceiptInvalidTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockDeliveryReceiptService)
+			tt.mockSetup(mockService)
+
+			router := gin.New()
+			router.Use(errorHandlerMiddleware())
+			NewDeliveryReceiptHandler(mockService).RegisterRoutes(router.Group("/api"))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/callbacks/delivery", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedCode != "" {
+				var resp customresponse.CustomResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.False(t, resp.Success)
+				assert.Equal(t, tt.expectedCode, resp.Error.Code)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/internal/service"
+	"github.com/srcndev/message-service/pkg/customresponse"
+)
+
+// InboundMessageHandler interface defines inbound message HTTP handlers
+type InboundMessageHandler interface {
+	Receive(c *gin.Context)
+	List(c *gin.Context)
+	RegisterRoutes(router *gin.RouterGroup)
+}
+
+// inboundMessageHandler is the private implementation of InboundMessageHandler interface
+type inboundMessageHandler struct {
+	service service.InboundMessageService
+}
+
+// Compile-time interface compliance check
+var _ InboundMessageHandler = (*inboundMessageHandler)(nil)
+
+// NewInboundMessageHandler creates a new inbound message handler
+func NewInboundMessageHandler(service service.InboundMessageService) InboundMessageHandler {
+	return &inboundMessageHandler{
+		service: service,
+	}
+}
+
+// RegisterRoutes registers inbound message routes
+func (h *inboundMessageHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/callbacks/inbound", h.Receive)
+	router.GET("/inbound-messages", h.List)
+}
+
+// Receive godoc
+// @Summary      Receive inbound message
+// @Description  Callback for SMS providers to deliver a reply from a recipient. Replies consisting of a stop keyword
+// @Description  (STOP, UNSUBSCRIBE) suppress the sender, start keywords (START) lift an opt-out, and an auto-reply
+// @Description  is queued when one is configured for the keyword.
+// @Tags         callbacks
+// @Accept       json
+// @Produce      json
+// @Param        message  body      dto.InboundMessageRequest  true  "Inbound message"
+// @Success      200      {object}  customresponse.CustomResponse{data=dto.InboundMessageResponse}
+// @Failure      400      {object}  customresponse.CustomResponse
+// @Failure      500      {object}  customresponse.CustomResponse
+// @Router       /callbacks/inbound [post]
+func (h *inboundMessageHandler) Receive(c *gin.Context) {
+	var req dto.InboundMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		customresponse.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	message, err := h.service.Receive(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	customresponse.Success(c, http.StatusOK, dto.ToInboundMessageResponse(message))
+}
+
+// List godoc
+// @Summary      List inbound messages
+// @Description  Get the replies received from recipients, most recent first, with pagination
+// @Tags         inbound
+// @Accept       json
+// @Produce      json
+// @Param        phoneNumber  query     string  false  "Only replies from this phone number"
+// @Param        limit        query     int     false  "Limit"   default(10)
+// @Param        offset       query     int     false  "Offset"  default(0)
+// @Success      200          {object}  customresponse.CustomResponse{data=[]dto.InboundMessageResponse}
+// @Failure      500          {object}  customresponse.CustomResponse
+// @Router       /inbound-messages [get]
+func (h *inboundMessageHandler) List(c *gin.Context) {
+	limit, offset := parsePagination(c)
+
+	messages, err := h.service.List(c.Request.Context(), c.Query("phoneNumber"), limit, offset)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responses := make([]dto.InboundMessageResponse, len(messages))
+	for i, message := range messages {
+		responses[i] = dto.ToInboundMessageResponse(message)
+	}
+
+	customresponse.Success(c, http.StatusOK, responses)
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/pkg/customresponse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock InboundMessageService
+type MockInboundMessageService struct {
+	mock.Mock
+}
+
+func (m *MockInboundMessageService) Receive(ctx context.Context, req dto.InboundMessageRequest) (*domain.InboundMessage, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InboundMessage), args.Error(1)
+}
+
+func (m *MockInboundMessageService) List(ctx context.Context, phoneNumber string, limit, offset int) ([]*domain.InboundMessage, error) {
+	args := m.Called(ctx, phoneNumber, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.InboundMessage), args.Error(1)
+}
+
+func TestInboundMessageHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	replyID := uint(42)
+	stop := &domain.InboundMessage{ID: 1, PhoneNumber: "+905551111111", Content: "STOP", Keyword: "STOP", Action: domain.InboundActionStop, ReplyMessageID: &replyID}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		requestBody    string
+		mockSetup      func(*MockInboundMessageService)
+		expectedStatus int
+		expectedCode   string
+		validateBody   func(*testing.T, []byte)
+	}{
+		{
+			name:        "receive - stop keyword",
+			method:      http.MethodPost,
+			path:        "/api/callbacks/inbound",
+			requestBody: `{"from": "+905551111111", "content": "STOP"}`,
+			mockSetup: func(m *MockInboundMessageService) {
+				m.On("Receive", mock.Anything, dto.InboundMessageRequest{From: "+905551111111", Content: "STOP"}).Return(stop, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, body []byte) {
+				var resp struct {
+					Data dto.InboundMessageResponse `json:"data"`
+				}
+				assert.NoError(t, json.Unmarshal(body, &resp))
+				assert.Equal(t, domain.InboundActionStop, resp.Data.Action)
+				assert.Equal(t, uint(42), *resp.Data.ReplyMessageID)
+			},
+		},
+		{
+			name:           "receive - invalid sender",
+			method:         http.MethodPost,
+			path:           "/api/callbacks/inbound",
+			requestBody:    `{"from": "05551111111", "content": "STOP"}`,
+			mockSetup:      func(m *MockInboundMessageService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name:           "receive - missing content",
+			method:         http.MethodPost,
+			path:           "/api/callbacks/inbound",
+			requestBody:    `{"from": "+905551111111"}`,
+			mockSetup:      func(m *MockInboundMessageService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name:        "receive - suppression update failed",
+			method:      http.MethodPost,
+			path:        "/api/callbacks/inbound",
+			requestBody: `{"from": "+905551111111", "content": "STOP"}`,
+			mockSetup: func(m *MockInboundMessageService) {
+				m.On("Receive", mock.Anything, mock.Anything).Return(nil, apperror.ErrSuppressionCreateFailed.WithError(errors.New("db error")))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   apperror.ErrCodeSuppressionCreateFailed,
+		},
+		{
+			name:   "list - by phone number",
+			method: http.MethodGet,
+			path:   "/api/inbound-messages?phoneNumber=%2B905551111111&limit=5",
+			mockSetup: func(m *MockInboundMessageService) {
+				m.On("List", mock.Anything, "+905551111111", 5, 0).Return([]*domain.InboundMessage{stop}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "list - error",
+			method: http.MethodGet,
+			path:   "/api/inbound-messages",
+			mockSetup: func(m *MockInboundMessageService) {
+				m.On("List", mock.Anything, "", 10, 0).Return(nil, apperror.ErrInboundMessageListFailed)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   apperror.ErrCodeInboundMessageListFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockInboundMessageService)
+			tt.mockSetup(mockService)
+
+			router := gin.New()
+			router.Use(errorHandlerMiddleware())
+			NewInboundMessageHandler(mockService).RegisterRoutes(router.Group("/api"))
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedCode != "" {
+				var resp customresponse.CustomResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.False(t, resp.Success)
+				assert.Equal(t, tt.expectedCode, resp.Error.Code)
+			}
+			if tt.validateBody != nil {
+				tt.validateBody(t, w.Body.Bytes())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
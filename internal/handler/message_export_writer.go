@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/srcndev/message-service/internal/dto"
+)
+
+// messageExportWriter encodes exported messages one at a time, output is buffered until Flush
+type messageExportWriter interface {
+	Write(message *domain.Message) error
+	Flush() error
+}
+
+// newMessageExportWriter creates the writer of an export format with its content type
+func newMessageExportWriter(format string, w io.Writer) (messageExportWriter, string) {
+	if format == dto.ExportFormatNDJSON {
+		buffered := bufio.NewWriter(w)
+		return &ndjsonExportWriter{buffered: buffered, encoder: json.NewEncoder(buffered)}, "application/x-ndjson"
+	}
+	return &csvExportWriter{writer: csv.NewWriter(w)}, "text/csv; charset=utf-8"
+}
+
+// csvExportWriter writes a header row followed by one row per message
+type csvExportWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (w *csvExportWriter) Write(message *domain.Message) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	return w.writer.Write(escapeFormulas(dto.ToExportRecord(message)))
+}
+
+func (w *csvExportWriter) Flush() error {
+	// An empty export still gets its header
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func (w *csvExportWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+	return w.writer.Write(dto.MessageExportColumns)
+}
+
+// formulaPrefixes start cells that spreadsheets evaluate as formulas
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeFormulas quotes cells a spreadsheet would evaluate with a leading apostrophe,
+// phone numbers included since they start with +
+func escapeFormulas(record []string) []string {
+	for i, cell := range record {
+		if cell != "" && strings.ContainsRune(formulaPrefixes, rune(cell[0])) {
+			record[i] = "'" + cell
+		}
+	}
+	return record
+}
+
+// ndjsonExportWriter writes one message response per line
+type ndjsonExportWriter struct {
+	buffered *bufio.Writer
+	encoder  *json.Encoder
+}
+
+func (w *ndjsonExportWriter) Write(message *domain.Message) error {
+	return w.encoder.Encode(dto.ToResponse(message))
+}
+
+func (w *ndjsonExportWriter) Flush() error {
+	return w.buffered.Flush()
+}
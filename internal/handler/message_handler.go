@@ -1,21 +1,34 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/srcndev/message-service/internal/domain"
 	"github.com/srcndev/message-service/internal/dto"
 	"github.com/srcndev/message-service/internal/service"
+	"github.com/srcndev/message-service/pkg/customerror"
 	"github.com/srcndev/message-service/pkg/customresponse"
+	"github.com/srcndev/message-service/pkg/logger"
 )
 
+// exportFlushInterval is the number of exported messages written between flushes to the client
+const exportFlushInterval = 500
+
 // MessageHandler interface defines message HTTP handlers
 type MessageHandler interface {
 	Create(c *gin.Context)
+	CreateBatch(c *gin.Context)
 	GetByID(c *gin.Context)
 	List(c *gin.Context)
 	ListSent(c *gin.Context)
+	ListFailed(c *gin.Context)
+	Export(c *gin.Context)
 	Update(c *gin.Context)
 	Delete(c *gin.Context)
 	RegisterRoutes(router *gin.RouterGroup)
@@ -23,42 +36,69 @@ type MessageHandler interface {
 
 // messageHandler is the private implementation of MessageHandler interface
 type messageHandler struct {
-	service service.MessageService
+	service          service.MessageService
+	createMiddleware []gin.HandlerFunc
+}
+
+// MessageHandlerOption configures a message handler
+type MessageHandlerOption func(*messageHandler)
+
+// WithCreateMiddleware runs middleware (e.g. idempotency keys) in front of the create endpoints only
+func WithCreateMiddleware(middleware ...gin.HandlerFunc) MessageHandlerOption {
+	return func(h *messageHandler) {
+		h.createMiddleware = middleware
+	}
 }
 
 // Compile-time interface compliance check
 var _ MessageHandler = (*messageHandler)(nil)
 
 // NewMessageHandler creates a new message handler
-func NewMessageHandler(service service.MessageService) MessageHandler {
-	return &messageHandler{
+func NewMessageHandler(service service.MessageService, opts ...MessageHandlerOption) MessageHandler {
+	h := &messageHandler{
 		service: service,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // RegisterRoutes registers all message routes
 func (h *messageHandler) RegisterRoutes(router *gin.RouterGroup) {
 	messages := router.Group("/messages")
 	{
-		messages.POST("", h.Create)
+		messages.POST("", h.withCreateMiddleware(h.Create)...)
+		messages.POST("/batch", h.withCreateMiddleware(h.CreateBatch)...)
 		messages.GET("/:id", h.GetByID)
 		messages.GET("", h.List)
 		messages.GET("/sent", h.ListSent)
+		messages.GET("/failed", h.ListFailed)
+		messages.GET("/export", h.Export)
 		messages.PUT("/:id", h.Update)
 		messages.DELETE("/:id", h.Delete)
 	}
 }
 
+// withCreateMiddleware returns the handler chain of a create endpoint
+func (h *messageHandler) withCreateMiddleware(handler gin.HandlerFunc) []gin.HandlerFunc {
+	return append(append([]gin.HandlerFunc{}, h.createMiddleware...), handler)
+}
+
 // Create godoc
 // @Summary      Create a new message
 // @Description  Create a new message to be sent via webhook
+// @Description  A recipient on the suppression list is rejected with 422 RECIPIENT_SUPPRESSED, or stored as suppressed when SUPPRESSION_MODE=suppress.
 // @Tags         messages
 // @Accept       json
 // @Produce      json
-// @Param        message  body      dto.CreateMessageRequest  true  "Message details"
-// @Success      201      {object}  customresponse.CustomResponse{data=dto.MessageResponse}
-// @Failure      400      {object}  customresponse.CustomResponse
-// @Failure      500      {object}  customresponse.CustomResponse
+// @Param        message          body      dto.CreateMessageRequest  true   "Message details"
+// @Param        Idempotency-Key  header    string                    false  "Replays the first response for retries with the same key"
+// @Success      201              {object}  customresponse.CustomResponse{data=dto.MessageResponse}
+// @Failure      400              {object}  customresponse.CustomResponse
+// @Failure      409              {object}  customresponse.CustomResponse
+// @Failure      422              {object}  customresponse.CustomResponse
+// @Failure      500              {object}  customresponse.CustomResponse
 // @Router       /messages [post]
 func (h *messageHandler) Create(c *gin.Context) {
 	var req dto.CreateMessageRequest
@@ -76,6 +116,73 @@ func (h *messageHandler) Create(c *gin.Context) {
 	customresponse.Success(c, http.StatusCreated, dto.ToResponse(message))
 }
 
+// CreateBatch godoc
+// @Summary      Create messages in bulk
+// @Description  Create up to 100 messages in one request. Every item is validated on its own and the valid ones are
+// @Description  stored in a single transaction. Responds 201 when all items were created, 207 with per-item errors otherwise.
+// @Tags         messages
+// @Accept       json
+// @Produce      json
+// @Param        messages         body      dto.CreateMessageBatchRequest  true   "Messages to create"
+// @Param        Idempotency-Key  header    string                         false  "Replays the first response for retries with the same key"
+// @Success      201              {object}  customresponse.CustomResponse{data=dto.MessageBatchResponse}
+// @Success      207              {object}  customresponse.CustomResponse{data=dto.MessageBatchResponse}
+// @Failure      400              {object}  customresponse.CustomResponse
+// @Failure      409              {object}  customresponse.CustomResponse
+// @Failure      422              {object}  customresponse.CustomResponse
+// @Failure      500              {object}  customresponse.CustomResponse
+// @Router       /messages/batch [post]
+func (h *messageHandler) CreateBatch(c *gin.Context) {
+	var req dto.CreateMessageBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		customresponse.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	// Items failing field validation are reported, the others go to the service
+	resp := dto.MessageBatchResponse{Results: make([]dto.MessageBatchItemResponse, len(req.Messages))}
+	valid := make([]dto.CreateMessageRequest, 0, len(req.Messages))
+	positions := make([]int, 0, len(req.Messages))
+	for i, item := range req.Messages {
+		resp.Results[i].Index = i
+		if err := binding.Validator.ValidateStruct(&item); err != nil {
+			resp.Results[i].Error = &customresponse.ErrorInfo{Code: "VALIDATION_ERROR", Message: err.Error()}
+			continue
+		}
+		valid = append(valid, item)
+		positions = append(positions, i)
+	}
+
+	results, err := h.service.CreateBatch(c.Request.Context(), valid)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	for i, result := range results {
+		item := &resp.Results[positions[i]]
+		if result.Err != nil {
+			item.Error = batchItemError(result.Err)
+			continue
+		}
+		item.ID = &result.Message.ID
+	}
+
+	for _, item := range resp.Results {
+		if item.Error != nil {
+			resp.Failed++
+		} else {
+			resp.Created++
+		}
+	}
+
+	status := http.StatusCreated
+	if resp.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+	customresponse.Success(c, status, resp)
+}
+
 // GetByID godoc
 // @Summary      Get message by ID
 // @Description  Get a single message by its ID
@@ -106,89 +213,196 @@ func (h *messageHandler) GetByID(c *gin.Context) {
 
 // List godoc
 // @Summary      List messages
-// @Description  Get a list of messages with pagination
+// @Description  Get a list of messages, newest first, with filters and cursor pagination on (createdAt, id)
 // @Tags         messages
 // @Accept       json
 // @Produce      json
-// @Param        limit   query     int  false  "Limit"   default(10)
-// @Param        offset  query     int  false  "Offset"  default(0)
-// @Success      200     {object}  customresponse.CustomResponse{data=[]dto.MessageResponse}
-// @Failure      500     {object}  customresponse.CustomResponse
+// @Param        limit        query     int     false  "Page size"  default(10)
+// @Param        cursor       query     string  false  "Opaque cursor from meta.nextCursor or meta.prevCursor"
+// @Param        withTotal    query     bool    false  "Also count every matching message into meta.total"
+// @Param        offset       query     int     false  "Deprecated offset paging, used only without a cursor and returns no meta"
+// @Param        status       query     string  false  "Only messages in this status"  Enums(pending, processing, sent, failed, cancelled, expired, suppressed, unknown, delivered, undelivered)
+// @Param        phoneNumber  query     string  false  "Only messages to this E.164 number (encode + as %2B)"
+// @Param        createdFrom  query     string  false  "Created at or after (RFC3339)"
+// @Param        createdTo    query     string  false  "Created before (RFC3339)"
+// @Param        sentFrom     query     string  false  "Sent at or after (RFC3339)"
+// @Param        sentTo       query     string  false  "Sent before (RFC3339)"
+// @Param        q            query     string  false  "Case-insensitive search in the content"
+// @Success      200          {object}  customresponse.CustomResponse{data=[]dto.MessageResponse}
+// @Failure      400          {object}  customresponse.CustomResponse
+// @Failure      500          {object}  customresponse.CustomResponse
 // @Router       /messages [get]
 func (h *messageHandler) List(c *gin.Context) {
-	limit := 10
-	offset := 0
-
-	if l := c.Query("limit"); l != "" {
-		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
-			limit = parsed
-		}
+	var query dto.MessageFilterQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		customresponse.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
 	}
 
-	if o := c.Query("offset"); o != "" {
-		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
-			offset = parsed
+	if useOffset(c) {
+		limit, offset := parsePagination(c)
+		messages, err := h.service.List(c.Request.Context(), query.ToFilter(), limit, offset)
+		if err != nil {
+			c.Error(err)
+			return
 		}
-	}
-
-	messages, err := h.service.List(c.Request.Context(), limit, offset)
-	if err != nil {
-		c.Error(err)
+		customresponse.Success(c, http.StatusOK, toResponses(messages))
 		return
 	}
 
-	responses := make([]dto.MessageResponse, len(messages))
-	for i, message := range messages {
-		responses[i] = dto.ToResponse(message)
-	}
-
-	customresponse.Success(c, http.StatusOK, responses)
+	h.listPage(c, query.ToFilter(), domain.SortByCreatedAt)
 }
 
 // ListSent godoc
 // @Summary      List sent messages
-// @Description  Get a list of sent messages with pagination
+// @Description  Get a list of sent messages, most recently sent first, with cursor pagination on (sentAt, id)
 // @Tags         messages
 // @Accept       json
 // @Produce      json
-// @Param        limit   query     int  false  "Limit"   default(10)
-// @Param        offset  query     int  false  "Offset"  default(0)
-// @Success      200     {object}  customresponse.CustomResponse{data=[]dto.MessageResponse}
-// @Failure      500     {object}  customresponse.CustomResponse
+// @Param        limit        query     int     false  "Page size"  default(10)
+// @Param        cursor       query     string  false  "Opaque cursor from meta.nextCursor or meta.prevCursor"
+// @Param        withTotal    query     bool    false  "Also count every matching message into meta.total"
+// @Param        offset       query     int     false  "Deprecated offset paging, used only without a cursor and returns no meta"
+// @Success      200          {object}  customresponse.CustomResponse{data=[]dto.MessageResponse}
+// @Failure      400          {object}  customresponse.CustomResponse
+// @Failure      500          {object}  customresponse.CustomResponse
 // @Router       /messages/sent [get]
 func (h *messageHandler) ListSent(c *gin.Context) {
-	limit := 10
-	offset := 0
+	if useOffset(c) {
+		limit, offset := parsePagination(c)
+		messages, err := h.service.ListSentMessages(c.Request.Context(), limit, offset)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		customresponse.Success(c, http.StatusOK, toResponses(messages))
+		return
+	}
 
-	if l := c.Query("limit"); l != "" {
-		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
-			limit = parsed
+	h.listPage(c, domain.MessageFilter{Status: domain.StatusSent}, domain.SortBySentAt)
+}
+
+// ListFailed godoc
+// @Summary      List failed messages
+// @Description  Get a list of messages that exhausted their delivery attempts, most recent first, with cursor pagination on (failedAt, id)
+// @Tags         messages
+// @Accept       json
+// @Produce      json
+// @Param        limit        query     int     false  "Page size"  default(10)
+// @Param        cursor       query     string  false  "Opaque cursor from meta.nextCursor or meta.prevCursor"
+// @Param        withTotal    query     bool    false  "Also count every matching message into meta.total"
+// @Param        offset       query     int     false  "Deprecated offset paging, used only without a cursor and returns no meta"
+// @Success      200          {object}  customresponse.CustomResponse{data=[]dto.MessageResponse}
+// @Failure      400          {object}  customresponse.CustomResponse
+// @Failure      500          {object}  customresponse.CustomResponse
+// @Router       /messages/failed [get]
+func (h *messageHandler) ListFailed(c *gin.Context) {
+	if useOffset(c) {
+		limit, offset := parsePagination(c)
+		messages, err := h.service.ListFailedMessages(c.Request.Context(), limit, offset)
+		if err != nil {
+			c.Error(err)
+			return
 		}
+		customresponse.Success(c, http.StatusOK, toResponses(messages))
+		return
 	}
 
-	if o := c.Query("offset"); o != "" {
-		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
-			offset = parsed
+	h.listPage(c, domain.MessageFilter{Status: domain.StatusFailed}, domain.SortByFailedAt)
+}
+
+// listPage responds with a cursor page of the messages matching the filter and its pagination meta
+func (h *messageHandler) listPage(c *gin.Context, filter domain.MessageFilter, sortBy domain.MessageSortKey) {
+	limit, _ := parsePagination(c)
+	withTotal, _ := strconv.ParseBool(c.Query("withTotal"))
+
+	page := domain.MessagePageQuery{SortBy: sortBy, Limit: limit, WithTotal: withTotal}
+	if cursor := c.Query("cursor"); cursor != "" {
+		if err := dto.ApplyMessageCursor(&page, cursor); err != nil {
+			c.Error(err)
+			return
 		}
 	}
 
-	messages, err := h.service.ListSentMessages(c.Request.Context(), limit, offset)
+	result, err := h.service.ListPage(c.Request.Context(), filter, page)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	responses := make([]dto.MessageResponse, len(messages))
-	for i, message := range messages {
-		responses[i] = dto.ToResponse(message)
+	customresponse.SuccessWithMeta(c, http.StatusOK, toResponses(result.Messages), dto.ToPageMeta(result, page))
+}
+
+// Export godoc
+// @Summary      Export messages
+// @Description  Stream every message matching the filters of the list endpoint as CSV or NDJSON, oldest first. The export is not paginated,
+// @Description  rows are read through a database cursor and written as they arrive.
+// @Tags         messages
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Param        format       query     string  false  "Export format"  Enums(csv, ndjson)  default(csv)
+// @Param        status       query     string  false  "Only messages in this status"  Enums(pending, processing, sent, failed, cancelled, expired, suppressed, unknown, delivered, undelivered)
+// @Param        phoneNumber  query     string  false  "Only messages to this E.164 number (encode + as %2B)"
+// @Param        createdFrom  query     string  false  "Created at or after (RFC3339)"
+// @Param        createdTo    query     string  false  "Created before (RFC3339)"
+// @Param        sentFrom     query     string  false  "Sent at or after (RFC3339)"
+// @Param        sentTo       query     string  false  "Sent before (RFC3339)"
+// @Param        q            query     string  false  "Case-insensitive search in the content"
+// @Success      200          {file}    file
+// @Failure      400          {object}  customresponse.CustomResponse
+// @Failure      500          {object}  customresponse.CustomResponse
+// @Router       /messages/export [get]
+func (h *messageHandler) Export(c *gin.Context) {
+	var query dto.MessageExportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		customresponse.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+	if query.Format == "" {
+		query.Format = dto.ExportFormatCSV
+	}
+
+	// A large export outlasts the server write timeout, unsupported writers (tests) keep their deadline
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	writer, contentType := newMessageExportWriter(query.Format, c.Writer)
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="messages.%s"`, query.Format))
+
+	exported := 0
+	err := h.service.Export(c.Request.Context(), query.ToFilter(), func(message *domain.Message) error {
+		if err := writer.Write(message); err != nil {
+			return err
+		}
+		exported++
+		if exported%exportFlushInterval == 0 {
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err == nil {
+		return
 	}
 
-	customresponse.Success(c, http.StatusOK, responses)
+	// Nothing was sent yet, so the failure can still be reported as a regular error response
+	if !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		c.Error(err)
+		return
+	}
+	logger.Error("Message export aborted after %d messages: %v", exported, err)
 }
 
 // Update godoc
 // @Summary      Update message
-// @Description  Update an existing message by ID
+// @Description  Update a pending message by ID, it can be rescheduled with sendAt or cancelled with status "cancelled", a message held as "unknown" only takes status "pending" (send again) or "cancelled", other messages that are no longer pending are rejected
 // @Tags         messages
 // @Accept       json
 // @Produce      json
@@ -197,6 +411,7 @@ func (h *messageHandler) ListSent(c *gin.Context) {
 // @Success      200      {object}  customresponse.CustomResponse{data=dto.MessageResponse}
 // @Failure      400      {object}  customresponse.CustomResponse
 // @Failure      404      {object}  customresponse.CustomResponse
+// @Failure      409      {object}  customresponse.CustomResponse
 // @Failure      500      {object}  customresponse.CustomResponse
 // @Router       /messages/{id} [put]
 func (h *messageHandler) Update(c *gin.Context) {
@@ -247,3 +462,52 @@ func (h *messageHandler) Delete(c *gin.Context) {
 
 	customresponse.Success(c, http.StatusNoContent, map[string]interface{}(nil))
 }
+
+// batchItemError describes why a batch item was rejected, client errors keep their detail
+func batchItemError(err error) *customresponse.ErrorInfo {
+	var appErr *customerror.CustomError
+	if !errors.As(err, &appErr) {
+		return &customresponse.ErrorInfo{Code: "INTERNAL_ERROR", Message: "Internal server error"}
+	}
+
+	message := appErr.Message
+	if appErr.Err != nil && appErr.GetStatusCode() < http.StatusInternalServerError {
+		message = fmt.Sprintf("%s: %v", appErr.Message, appErr.Err)
+	}
+	return &customresponse.ErrorInfo{Code: appErr.Code, Message: message}
+}
+
+// useOffset reports whether the request pages with the deprecated offset instead of a cursor
+func useOffset(c *gin.Context) bool {
+	_, hasOffset := c.GetQuery("offset")
+	return hasOffset && c.Query("cursor") == ""
+}
+
+// toResponses converts messages to their response DTOs
+func toResponses(messages []*domain.Message) []dto.MessageResponse {
+	responses := make([]dto.MessageResponse, len(messages))
+	for i, message := range messages {
+		responses[i] = dto.ToResponse(message)
+	}
+	return responses
+}
+
+// parsePagination reads limit and offset query parameters with defaults
+func parsePagination(c *gin.Context) (int, int) {
+	limit := 10
+	offset := 0
+
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+
+	if o := c.Query("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	return limit, offset
+}
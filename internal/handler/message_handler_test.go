@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/srcndev/message-service/internal/apperror"
 	"github.com/srcndev/message-service/internal/domain"
 	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/internal/service"
 	"github.com/srcndev/message-service/pkg/customerror"
 	"github.com/srcndev/message-service/pkg/customresponse"
 	"github.com/stretchr/testify/assert"
@@ -33,6 +35,14 @@ func (m *MockMessageService) Create(ctx context.Context, req dto.CreateMessageRe
 	return args.Get(0).(*domain.Message), args.Error(1)
 }
 
+func (m *MockMessageService) CreateBatch(ctx context.Context, reqs []dto.CreateMessageRequest) ([]service.BatchResult, error) {
+	args := m.Called(ctx, reqs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]service.BatchResult), args.Error(1)
+}
+
 func (m *MockMessageService) GetByID(ctx context.Context, id uint) (*domain.Message, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -41,14 +51,22 @@ func (m *MockMessageService) GetByID(ctx context.Context, id uint) (*domain.Mess
 	return args.Get(0).(*domain.Message), args.Error(1)
 }
 
-func (m *MockMessageService) List(ctx context.Context, limit, offset int) ([]*domain.Message, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockMessageService) List(ctx context.Context, filter domain.MessageFilter, limit, offset int) ([]*domain.Message, error) {
+	args := m.Called(ctx, filter, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*domain.Message), args.Error(1)
 }
 
+func (m *MockMessageService) ListPage(ctx context.Context, filter domain.MessageFilter, page domain.MessagePageQuery) (*domain.MessagePage, error) {
+	args := m.Called(ctx, filter, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MessagePage), args.Error(1)
+}
+
 func (m *MockMessageService) ListSentMessages(ctx context.Context, limit, offset int) ([]*domain.Message, error) {
 	args := m.Called(ctx, limit, offset)
 	if args.Get(0) == nil {
@@ -57,6 +75,27 @@ func (m *MockMessageService) ListSentMessages(ctx context.Context, limit, offset
 	return args.Get(0).([]*domain.Message), args.Error(1)
 }
 
+func (m *MockMessageService) ListFailedMessages(ctx context.Context, limit, offset int) ([]*domain.Message, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+
+// Export feeds the messages given to Return to fn, then returns the error
+func (m *MockMessageService) Export(ctx context.Context, filter domain.MessageFilter, fn func(*domain.Message) error) error {
+	args := m.Called(ctx, filter)
+	if messages, ok := args.Get(0).([]*domain.Message); ok {
+		for _, message := range messages {
+			if err := fn(message); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
 func (m *MockMessageService) Update(ctx context.Context, id uint, req dto.UpdateMessageRequest) (*domain.Message, error) {
 	args := m.Called(ctx, id, req)
 	if args.Get(0) == nil {
@@ -78,8 +117,64 @@ func (m *MockMessageService) GetPendingMessages(ctx context.Context, limit int)
 	return args.Get(0).([]*domain.Message), args.Error(1)
 }
 
-func (m *MockMessageService) SetSent(ctx context.Context, id uint, messageID string) error {
-	args := m.Called(ctx, id, messageID)
+func (m *MockMessageService) MarkUnknown(ctx context.Context, id uint, owner string) (bool, error) {
+	args := m.Called(ctx, id, owner)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMessageService) ClaimPendingMessages(ctx context.Context, owner string, limit int, reserved map[domain.MessagePriority]int, leaseDuration time.Duration) ([]*domain.Message, error) {
+	args := m.Called(ctx, owner, limit, reserved, leaseDuration)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+
+func (m *MockMessageService) ExpireMessage(ctx context.Context, id uint, owner string) error {
+	args := m.Called(ctx, id, owner)
+	return args.Error(0)
+}
+
+func (m *MockMessageService) CreateReply(ctx context.Context, phoneNumber, content string) (*domain.Message, error) {
+	args := m.Called(ctx, phoneNumber, content)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+
+func (m *MockMessageService) SuppressMessage(ctx context.Context, id uint, owner string) error {
+	args := m.Called(ctx, id, owner)
+	return args.Error(0)
+}
+
+func (m *MockMessageService) ExpirePendingMessages(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMessageService) ReleaseExpiredLeases(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMessageService) ReleaseLease(ctx context.Context, id uint, owner string) error {
+	args := m.Called(ctx, id, owner)
+	return args.Error(0)
+}
+
+func (m *MockMessageService) SetSent(ctx context.Context, id uint, owner, messageID, provider string) error {
+	args := m.Called(ctx, id, owner, messageID, provider)
+	return args.Error(0)
+}
+
+func (m *MockMessageService) RecordFailedAttempt(ctx context.Context, id uint, owner, errCode, errMessage string, nextAttemptAt time.Time) error {
+	args := m.Called(ctx, id, owner, errCode, errMessage, nextAttemptAt)
+	return args.Error(0)
+}
+
+func (m *MockMessageService) SetFailed(ctx context.Context, id uint, owner, errCode, errMessage string) error {
+	args := m.Called(ctx, id, owner, errCode, errMessage)
 	return args.Error(0)
 }
 
@@ -163,6 +258,39 @@ func TestMessageHandler_Create(t *testing.T) {
 				assert.Equal(t, "VALIDATION_ERROR", resp.Error.Code)
 			},
 		},
+		{
+			name:        "success - creates message from template",
+			requestBody: `{"phoneNumber": "+905551111111", "templateId": 1, "variables": {"code": "1234"}}`,
+			mockSetup: func(m *MockMessageService) {
+				m.On("Create", mock.Anything, mock.MatchedBy(func(req dto.CreateMessageRequest) bool {
+					return req.TemplateID != nil && *req.TemplateID == 1 && req.Variables["code"] == "1234"
+				})).Return(&domain.Message{ID: 1, PhoneNumber: "+905551111111", Content: "Your code is 1234"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "error - content and template both given",
+			requestBody:    `{"phoneNumber": "+905551111111", "content": "Test", "templateId": 1}`,
+			mockSetup:      func(m *MockMessageService) {},
+			expectedStatus: http.StatusBadRequest,
+			validateBody: func(t *testing.T, body []byte) {
+				var resp customresponse.CustomResponse
+				json.Unmarshal(body, &resp)
+				assert.Equal(t, "VALIDATION_ERROR", resp.Error.Code)
+			},
+		},
+		{
+			name:           "error - unknown priority",
+			requestBody:    `{"phoneNumber": "+905551111111", "content": "Test", "priority": "urgent"}`,
+			mockSetup:      func(m *MockMessageService) {},
+			expectedStatus: http.StatusBadRequest,
+			validateBody: func(t *testing.T, body []byte) {
+				var resp customresponse.CustomResponse
+				json.Unmarshal(body, &resp)
+				assert.False(t, resp.Success)
+				assert.Equal(t, "VALIDATION_ERROR", resp.Error.Code)
+			},
+		},
 		{
 			name: "error - service error",
 			requestBody: dto.CreateMessageRequest{
@@ -211,6 +339,239 @@ func TestMessageHandler_Create(t *testing.T) {
 	}
 }
 
+func TestMessageHandler_CreateMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// The middleware answers on its own, the service is never reached
+	teapot := func(c *gin.Context) { c.AbortWithStatus(http.StatusTeapot) }
+	router := setupRouter(NewMessageHandler(new(MockMessageService), WithCreateMiddleware(teapot)))
+
+	tests := []struct {
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{method: http.MethodPost, path: "/api/messages", expectedStatus: http.StatusTeapot},
+		{method: http.MethodPost, path: "/api/messages/batch", expectedStatus: http.StatusTeapot},
+		{method: http.MethodGet, path: "/api/messages/abc", expectedStatus: http.StatusBadRequest},
+		{method: http.MethodPut, path: "/api/messages/abc", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(`{}`)))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestMessageHandler_CreateBatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockSetup      func(*MockMessageService)
+		expectedStatus int
+		validateBody   func(*testing.T, dto.MessageBatchResponse)
+	}{
+		{
+			name:        "success - creates all messages",
+			requestBody: `{"messages": [{"phoneNumber": "+905551111111", "content": "One"}, {"phoneNumber": "+905552222222", "content": "Two"}]}`,
+			mockSetup: func(m *MockMessageService) {
+				m.On("CreateBatch", mock.Anything, mock.MatchedBy(func(reqs []dto.CreateMessageRequest) bool {
+					return len(reqs) == 2
+				})).Return([]service.BatchResult{
+					{Message: &domain.Message{ID: 1}},
+					{Message: &domain.Message{ID: 2}},
+				}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			validateBody: func(t *testing.T, resp dto.MessageBatchResponse) {
+				assert.Equal(t, 2, resp.Created)
+				assert.Equal(t, 0, resp.Failed)
+				assert.Equal(t, uint(2), *resp.Results[1].ID)
+			},
+		},
+		{
+			name: "partial - reports invalid items by position",
+			requestBody: `{"messages": [
+				{"phoneNumber": "123", "content": "Bad phone"},
+				{"phoneNumber": "+905551111111", "content": "One"},
+				{"phoneNumber": "+905552222222", "content": "Past", "sendAt": "2020-01-01T00:00:00Z"}
+			]}`,
+			mockSetup: func(m *MockMessageService) {
+				m.On("CreateBatch", mock.Anything, mock.MatchedBy(func(reqs []dto.CreateMessageRequest) bool {
+					return len(reqs) == 2 && reqs[0].Content == "One"
+				})).Return([]service.BatchResult{
+					{Message: &domain.Message{ID: 7}},
+					{Err: apperror.ErrSendAtInPast},
+				}, nil)
+			},
+			expectedStatus: http.StatusMultiStatus,
+			validateBody: func(t *testing.T, resp dto.MessageBatchResponse) {
+				assert.Equal(t, 1, resp.Created)
+				assert.Equal(t, 2, resp.Failed)
+				assert.Equal(t, "VALIDATION_ERROR", resp.Results[0].Error.Code)
+				assert.Equal(t, uint(7), *resp.Results[1].ID)
+				assert.Equal(t, 2, resp.Results[2].Index)
+				assert.Equal(t, apperror.ErrCodeSendAtInPast, resp.Results[2].Error.Code)
+			},
+		},
+		{
+			name:           "error - empty batch",
+			requestBody:    `{"messages": []}`,
+			mockSetup:      func(m *MockMessageService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "error - insert fails",
+			requestBody: `{"messages": [{"phoneNumber": "+905551111111", "content": "One"}]}`,
+			mockSetup: func(m *MockMessageService) {
+				m.On("CreateBatch", mock.Anything, mock.Anything).Return(nil, apperror.ErrMessageCreateFailed)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockMessageService)
+			tt.mockSetup(mockService)
+
+			router := setupRouter(NewMessageHandler(mockService))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/messages/batch", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.validateBody != nil {
+				var resp struct {
+					Data dto.MessageBatchResponse `json:"data"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				tt.validateBody(t, resp.Data)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageHandler_Export(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	createdAt := time.Date(2025, 11, 9, 10, 0, 0, 0, time.UTC)
+	messages := []*domain.Message{
+		{ID: 1, PhoneNumber: "+905551111111", Content: "Hello, world", Status: domain.StatusSent, Priority: domain.PriorityNormal, CreatedAt: createdAt, UpdatedAt: createdAt},
+		{ID: 2, PhoneNumber: "+905552222222", Content: "=HYPERLINK(\"http://evil.test\")", Status: domain.StatusSent, Priority: domain.PriorityHigh, CreatedAt: createdAt, UpdatedAt: createdAt},
+	}
+
+	tests := []struct {
+		name                string
+		query               string
+		mockSetup           func(*MockMessageService)
+		expectedStatus      int
+		expectedContentType string
+		validateBody        func(*testing.T, string)
+	}{
+		{
+			name:  "success - csv by default",
+			query: "",
+			mockSetup: func(m *MockMessageService) {
+				m.On("Export", mock.Anything, domain.MessageFilter{}).Return(messages, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			validateBody: func(t *testing.T, body string) {
+				lines := strings.Split(strings.TrimSpace(body), "\n")
+				assert.Len(t, lines, 3)
+				assert.True(t, strings.HasPrefix(lines[0], "id,phoneNumber,content,"))
+				assert.True(t, strings.HasPrefix(lines[1], `1,'+905551111111,"Hello, world",`))
+				assert.True(t, strings.HasPrefix(lines[2], `2,'+905552222222,"'=HYPERLINK(""http://evil.test"")",`))
+				assert.Contains(t, lines[2], "2025-11-09T10:00:00Z")
+			},
+		},
+		{
+			name:  "success - empty csv keeps the header",
+			query: "?status=failed",
+			mockSetup: func(m *MockMessageService) {
+				m.On("Export", mock.Anything, domain.MessageFilter{Status: domain.StatusFailed}).Return(nil, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			validateBody: func(t *testing.T, body string) {
+				assert.Equal(t, strings.Join(dto.MessageExportColumns, ",")+"\n", body)
+			},
+		},
+		{
+			name:  "success - ndjson with status filter",
+			query: "?format=ndjson&status=sent",
+			mockSetup: func(m *MockMessageService) {
+				m.On("Export", mock.Anything, domain.MessageFilter{Status: domain.StatusSent}).Return(messages, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+			validateBody: func(t *testing.T, body string) {
+				lines := strings.Split(strings.TrimSpace(body), "\n")
+				assert.Len(t, lines, 2)
+				var resp dto.MessageResponse
+				assert.NoError(t, json.Unmarshal([]byte(lines[1]), &resp))
+				assert.Equal(t, uint(2), resp.ID)
+				assert.Equal(t, domain.PriorityHigh, resp.Priority)
+			},
+		},
+		{
+			name:           "error - unknown format",
+			query:          "?format=xlsx",
+			mockSetup:      func(m *MockMessageService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "error - unknown status",
+			query:          "?status=bogus",
+			mockSetup:      func(m *MockMessageService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "error - query fails before any row",
+			query: "",
+			mockSetup: func(m *MockMessageService) {
+				m.On("Export", mock.Anything, domain.MessageFilter{}).Return(nil, apperror.ErrMessageExportFailed)
+			},
+			expectedStatus:      http.StatusInternalServerError,
+			expectedContentType: "application/json; charset=utf-8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockMessageService)
+			tt.mockSetup(mockService)
+
+			router := setupRouter(NewMessageHandler(mockService))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/messages/export"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedContentType != "" {
+				assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+			}
+			if tt.validateBody != nil {
+				tt.validateBody(t, w.Body.String())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 func TestMessageHandler_GetByID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -301,9 +662,9 @@ func TestMessageHandler_List(t *testing.T) {
 			name:        "success - default pagination",
 			queryParams: "",
 			mockSetup: func(m *MockMessageService) {
-				m.On("List", mock.Anything, 10, 0).Return([]*domain.Message{
+				m.On("ListPage", mock.Anything, domain.MessageFilter{}, domain.MessagePageQuery{SortBy: domain.SortByCreatedAt, Limit: 10}).Return(&domain.MessagePage{Messages: []*domain.Message{
 					{ID: 1, PhoneNumber: "+905551111111", Content: "Msg1", Status: domain.StatusPending},
-				}, nil)
+				}}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			validateBody: func(t *testing.T, body []byte) {
@@ -316,7 +677,7 @@ func TestMessageHandler_List(t *testing.T) {
 			name:        "success - custom pagination",
 			queryParams: "?limit=5&offset=10",
 			mockSetup: func(m *MockMessageService) {
-				m.On("List", mock.Anything, 5, 10).Return([]*domain.Message{}, nil)
+				m.On("List", mock.Anything, domain.MessageFilter{}, 5, 10).Return([]*domain.Message{}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			validateBody: func(t *testing.T, body []byte) {
@@ -325,11 +686,44 @@ func TestMessageHandler_List(t *testing.T) {
 				assert.True(t, resp.Success)
 			},
 		},
+		{
+			name:        "success - filters",
+			queryParams: "?status=sent&phoneNumber=%2B905551111111&createdFrom=2025-11-01T00:00:00Z&createdTo=2025-12-01T00:00:00%2B03:00&sentFrom=2025-11-02T00:00:00Z&q=+code+",
+			mockSetup: func(m *MockMessageService) {
+				m.On("ListPage", mock.Anything, mock.MatchedBy(func(f domain.MessageFilter) bool {
+					return f.Status == domain.StatusSent &&
+						f.PhoneNumber == "+905551111111" &&
+						f.CreatedFrom.Equal(time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)) &&
+						f.CreatedTo.Equal(time.Date(2025, 11, 30, 21, 0, 0, 0, time.UTC)) &&
+						f.SentFrom != nil && f.SentTo == nil &&
+						f.Search == "code"
+				}), mock.Anything).Return(&domain.MessagePage{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error - invalid status",
+			queryParams:    "?status=bogus",
+			mockSetup:      func(m *MockMessageService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "error - unencoded plus in phone number",
+			queryParams:    "?phoneNumber=+905551111111",
+			mockSetup:      func(m *MockMessageService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "error - invalid date",
+			queryParams:    "?createdFrom=yesterday",
+			mockSetup:      func(m *MockMessageService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name:        "error - service error",
 			queryParams: "",
 			mockSetup: func(m *MockMessageService) {
-				m.On("List", mock.Anything, 10, 0).Return(nil, apperror.ErrMessageListFailed)
+				m.On("ListPage", mock.Anything, domain.MessageFilter{}, domain.MessagePageQuery{SortBy: domain.SortByCreatedAt, Limit: 10}).Return(nil, apperror.ErrMessageListFailed)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			validateBody: func(t *testing.T, body []byte) {
@@ -361,6 +755,77 @@ func TestMessageHandler_List(t *testing.T) {
 	}
 }
 
+func TestMessageHandler_List_CursorPagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	createdAt := time.Date(2025, 11, 9, 10, 0, 0, 123456000, time.UTC)
+	total := int64(25)
+	mockService := new(MockMessageService)
+	router := setupRouter(NewMessageHandler(mockService))
+
+	// First page: newest messages with a cursor to the next page
+	mockService.On("ListPage", mock.Anything, domain.MessageFilter{}, domain.MessagePageQuery{SortBy: domain.SortByCreatedAt, Limit: 2, WithTotal: true}).
+		Return(&domain.MessagePage{
+			Messages: []*domain.Message{{ID: 9, CreatedAt: createdAt.Add(time.Second)}, {ID: 8, CreatedAt: createdAt}},
+			HasNext:  true,
+			Total:    &total,
+		}, nil).Once()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/messages?limit=2&withTotal=true", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp customresponse.CustomResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.True(t, resp.Meta.HasNext)
+	assert.False(t, resp.Meta.HasPrev)
+	assert.Equal(t, int64(25), *resp.Meta.Total)
+	assert.NotEmpty(t, resp.Meta.NextCursor)
+	assert.Empty(t, resp.Meta.PrevCursor)
+
+	// Second page: continues after the last message of the first page
+	mockService.On("ListPage", mock.Anything, domain.MessageFilter{}, mock.MatchedBy(func(page domain.MessagePageQuery) bool {
+		return page.After != nil && page.After.ID == 8 && page.After.Time.Equal(createdAt) && page.Before == nil
+	})).Return(&domain.MessagePage{
+		Messages: []*domain.Message{{ID: 7, CreatedAt: createdAt}},
+		HasPrev:  true,
+	}, nil).Once()
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/messages?limit=2&cursor="+resp.Meta.NextCursor, nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var next customresponse.CustomResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &next))
+	assert.False(t, next.Meta.HasNext)
+	assert.True(t, next.Meta.HasPrev)
+	assert.NotEmpty(t, next.Meta.PrevCursor)
+
+	// Previous page: goes back before the first message of the second page
+	mockService.On("ListPage", mock.Anything, domain.MessageFilter{}, mock.MatchedBy(func(page domain.MessagePageQuery) bool {
+		return page.Before != nil && page.Before.ID == 7 && page.After == nil
+	})).Return(&domain.MessagePage{}, nil).Once()
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/messages?limit=2&cursor="+next.Meta.PrevCursor, nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// A cursor of the sent list does not belong to the message list
+	sentCursor := dto.EncodeMessageCursor(domain.SortBySentAt, domain.MessageCursor{Time: createdAt, ID: 8}, false)
+	for _, cursor := range []string{sentCursor, "not-a-cursor"} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/messages?cursor="+cursor, nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var errResp customresponse.CustomResponse
+		json.Unmarshal(w.Body.Bytes(), &errResp)
+		assert.Equal(t, apperror.ErrCodeInvalidCursor, errResp.Error.Code)
+	}
+
+	mockService.AssertExpectations(t)
+}
+
 func TestMessageHandler_ListSent(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -377,9 +842,9 @@ func TestMessageHandler_ListSent(t *testing.T) {
 			mockSetup: func(m *MockMessageService) {
 				sentAt := time.Now()
 				messageID := "msg-123"
-				m.On("ListSentMessages", mock.Anything, 10, 0).Return([]*domain.Message{
+				m.On("ListPage", mock.Anything, domain.MessageFilter{Status: domain.StatusSent}, domain.MessagePageQuery{SortBy: domain.SortBySentAt, Limit: 10}).Return(&domain.MessagePage{Messages: []*domain.Message{
 					{ID: 1, PhoneNumber: "+905551111111", Content: "Sent Msg1", Status: domain.StatusSent, SentAt: &sentAt, MessageID: &messageID},
-				}, nil)
+				}}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			validateBody: func(t *testing.T, body []byte) {
@@ -407,7 +872,7 @@ func TestMessageHandler_ListSent(t *testing.T) {
 			name:        "success - empty list",
 			queryParams: "",
 			mockSetup: func(m *MockMessageService) {
-				m.On("ListSentMessages", mock.Anything, 10, 0).Return([]*domain.Message{}, nil)
+				m.On("ListPage", mock.Anything, domain.MessageFilter{Status: domain.StatusSent}, domain.MessagePageQuery{SortBy: domain.SortBySentAt, Limit: 10}).Return(&domain.MessagePage{}, nil)
 			},
 			expectedStatus: http.StatusOK,
 			validateBody: func(t *testing.T, body []byte) {
@@ -422,7 +887,7 @@ func TestMessageHandler_ListSent(t *testing.T) {
 			name:        "error - service error",
 			queryParams: "",
 			mockSetup: func(m *MockMessageService) {
-				m.On("ListSentMessages", mock.Anything, 10, 0).Return(nil, apperror.ErrMessageListFailed)
+				m.On("ListPage", mock.Anything, domain.MessageFilter{Status: domain.StatusSent}, domain.MessagePageQuery{SortBy: domain.SortBySentAt, Limit: 10}).Return(nil, apperror.ErrMessageListFailed)
 			},
 			expectedStatus: http.StatusInternalServerError,
 			validateBody: func(t *testing.T, body []byte) {
@@ -454,6 +919,83 @@ func TestMessageHandler_ListSent(t *testing.T) {
 	}
 }
 
+func TestMessageHandler_ListFailed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		queryParams    string
+		mockSetup      func(*MockMessageService)
+		expectedStatus int
+		validateBody   func(*testing.T, []byte)
+	}{
+		{
+			name:        "success - default pagination",
+			queryParams: "",
+			mockSetup: func(m *MockMessageService) {
+				failedAt := time.Now()
+				errCode := "WEBHOOK_SERVER_ERROR"
+				m.On("ListPage", mock.Anything, domain.MessageFilter{Status: domain.StatusFailed}, domain.MessagePageQuery{SortBy: domain.SortByFailedAt, Limit: 10}).Return(&domain.MessagePage{Messages: []*domain.Message{
+					{ID: 1, PhoneNumber: "+905551111111", Content: "Failed Msg1", Status: domain.StatusFailed, AttemptCount: 5, LastErrorCode: &errCode, FailedAt: &failedAt},
+				}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, body []byte) {
+				var resp customresponse.CustomResponse
+				json.Unmarshal(body, &resp)
+				assert.True(t, resp.Success)
+				messages := resp.Data.([]interface{})
+				assert.Equal(t, 1, len(messages))
+				message := messages[0].(map[string]interface{})
+				assert.Equal(t, "failed", message["status"])
+				assert.Equal(t, float64(5), message["attemptCount"])
+				assert.Equal(t, "WEBHOOK_SERVER_ERROR", message["lastErrorCode"])
+			},
+		},
+		{
+			name:        "success - custom pagination",
+			queryParams: "?limit=5&offset=10",
+			mockSetup: func(m *MockMessageService) {
+				m.On("ListFailedMessages", mock.Anything, 5, 10).Return([]*domain.Message{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "error - service error",
+			queryParams: "",
+			mockSetup: func(m *MockMessageService) {
+				m.On("ListPage", mock.Anything, domain.MessageFilter{Status: domain.StatusFailed}, domain.MessagePageQuery{SortBy: domain.SortByFailedAt, Limit: 10}).Return(nil, apperror.ErrMessageListFailed)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			validateBody: func(t *testing.T, body []byte) {
+				var resp customresponse.CustomResponse
+				json.Unmarshal(body, &resp)
+				assert.False(t, resp.Success)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockMessageService)
+			tt.mockSetup(mockService)
+
+			handler := NewMessageHandler(mockService)
+			router := setupRouter(handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/messages/failed"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.validateBody != nil {
+				tt.validateBody(t, w.Body.Bytes())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 func TestMessageHandler_Update(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -500,6 +1042,20 @@ func TestMessageHandler_Update(t *testing.T) {
 				assert.Equal(t, "INVALID_ID", resp.Error.Code)
 			},
 		},
+		{
+			name:      "error - status set by the sender only",
+			messageID: "1",
+			requestBody: dto.UpdateMessageRequest{
+				Status: statusPtr(domain.StatusSent),
+			},
+			mockSetup:      func(m *MockMessageService) {},
+			expectedStatus: http.StatusBadRequest,
+			validateBody: func(t *testing.T, body []byte) {
+				var resp customresponse.CustomResponse
+				json.Unmarshal(body, &resp)
+				assert.Equal(t, "VALIDATION_ERROR", resp.Error.Code)
+			},
+		},
 		{
 			name:      "error - message not found",
 			messageID: "999",
@@ -631,6 +1187,7 @@ func TestMessageHandler_RegisterRoutes(t *testing.T) {
 			"GET /api/messages/:id":    true,
 			"GET /api/messages":        true,
 			"GET /api/messages/sent":   true,
+			"GET /api/messages/failed": true,
 			"PUT /api/messages/:id":    true,
 			"DELETE /api/messages/:id": true,
 		}
@@ -655,3 +1212,7 @@ func TestMessageHandler_InterfaceCompliance(t *testing.T) {
 func stringPtr(s string) *string {
 	return &s
 }
+
+func statusPtr(s domain.MessageStatus) *domain.MessageStatus {
+	return &s
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/internal/service"
+	"github.com/srcndev/message-service/pkg/customerror"
+	"github.com/srcndev/message-service/pkg/customresponse"
+	"github.com/srcndev/message-service/pkg/logger"
+)
+
+// maxImportSize bounds the size of an uploaded import file
+const maxImportSize = 50 << 20
+
+// MessageImportHandler interface defines message import HTTP handlers
+type MessageImportHandler interface {
+	Import(c *gin.Context)
+	RegisterRoutes(router *gin.RouterGroup)
+}
+
+// messageImportHandler is the private implementation of MessageImportHandler interface
+type messageImportHandler struct {
+	service service.MessageImportService
+}
+
+// Compile-time interface compliance check
+var _ MessageImportHandler = (*messageImportHandler)(nil)
+
+// NewMessageImportHandler creates a new message import handler
+func NewMessageImportHandler(service service.MessageImportService) MessageImportHandler {
+	return &messageImportHandler{
+		service: service,
+	}
+}
+
+// RegisterRoutes registers message import routes
+func (h *messageImportHandler) RegisterRoutes(router *gin.RouterGroup) {
+	router.POST("/messages/import", h.Import)
+}
+
+// Import godoc
+// @Summary      Import messages from a file
+// @Description  Upload a CSV (header with phoneNumber/phone and content, optional sendAt, expiresAt, ttl, priority) or
+// @Description  NDJSON file (one POST /messages body per line). Rows are validated like single creates and inserted in
+// @Description  chunks, the report lists the rejected rows by line. When the import aborts, the error response
+// @Description  still carries the report of the rows created so far and the line the import stopped at.
+// @Tags         messages
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file    formData  file    true   "CSV or NDJSON file"
+// @Param        format  query     string  false  "File format, guessed from the file extension when omitted"  Enums(csv, ndjson)
+// @Success      200     {object}  customresponse.CustomResponse{data=dto.MessageImportResponse}
+// @Failure      400     {object}  customresponse.CustomResponse
+// @Failure      500     {object}  customresponse.CustomResponse
+// @Router       /messages/import [post]
+func (h *messageImportHandler) Import(c *gin.Context) {
+	// Reading and inserting a large file outlasts the server timeouts, unsupported writers (tests) keep their deadlines
+	rc := http.NewResponseController(c.Writer)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+	// Read the file part as a stream instead of buffering the whole form
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		customresponse.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			customresponse.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", "file is required")
+			return
+		}
+		if err != nil {
+			customresponse.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+			return
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+
+		format := service.ImportFormat(c.Query("format"))
+		if format == "" {
+			format = service.ImportFormatFromFilename(part.FileName())
+		}
+
+		report, err := h.service.Import(c.Request.Context(), format, part)
+		if err != nil && report != nil {
+			abortImport(c, err, report)
+			return
+		}
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		customresponse.Success(c, http.StatusOK, toImportResponse(report))
+		return
+	}
+}
+
+// abortImport reports the error of an aborted import with the rows created before it stopped
+func abortImport(c *gin.Context, err error, report *service.ImportReport) {
+	statusCode := http.StatusInternalServerError
+	var appErr *customerror.CustomError
+	if errors.As(err, &appErr) {
+		statusCode = appErr.GetStatusCode()
+	}
+
+	logger.Error("Message import stopped at line %d after creating %d messages: %v", report.StoppedAt, report.Created, err)
+	info := batchItemError(err)
+	customresponse.ErrorWithData(c, statusCode, info.Code, info.Message, toImportResponse(report))
+}
+
+// toImportResponse converts an import report to its response
+func toImportResponse(report *service.ImportReport) dto.MessageImportResponse {
+	resp := dto.MessageImportResponse{
+		Rows:      report.Rows,
+		Created:   report.Created,
+		Failed:    report.Failed,
+		Errors:    make([]dto.MessageImportRowError, 0, len(report.Errors)),
+		StoppedAt: report.StoppedAt,
+	}
+	for _, rowErr := range report.Errors {
+		resp.Errors = append(resp.Errors, dto.MessageImportRowError{
+			Line:  rowErr.Line,
+			Error: *batchItemError(rowErr.Err),
+		})
+	}
+	return resp
+}
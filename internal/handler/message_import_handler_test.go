@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/internal/service"
+	"github.com/srcndev/message-service/pkg/customresponse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock MessageImportService
+type MockMessageImportService struct {
+	mock.Mock
+}
+
+func (m *MockMessageImportService) Import(ctx context.Context, format service.ImportFormat, r io.Reader) (*service.ImportReport, error) {
+	content, _ := io.ReadAll(r)
+	args := m.Called(ctx, format, string(content))
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.ImportReport), args.Error(1)
+}
+
+// multipartBody builds a multipart form with the file under the given field name
+func multipartBody(t *testing.T, field, filename, content string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(field, filename)
+	assert.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+	return body, writer.FormDataContentType()
+}
+
+func TestMessageImportHandler_Import(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const csvFile = "phone,content\n+905551111111,Hello\n123,Bad\n"
+
+	tests := []struct {
+		name           string
+		query          string
+		field          string
+		filename       string
+		mockSetup      func(*MockMessageImportService)
+		expectedStatus int
+		expectedCode   string
+		validateBody   func(*testing.T, dto.MessageImportResponse)
+	}{
+		{
+			name:     "success - format from file extension",
+			field:    "file",
+			filename: "recipients.csv",
+			mockSetup: func(m *MockMessageImportService) {
+				m.On("Import", mock.Anything, service.ImportFormatCSV, csvFile).Return(&service.ImportReport{
+					Rows:    2,
+					Created: 1,
+					Failed:  1,
+					Errors: []service.ImportRowError{
+						{Line: 3, Err: apperror.ErrImportRowInvalid.WithError(errors.New("invalid phoneNumber"))},
+					},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			validateBody: func(t *testing.T, resp dto.MessageImportResponse) {
+				assert.Equal(t, 2, resp.Rows)
+				assert.Equal(t, 1, resp.Created)
+				assert.Equal(t, 3, resp.Errors[0].Line)
+				assert.Equal(t, apperror.ErrCodeImportRowInvalid, resp.Errors[0].Error.Code)
+				assert.Contains(t, resp.Errors[0].Error.Message, "invalid phoneNumber")
+			},
+		},
+		{
+			name:     "success - format from query",
+			query:    "?format=ndjson",
+			field:    "file",
+			filename: "upload.txt",
+			mockSetup: func(m *MockMessageImportService) {
+				m.On("Import", mock.Anything, service.ImportFormatNDJSON, csvFile).Return(&service.ImportReport{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error - file part missing",
+			field:          "upload",
+			filename:       "recipients.csv",
+			mockSetup:      func(m *MockMessageImportService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name:     "error - aborted import keeps the partial report",
+			field:    "file",
+			filename: "recipients.csv",
+			mockSetup: func(m *MockMessageImportService) {
+				m.On("Import", mock.Anything, service.ImportFormatCSV, csvFile).Return(&service.ImportReport{
+					Rows:      150,
+					Created:   100,
+					StoppedAt: 102,
+				}, apperror.ErrMessageCreateFailed.WithError(errors.New("db error")))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   apperror.ErrCodeMessageCreateFailed,
+			validateBody: func(t *testing.T, resp dto.MessageImportResponse) {
+				assert.Equal(t, 100, resp.Created)
+				assert.Equal(t, 102, resp.StoppedAt)
+			},
+		},
+		{
+			name:     "error - unsupported format",
+			field:    "file",
+			filename: "recipients.xlsx",
+			mockSetup: func(m *MockMessageImportService) {
+				m.On("Import", mock.Anything, service.ImportFormat(""), csvFile).Return(nil, apperror.ErrImportFormatUnsupported)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   apperror.ErrCodeImportFormatUnsupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockMessageImportService)
+			tt.mockSetup(mockService)
+
+			router := gin.New()
+			router.Use(errorHandlerMiddleware())
+			NewMessageImportHandler(mockService).RegisterRoutes(router.Group("/api"))
+
+			body, contentType := multipartBody(t, tt.field, tt.filename, csvFile)
+			req := httptest.NewRequest(http.MethodPost, "/api/messages/import"+tt.query, body)
+			req.Header.Set("Content-Type", contentType)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedCode != "" {
+				var resp customresponse.CustomResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.False(t, resp.Success)
+				assert.Equal(t, tt.expectedCode, resp.Error.Code)
+			}
+			if tt.validateBody != nil {
+				var resp struct {
+					Data dto.MessageImportResponse `json:"data"`
+				}
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				tt.validateBody(t, resp.Data)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageImportHandler_Import_NotMultipart(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockMessageImportService)
+	router := gin.New()
+	NewMessageImportHandler(mockService).RegisterRoutes(router.Group("/api"))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/messages/import", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "Import", mock.Anything, mock.Anything, mock.Anything)
+}
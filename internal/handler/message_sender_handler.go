@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/srcndev/message-service/internal/job"
+	"github.com/srcndev/message-service/pkg/circuitbreaker"
 	"github.com/srcndev/message-service/pkg/customerror"
 	"github.com/srcndev/message-service/pkg/customresponse"
 )
@@ -20,15 +21,17 @@ type MessageSenderHandler interface {
 // messageSenderHandler is the private implementation of MessageSenderHandler interface
 type messageSenderHandler struct {
 	messageSenderJob job.MessageSenderJob
+	breaker          circuitbreaker.Breaker
 }
 
 // Compile-time interface compliance check
 var _ MessageSenderHandler = (*messageSenderHandler)(nil)
 
-// NewMessageSenderHandler creates a new message sender handler
-func NewMessageSenderHandler(messageSenderJob job.MessageSenderJob) MessageSenderHandler {
+// NewMessageSenderHandler creates a new message sender handler, breaker may be nil when disabled
+func NewMessageSenderHandler(messageSenderJob job.MessageSenderJob, breaker circuitbreaker.Breaker) MessageSenderHandler {
 	return &messageSenderHandler{
 		messageSenderJob: messageSenderJob,
+		breaker:          breaker,
 	}
 }
 
@@ -90,14 +93,19 @@ func (h *messageSenderHandler) Stop(c *gin.Context) {
 
 // Status godoc
 // @Summary      Get sender status
-// @Description  Check if the message sender job is running
+// @Description  Check if the message sender job is running and the webhook circuit breaker state
 // @Tags         sender
 // @Accept       json
 // @Produce      json
-// @Success      200  {object}  customresponse.CustomResponse{data=map[string]bool}
+// @Success      200  {object}  customresponse.CustomResponse{data=map[string]interface{}}
 // @Router       /sender/status [get]
 func (h *messageSenderHandler) Status(c *gin.Context) {
-	customresponse.Success(c, http.StatusOK, gin.H{
+	status := gin.H{
 		"running": h.messageSenderJob.IsRunning(),
-	})
+	}
+	if h.breaker != nil {
+		status["circuitBreaker"] = h.breaker.Snapshot()
+	}
+
+	customresponse.Success(c, http.StatusOK, status)
 }
@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/srcndev/message-service/pkg/circuitbreaker"
 	"github.com/srcndev/message-service/pkg/customerror"
 	"github.com/srcndev/message-service/pkg/customresponse"
 	"github.com/srcndev/message-service/pkg/scheduler"
@@ -62,7 +64,7 @@ func setupSenderRouter(handler MessageSenderHandler) *gin.Engine {
 func TestNewMessageSenderHandler(t *testing.T) {
 	t.Run("creates handler successfully", func(t *testing.T) {
 		mockJob := new(MockMessageSenderJob)
-		handler := NewMessageSenderHandler(mockJob)
+		handler := NewMessageSenderHandler(mockJob, nil)
 
 		assert.NotNil(t, handler)
 		assert.Implements(t, (*MessageSenderHandler)(nil), handler)
@@ -132,7 +134,7 @@ func TestMessageSenderHandler_Start(t *testing.T) {
 			mockJob := new(MockMessageSenderJob)
 			tt.mockSetup(mockJob)
 
-			handler := NewMessageSenderHandler(mockJob)
+			handler := NewMessageSenderHandler(mockJob, nil)
 			router := setupSenderRouter(handler)
 
 			req := httptest.NewRequest(http.MethodPost, "/api/sender/start", nil)
@@ -212,7 +214,7 @@ func TestMessageSenderHandler_Stop(t *testing.T) {
 			mockJob := new(MockMessageSenderJob)
 			tt.mockSetup(mockJob)
 
-			handler := NewMessageSenderHandler(mockJob)
+			handler := NewMessageSenderHandler(mockJob, nil)
 			router := setupSenderRouter(handler)
 
 			req := httptest.NewRequest(http.MethodPost, "/api/sender/stop", nil)
@@ -279,7 +281,7 @@ func TestMessageSenderHandler_Status(t *testing.T) {
 			mockJob := new(MockMessageSenderJob)
 			tt.mockSetup(mockJob)
 
-			handler := NewMessageSenderHandler(mockJob)
+			handler := NewMessageSenderHandler(mockJob, nil)
 			router := setupSenderRouter(handler)
 
 			req := httptest.NewRequest(http.MethodGet, "/api/sender/status", nil)
@@ -296,12 +298,44 @@ func TestMessageSenderHandler_Status(t *testing.T) {
 	}
 }
 
+func TestMessageSenderHandler_Status_CircuitBreaker(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockJob := new(MockMessageSenderJob)
+	mockJob.On("IsRunning").Return(true)
+
+	breaker := circuitbreaker.NewBreaker(circuitbreaker.Config{FailureThreshold: 1, CoolDown: time.Minute})
+	breaker.Failure()
+
+	handler := NewMessageSenderHandler(mockJob, breaker)
+	router := setupSenderRouter(handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/sender/status", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var resp customresponse.CustomResponse
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+
+	data, ok := resp.Data.(map[string]interface{})
+	assert.True(t, ok)
+	cb, ok := data["circuitBreaker"].(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, "open", cb["state"])
+	assert.Equal(t, float64(1), cb["consecutiveFailures"])
+	assert.NotEmpty(t, cb["retryAt"])
+}
+
 func TestMessageSenderHandler_RegisterRoutes(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	t.Run("registers all routes", func(t *testing.T) {
 		mockJob := new(MockMessageSenderJob)
-		handler := NewMessageSenderHandler(mockJob)
+		handler := NewMessageSenderHandler(mockJob, nil)
 		router := gin.New()
 		handler.RegisterRoutes(router.Group("/api"))
 
@@ -327,7 +361,7 @@ func TestMessageSenderHandler_RegisterRoutes(t *testing.T) {
 func TestMessageSenderHandler_InterfaceCompliance(t *testing.T) {
 	t.Run("handler implements MessageSenderHandler interface", func(t *testing.T) {
 		mockJob := new(MockMessageSenderJob)
-		var _ MessageSenderHandler = NewMessageSenderHandler(mockJob)
+		var _ MessageSenderHandler = NewMessageSenderHandler(mockJob, nil)
 	})
 }
 
@@ -336,7 +370,7 @@ func TestMessageSenderHandler_MultipleStartStopCycles(t *testing.T) {
 
 	t.Run("can start and stop multiple times", func(t *testing.T) {
 		mockJob := new(MockMessageSenderJob)
-		handler := NewMessageSenderHandler(mockJob)
+		handler := NewMessageSenderHandler(mockJob, nil)
 		router := setupSenderRouter(handler)
 
 		// First start
@@ -369,7 +403,7 @@ func TestMessageSenderHandler_StatusCheckWhileRunning(t *testing.T) {
 
 	t.Run("status changes correctly", func(t *testing.T) {
 		mockJob := new(MockMessageSenderJob)
-		handler := NewMessageSenderHandler(mockJob)
+		handler := NewMessageSenderHandler(mockJob, nil)
 		router := setupSenderRouter(handler)
 
 		// Check status - not running
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/internal/service"
+	"github.com/srcndev/message-service/pkg/customresponse"
+)
+
+// SuppressionHandler interface defines suppression list HTTP handlers
+type SuppressionHandler interface {
+	Create(c *gin.Context)
+	GetByPhoneNumber(c *gin.Context)
+	List(c *gin.Context)
+	Delete(c *gin.Context)
+	RegisterRoutes(router *gin.RouterGroup)
+}
+
+// suppressionHandler is the private implementation of SuppressionHandler interface
+type suppressionHandler struct {
+	service service.SuppressionService
+}
+
+// Compile-time interface compliance check
+var _ SuppressionHandler = (*suppressionHandler)(nil)
+
+// NewSuppressionHandler creates a new suppression handler
+func NewSuppressionHandler(service service.SuppressionService) SuppressionHandler {
+	return &suppressionHandler{
+		service: service,
+	}
+}
+
+// RegisterRoutes registers all suppression routes
+func (h *suppressionHandler) RegisterRoutes(router *gin.RouterGroup) {
+	suppressions := router.Group("/suppressions")
+	{
+		suppressions.POST("", h.Create)
+		suppressions.GET("/:phoneNumber", h.GetByPhoneNumber)
+		suppressions.GET("", h.List)
+		suppressions.DELETE("/:phoneNumber", h.Delete)
+	}
+}
+
+// Create godoc
+// @Summary      Suppress a phone number
+// @Description  Add a phone number to the suppression list, no message is sent to it until it is removed
+// @Tags         suppressions
+// @Accept       json
+// @Produce      json
+// @Param        suppression  body      dto.CreateSuppressionRequest  true  "Suppression details"
+// @Success      201          {object}  customresponse.CustomResponse{data=dto.SuppressionResponse}
+// @Failure      400          {object}  customresponse.CustomResponse
+// @Failure      409          {object}  customresponse.CustomResponse
+// @Failure      500          {object}  customresponse.CustomResponse
+// @Router       /suppressions [post]
+func (h *suppressionHandler) Create(c *gin.Context) {
+	var req dto.CreateSuppressionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		customresponse.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	suppression, err := h.service.Create(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	customresponse.Success(c, http.StatusCreated, dto.ToSuppressionResponse(suppression))
+}
+
+// GetByPhoneNumber godoc
+// @Summary      Get suppression by phone number
+// @Description  Get the suppression of a phone number, 404 when the number is not suppressed
+// @Tags         suppressions
+// @Accept       json
+// @Produce      json
+// @Param        phoneNumber  path      string  true  "Phone number in E.164 format (URL encode the +)"
+// @Success      200          {object}  customresponse.CustomResponse{data=dto.SuppressionResponse}
+// @Failure      404          {object}  customresponse.CustomResponse
+// @Failure      500          {object}  customresponse.CustomResponse
+// @Router       /suppressions/{phoneNumber} [get]
+func (h *suppressionHandler) GetByPhoneNumber(c *gin.Context) {
+	suppression, err := h.service.GetByPhoneNumber(c.Request.Context(), c.Param("phoneNumber"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	customresponse.Success(c, http.StatusOK, dto.ToSuppressionResponse(suppression))
+}
+
+// List godoc
+// @Summary      List suppressions
+// @Description  Get the suppressed phone numbers, most recent first, with pagination
+// @Tags         suppressions
+// @Accept       json
+// @Produce      json
+// @Param        limit   query     int  false  "Limit"   default(10)
+// @Param        offset  query     int  false  "Offset"  default(0)
+// @Success      200     {object}  customresponse.CustomResponse{data=[]dto.SuppressionResponse}
+// @Failure      500     {object}  customresponse.CustomResponse
+// @Router       /suppressions [get]
+func (h *suppressionHandler) List(c *gin.Context) {
+	limit, offset := parsePagination(c)
+
+	suppressions, err := h.service.List(c.Request.Context(), limit, offset)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responses := make([]dto.SuppressionResponse, len(suppressions))
+	for i, suppression := range suppressions {
+		responses[i] = dto.ToSuppressionResponse(suppression)
+	}
+
+	customresponse.Success(c, http.StatusOK, responses)
+}
+
+// Delete godoc
+// @Summary      Remove a suppression
+// @Description  Remove a phone number from the suppression list so it can receive messages again
+// @Tags         suppressions
+// @Accept       json
+// @Produce      json
+// @Param        phoneNumber  path      string  true  "Phone number in E.164 format (URL encode the +)"
+// @Success      204          {object}  customresponse.CustomResponse
+// @Failure      404          {object}  customresponse.CustomResponse
+// @Failure      500          {object}  customresponse.CustomResponse
+// @Router       /suppressions/{phoneNumber} [delete]
+func (h *suppressionHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Request.Context(), c.Param("phoneNumber")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	customresponse.Success(c, http.StatusNoContent, map[string]interface{}(nil))
+}
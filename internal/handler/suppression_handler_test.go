@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/pkg/customresponse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock SuppressionService
+type MockSuppressionService struct {
+	mock.Mock
+}
+
+func (m *MockSuppressionService) Create(ctx context.Context, req dto.CreateSuppressionRequest) (*domain.Suppression, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Suppression), args.Error(1)
+}
+
+func (m *MockSuppressionService) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.Suppression, error) {
+	args := m.Called(ctx, phoneNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Suppression), args.Error(1)
+}
+
+func (m *MockSuppressionService) List(ctx context.Context, limit, offset int) ([]*domain.Suppression, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Suppression), args.Error(1)
+}
+
+func (m *MockSuppressionService) Delete(ctx context.Context, phoneNumber string) error {
+	args := m.Called(ctx, phoneNumber)
+	return args.Error(0)
+}
+
+func (m *MockSuppressionService) IsSuppressed(ctx context.Context, phoneNumber string) (bool, error) {
+	args := m.Called(ctx, phoneNumber)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestSuppressionHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	optOut := &domain.Suppression{ID: 1, PhoneNumber: "+905551111111", Reason: domain.SuppressionReasonOptOut}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		requestBody    string
+		mockSetup      func(*MockSuppressionService)
+		expectedStatus int
+		expectedCode   string
+		validateBody   func(*testing.T, []byte)
+	}{
+		{
+			name:        "create - success",
+			method:      http.MethodPost,
+			path:        "/api/suppressions",
+			requestBody: `{"phoneNumber": "+905551111111", "reason": "opt_out"}`,
+			mockSetup: func(m *MockSuppressionService) {
+				m.On("Create", mock.Anything, dto.CreateSuppressionRequest{PhoneNumber: "+905551111111", Reason: domain.SuppressionReasonOptOut}).Return(optOut, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			validateBody: func(t *testing.T, body []byte) {
+				var resp struct {
+					Data dto.SuppressionResponse `json:"data"`
+				}
+				assert.NoError(t, json.Unmarshal(body, &resp))
+				assert.Equal(t, "+905551111111", resp.Data.PhoneNumber)
+				assert.Equal(t, domain.SuppressionReasonOptOut, resp.Data.Reason)
+			},
+		},
+		{
+			name:           "create - invalid phone number",
+			method:         http.MethodPost,
+			path:           "/api/suppressions",
+			requestBody:    `{"phoneNumber": "05551111111"}`,
+			mockSetup:      func(m *MockSuppressionService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name:           "create - unknown reason",
+			method:         http.MethodPost,
+			path:           "/api/suppressions",
+			requestBody:    `{"phoneNumber": "+905551111111", "reason": "bored"}`,
+			mockSetup:      func(m *MockSuppressionService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name:        "create - already suppressed",
+			method:      http.MethodPost,
+			path:        "/api/suppressions",
+			requestBody: `{"phoneNumber": "+905551111111"}`,
+			mockSetup: func(m *MockSuppressionService) {
+				m.On("Create", mock.Anything, mock.Anything).Return(nil, apperror.ErrSuppressionExists)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   apperror.ErrCodeSuppressionExists,
+		},
+		{
+			name:   "get - encoded phone number",
+			method: http.MethodGet,
+			path:   "/api/suppressions/%2B905551111111",
+			mockSetup: func(m *MockSuppressionService) {
+				m.On("GetByPhoneNumber", mock.Anything, "+905551111111").Return(optOut, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "get - not suppressed",
+			method: http.MethodGet,
+			path:   "/api/suppressions/+905559999999",
+			mockSetup: func(m *MockSuppressionService) {
+				m.On("GetByPhoneNumber", mock.Anything, "+905559999999").Return(nil, apperror.ErrSuppressionNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   apperror.ErrCodeSuppressionNotFound,
+		},
+		{
+			name:   "list - success",
+			method: http.MethodGet,
+			path:   "/api/suppressions?limit=5&offset=10",
+			mockSetup: func(m *MockSuppressionService) {
+				m.On("List", mock.Anything, 5, 10).Return([]*domain.Suppression{optOut}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "delete - success",
+			method: http.MethodDelete,
+			path:   "/api/suppressions/%2B905551111111",
+			mockSetup: func(m *MockSuppressionService) {
+				m.On("Delete", mock.Anything, "+905551111111").Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "delete - not suppressed",
+			method: http.MethodDelete,
+			path:   "/api/suppressions/%2B905551111111",
+			mockSetup: func(m *MockSuppressionService) {
+				m.On("Delete", mock.Anything, "+905551111111").Return(apperror.ErrSuppressionNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   apperror.ErrCodeSuppressionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockSuppressionService)
+			tt.mockSetup(mockService)
+
+			router := gin.New()
+			router.Use(errorHandlerMiddleware())
+			NewSuppressionHandler(mockService).RegisterRoutes(router.Group("/api"))
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedCode != "" {
+				var resp customresponse.CustomResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.False(t, resp.Success)
+				assert.Equal(t, tt.expectedCode, resp.Error.Code)
+			}
+			if tt.validateBody != nil {
+				tt.validateBody(t, w.Body.Bytes())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/internal/service"
+	"github.com/srcndev/message-service/pkg/customresponse"
+)
+
+// TemplateHandler interface defines template HTTP handlers
+type TemplateHandler interface {
+	Create(c *gin.Context)
+	GetByID(c *gin.Context)
+	List(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	RegisterRoutes(router *gin.RouterGroup)
+}
+
+// templateHandler is the private implementation of TemplateHandler interface
+type templateHandler struct {
+	service service.TemplateService
+}
+
+// Compile-time interface compliance check
+var _ TemplateHandler = (*templateHandler)(nil)
+
+// NewTemplateHandler creates a new template handler
+func NewTemplateHandler(service service.TemplateService) TemplateHandler {
+	return &templateHandler{
+		service: service,
+	}
+}
+
+// RegisterRoutes registers all template routes
+func (h *templateHandler) RegisterRoutes(router *gin.RouterGroup) {
+	templates := router.Group("/templates")
+	{
+		templates.POST("", h.Create)
+		templates.GET("/:id", h.GetByID)
+		templates.GET("", h.List)
+		templates.PUT("/:id", h.Update)
+		templates.DELETE("/:id", h.Delete)
+	}
+}
+
+// Create godoc
+// @Summary      Create a new template
+// @Description  Create reusable message content with {{variable}} placeholders
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Param        template  body      dto.CreateTemplateRequest  true  "Template details"
+// @Success      201       {object}  customresponse.CustomResponse{data=dto.TemplateResponse}
+// @Failure      400       {object}  customresponse.CustomResponse
+// @Failure      500       {object}  customresponse.CustomResponse
+// @Router       /templates [post]
+func (h *templateHandler) Create(c *gin.Context) {
+	var req dto.CreateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		customresponse.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	template, err := h.service.Create(c.Request.Context(), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	customresponse.Success(c, http.StatusCreated, dto.ToTemplateResponse(template))
+}
+
+// GetByID godoc
+// @Summary      Get template by ID
+// @Description  Get a single template by its ID
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Template ID"
+// @Success      200  {object}  customresponse.CustomResponse{data=dto.TemplateResponse}
+// @Failure      400  {object}  customresponse.CustomResponse
+// @Failure      404  {object}  customresponse.CustomResponse
+// @Failure      500  {object}  customresponse.CustomResponse
+// @Router       /templates/{id} [get]
+func (h *templateHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		customresponse.Error(c, http.StatusBadRequest, "INVALID_ID", "Invalid template ID")
+		return
+	}
+
+	template, err := h.service.GetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	customresponse.Success(c, http.StatusOK, dto.ToTemplateResponse(template))
+}
+
+// List godoc
+// @Summary      List templates
+// @Description  Get a list of templates ordered by name with pagination
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Param        limit   query     int  false  "Limit"   default(10)
+// @Param        offset  query     int  false  "Offset"  default(0)
+// @Success      200     {object}  customresponse.CustomResponse{data=[]dto.TemplateResponse}
+// @Failure      500     {object}  customresponse.CustomResponse
+// @Router       /templates [get]
+func (h *templateHandler) List(c *gin.Context) {
+	limit, offset := parsePagination(c)
+
+	templates, err := h.service.List(c.Request.Context(), limit, offset)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responses := make([]dto.TemplateResponse, len(templates))
+	for i, template := range templates {
+		responses[i] = dto.ToTemplateResponse(template)
+	}
+
+	customresponse.Success(c, http.StatusOK, responses)
+}
+
+// Update godoc
+// @Summary      Update template
+// @Description  Update an existing template by ID, messages already created from it are not changed
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                        true  "Template ID"
+// @Param        template  body      dto.UpdateTemplateRequest  true  "Template details"
+// @Success      200       {object}  customresponse.CustomResponse{data=dto.TemplateResponse}
+// @Failure      400       {object}  customresponse.CustomResponse
+// @Failure      404       {object}  customresponse.CustomResponse
+// @Failure      500       {object}  customresponse.CustomResponse
+// @Router       /templates/{id} [put]
+func (h *templateHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		customresponse.Error(c, http.StatusBadRequest, "INVALID_ID", "Invalid template ID")
+		return
+	}
+
+	var req dto.UpdateTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		customresponse.Error(c, http.StatusBadRequest, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	template, err := h.service.Update(c.Request.Context(), uint(id), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	customresponse.Success(c, http.StatusOK, dto.ToTemplateResponse(template))
+}
+
+// Delete godoc
+// @Summary      Delete template
+// @Description  Soft delete a template by ID
+// @Tags         templates
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Template ID"
+// @Success      204  {object}  customresponse.CustomResponse
+// @Failure      400  {object}  customresponse.CustomResponse
+// @Failure      404  {object}  customresponse.CustomResponse
+// @Failure      500  {object}  customresponse.CustomResponse
+// @Router       /templates/{id} [delete]
+func (h *templateHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		customresponse.Error(c, http.StatusBadRequest, "INVALID_ID", "Invalid template ID")
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), uint(id)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	customresponse.Success(c, http.StatusNoContent, map[string]interface{}(nil))
+}
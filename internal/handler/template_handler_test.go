@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/pkg/customresponse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock TemplateService
+type MockTemplateService struct {
+	mock.Mock
+}
+
+func (m *MockTemplateService) Create(ctx context.Context, req dto.CreateTemplateRequest) (*domain.Template, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Template), args.Error(1)
+}
+
+func (m *MockTemplateService) GetByID(ctx context.Context, id uint) (*domain.Template, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Template), args.Error(1)
+}
+
+func (m *MockTemplateService) List(ctx context.Context, limit, offset int) ([]*domain.Template, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Template), args.Error(1)
+}
+
+func (m *MockTemplateService) Update(ctx context.Context, id uint, req dto.UpdateTemplateRequest) (*domain.Template, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Template), args.Error(1)
+}
+
+func (m *MockTemplateService) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTemplateService) Render(ctx context.Context, id uint, variables map[string]string) (string, error) {
+	args := m.Called(ctx, id, variables)
+	return args.String(0), args.Error(1)
+}
+
+func TestTemplateHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	otp := &domain.Template{ID: 1, Name: "otp", Content: "Hi {{name}}, your code is {{code}}"}
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		requestBody    string
+		mockSetup      func(*MockTemplateService)
+		expectedStatus int
+		expectedCode   string
+		validateBody   func(*testing.T, []byte)
+	}{
+		{
+			name:        "create - success",
+			method:      http.MethodPost,
+			path:        "/api/templates",
+			requestBody: `{"name": "otp", "content": "Hi {{name}}, your code is {{code}}"}`,
+			mockSetup: func(m *MockTemplateService) {
+				m.On("Create", mock.Anything, dto.CreateTemplateRequest{Name: "otp", Content: "Hi {{name}}, your code is {{code}}"}).Return(otp, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			validateBody: func(t *testing.T, body []byte) {
+				var resp struct {
+					Data dto.TemplateResponse `json:"data"`
+				}
+				assert.NoError(t, json.Unmarshal(body, &resp))
+				assert.Equal(t, []string{"name", "code"}, resp.Data.Variables)
+			},
+		},
+		{
+			name:           "create - missing content",
+			method:         http.MethodPost,
+			path:           "/api/templates",
+			requestBody:    `{"name": "otp"}`,
+			mockSetup:      func(m *MockTemplateService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "VALIDATION_ERROR",
+		},
+		{
+			name:   "get - success",
+			method: http.MethodGet,
+			path:   "/api/templates/1",
+			mockSetup: func(m *MockTemplateService) {
+				m.On("GetByID", mock.Anything, uint(1)).Return(otp, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "get - not found",
+			method: http.MethodGet,
+			path:   "/api/templates/99",
+			mockSetup: func(m *MockTemplateService) {
+				m.On("GetByID", mock.Anything, uint(99)).Return(nil, apperror.ErrTemplateNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   apperror.ErrCodeTemplateNotFound,
+		},
+		{
+			name:           "get - invalid id",
+			method:         http.MethodGet,
+			path:           "/api/templates/abc",
+			mockSetup:      func(m *MockTemplateService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_ID",
+		},
+		{
+			name:   "list - success",
+			method: http.MethodGet,
+			path:   "/api/templates?limit=5",
+			mockSetup: func(m *MockTemplateService) {
+				m.On("List", mock.Anything, 5, 0).Return([]*domain.Template{otp}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "update - success",
+			method:      http.MethodPut,
+			path:        "/api/templates/1",
+			requestBody: `{"content": "Your code is {{code}}"}`,
+			mockSetup: func(m *MockTemplateService) {
+				m.On("Update", mock.Anything, uint(1), mock.MatchedBy(func(req dto.UpdateTemplateRequest) bool {
+					return req.Content != nil && *req.Content == "Your code is {{code}}" && req.Name == nil
+				})).Return(&domain.Template{ID: 1, Name: "otp", Content: "Your code is {{code}}"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "delete - success",
+			method: http.MethodDelete,
+			path:   "/api/templates/1",
+			mockSetup: func(m *MockTemplateService) {
+				m.On("Delete", mock.Anything, uint(1)).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:   "delete - service error",
+			method: http.MethodDelete,
+			path:   "/api/templates/1",
+			mockSetup: func(m *MockTemplateService) {
+				m.On("Delete", mock.Anything, uint(1)).Return(apperror.ErrTemplateDeleteFailed)
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   apperror.ErrCodeTemplateDeleteFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockTemplateService)
+			tt.mockSetup(mockService)
+
+			router := gin.New()
+			router.Use(errorHandlerMiddleware())
+			NewTemplateHandler(mockService).RegisterRoutes(router.Group("/api"))
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedCode != "" {
+				var resp customresponse.CustomResponse
+				json.Unmarshal(w.Body.Bytes(), &resp)
+				assert.False(t, resp.Success)
+				assert.Equal(t, tt.expectedCode, resp.Error.Code)
+			}
+			if tt.validateBody != nil {
+				tt.validateBody(t, w.Body.Bytes())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/service"
+	"github.com/srcndev/message-service/pkg/logger"
+	"github.com/srcndev/message-service/pkg/scheduler"
+)
+
+// ExpirySweeperJob defines the interface for expiring stale pending messages
+type ExpirySweeperJob interface {
+	// Start starts the scheduled job
+	Start(ctx context.Context) error
+	// Stop stops the scheduled job
+	Stop(ctx context.Context) error
+	// IsRunning returns whether the job is running
+	IsRunning() bool
+}
+
+// expirySweeperJob expires pending messages past their validity, even while the sender is stopped
+type expirySweeperJob struct {
+	messageService service.MessageService
+	scheduler      scheduler.Scheduler
+}
+
+// Compile-time interface compliance check
+var _ ExpirySweeperJob = (*expirySweeperJob)(nil)
+
+// NewExpirySweeperJob creates a new expiry sweeper job with the message service
+func NewExpirySweeperJob(messageService service.MessageService, interval time.Duration) (ExpirySweeperJob, error) {
+	j := &expirySweeperJob{
+		messageService: messageService,
+	}
+
+	sch, err := scheduler.NewScheduler(j.run, interval)
+	if err != nil {
+		return nil, apperror.ErrExpirySweeperInitFailed.WithError(err)
+	}
+	j.scheduler = sch
+
+	return j, nil
+}
+
+// run is the job function that gets executed by scheduler
+func (j *expirySweeperJob) run(ctx context.Context) error {
+	expired, err := j.messageService.ExpirePendingMessages(ctx)
+	if err != nil {
+		logger.Error("Error expiring pending messages: %v", err)
+		return err
+	}
+
+	if expired > 0 {
+		logger.Info("Expired %d pending messages past their validity", expired)
+	}
+	return nil
+}
+
+// Start starts the scheduled job
+func (j *expirySweeperJob) Start(ctx context.Context) error {
+	logger.Info("Starting expiry sweeper job")
+	return j.scheduler.Start(ctx)
+}
+
+// Stop stops the scheduled job
+func (j *expirySweeperJob) Stop(ctx context.Context) error {
+	logger.Info("Stopping expiry sweeper job")
+	return j.scheduler.Stop(ctx)
+}
+
+// IsRunning returns whether the job is running
+func (j *expirySweeperJob) IsRunning() bool {
+	return j.scheduler.IsRunning()
+}
@@ -0,0 +1,88 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/service"
+	"github.com/srcndev/message-service/pkg/logger"
+	"github.com/srcndev/message-service/pkg/scheduler"
+)
+
+// LeaseReaperJob defines the interface for releasing expired message leases
+type LeaseReaperJob interface {
+	// Start starts the scheduled job
+	Start(ctx context.Context) error
+	// Stop stops the scheduled job
+	Stop(ctx context.Context) error
+	// IsRunning returns whether the job is running
+	IsRunning() bool
+}
+
+// leaseReaperJob returns messages held by crashed instances to pending, after reconciling the webhook
+// calls those instances left in flight
+type leaseReaperJob struct {
+	messageService service.MessageService
+	senderService  service.MessageSenderService
+	scheduler      scheduler.Scheduler
+}
+
+// Compile-time interface compliance check
+var _ LeaseReaperJob = (*leaseReaperJob)(nil)
+
+// NewLeaseReaperJob creates a new lease reaper job with the message and sender services
+func NewLeaseReaperJob(messageService service.MessageService, senderService service.MessageSenderService, interval time.Duration) (LeaseReaperJob, error) {
+	j := &leaseReaperJob{
+		messageService: messageService,
+		senderService:  senderService,
+	}
+
+	sch, err := scheduler.NewScheduler(j.run, interval)
+	if err != nil {
+		return nil, apperror.ErrLeaseReaperInitFailed.WithError(err)
+	}
+	j.scheduler = sch
+
+	return j, nil
+}
+
+// run is the job function that gets executed by scheduler
+func (j *leaseReaperJob) run(ctx context.Context) error {
+	// Startup recovery misses calls of instances that restarted under another ID or never came back,
+	// their messages keep an in-flight call and are never released below
+	report, err := j.senderService.ReconcileSendIntents(ctx)
+	if err != nil {
+		logger.Error("Error reconciling send intents: %v", err)
+	} else if report.InFlight > 0 {
+		logger.Info("Reconciled %d stale send intents (closed: %d, held as unknown: %d)", report.InFlight, report.Closed, report.Unknown)
+	}
+
+	released, err := j.messageService.ReleaseExpiredLeases(ctx)
+	if err != nil {
+		logger.Error("Error releasing expired leases: %v", err)
+		return err
+	}
+
+	if released > 0 {
+		logger.Info("Released %d messages with expired leases", released)
+	}
+	return nil
+}
+
+// Start starts the scheduled job
+func (j *leaseReaperJob) Start(ctx context.Context) error {
+	logger.Info("Starting lease reaper job")
+	return j.scheduler.Start(ctx)
+}
+
+// Stop stops the scheduled job
+func (j *leaseReaperJob) Stop(ctx context.Context) error {
+	logger.Info("Stopping lease reaper job")
+	return j.scheduler.Stop(ctx)
+}
+
+// IsRunning returns whether the job is running
+func (j *leaseReaperJob) IsRunning() bool {
+	return j.scheduler.IsRunning()
+}
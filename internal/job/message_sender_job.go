@@ -6,6 +6,7 @@ import (
 
 	"github.com/srcndev/message-service/internal/apperror"
 	"github.com/srcndev/message-service/internal/service"
+	"github.com/srcndev/message-service/pkg/correlation"
 	"github.com/srcndev/message-service/pkg/logger"
 	"github.com/srcndev/message-service/pkg/scheduler"
 )
@@ -24,15 +25,25 @@ type MessageSenderJob interface {
 type messageSenderJob struct {
 	senderService service.MessageSenderService
 	scheduler     scheduler.Scheduler
+
+	drain            bool
+	drainMaxMessages int
+	drainMaxDuration time.Duration
 }
 
 // Compile-time interface compliance check
 var _ MessageSenderJob = (*messageSenderJob)(nil)
 
 // NewMessageSenderJob creates a new message sender job with the sender service
-func NewMessageSenderJob(senderService service.MessageSenderService, interval time.Duration) (MessageSenderJob, error) {
+func NewMessageSenderJob(senderService service.MessageSenderService, interval time.Duration, opts ...MessageSenderJobOption) (MessageSenderJob, error) {
 	j := &messageSenderJob{
-		senderService: senderService,
+		senderService:    senderService,
+		drainMaxMessages: defaultDrainMaxMessages,
+		drainMaxDuration: defaultDrainMaxDuration,
+	}
+
+	for _, opt := range opts {
+		opt(j)
 	}
 
 	// Create scheduler
@@ -47,12 +58,33 @@ func NewMessageSenderJob(senderService service.MessageSenderService, interval ti
 
 // run is the job function that gets executed by scheduler
 func (j *messageSenderJob) run(ctx context.Context) error {
-	logger.Info("Starting message sending cycle")
+	// Tag outgoing webhook calls of this cycle with one correlation ID
+	cycleID := correlation.NewID()
+	ctx = correlation.WithID(ctx, cycleID)
+	logger.Info("Starting message sending cycle (correlation id: %s)", cycleID)
 
-	err := j.senderService.SendPendingMessages(ctx)
-	if err != nil {
-		logger.Error("Error sending messages: %v", err)
-		return err
+	startedAt := time.Now()
+	claimed := 0
+	for {
+		report, err := j.senderService.SendPendingMessages(ctx)
+		if err != nil {
+			logger.Error("Error sending messages: %v", err)
+			return err
+		}
+
+		logger.Info("Message batch completed (claimed: %d, sent: %d, failed: %d, skipped: %d, expired: %d, suppressed: %d, took: %v)",
+			report.Claimed, report.Sent, report.Failed, report.Skipped, report.Expired, report.Suppressed, report.Duration)
+		claimed += report.Claimed
+
+		// Interval mode sends a single batch per tick, drain mode continues while full batches make progress.
+		// A full batch that was only released again would be claimed right back, so it ends the tick.
+		if !j.drain || !report.Full || !report.Progressed() || report.CircuitOpen || ctx.Err() != nil {
+			break
+		}
+		if claimed >= j.drainMaxMessages || time.Since(startedAt) >= j.drainMaxDuration {
+			logger.Info("Drain budget reached after %d messages in %v, waiting for next tick", claimed, time.Since(startedAt))
+			break
+		}
 	}
 
 	logger.Info("Message sending cycle completed")
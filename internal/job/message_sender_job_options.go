@@ -0,0 +1,26 @@
+package job
+
+import "time"
+
+// Default drain budget per cycle
+const (
+	defaultDrainMaxMessages = 1000
+	defaultDrainMaxDuration = 1 * time.Minute
+)
+
+// MessageSenderJobOption configures optional message sender job behavior
+type MessageSenderJobOption func(*messageSenderJob)
+
+// WithDrain keeps sending batches within a cycle while they come back full,
+// until the queue is empty or the message count or time budget is reached
+func WithDrain(maxMessages int, maxDuration time.Duration) MessageSenderJobOption {
+	return func(j *messageSenderJob) {
+		j.drain = true
+		if maxMessages > 0 {
+			j.drainMaxMessages = maxMessages
+		}
+		if maxDuration > 0 {
+			j.drainMaxDuration = maxDuration
+		}
+	}
+}
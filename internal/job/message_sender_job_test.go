@@ -0,0 +1,124 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/srcndev/message-service/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMessageSenderService mocks MessageSenderService interface
+type MockMessageSenderService struct {
+	mock.Mock
+}
+
+func (m *MockMessageSenderService) SendPendingMessages(ctx context.Context) (*service.SendReport, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.SendReport), args.Error(1)
+}
+
+func (m *MockMessageSenderService) RecoverSendIntents(ctx context.Context) (*service.RecoveryReport, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.RecoveryReport), args.Error(1)
+}
+
+func (m *MockMessageSenderService) ReconcileSendIntents(ctx context.Context) (*service.RecoveryReport, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*service.RecoveryReport), args.Error(1)
+}
+
+func newTestJob(t *testing.T, senderService service.MessageSenderService, opts ...MessageSenderJobOption) *messageSenderJob {
+	j, err := NewMessageSenderJob(senderService, time.Minute, opts...)
+	assert.NoError(t, err)
+	return j.(*messageSenderJob)
+}
+
+func TestMessageSenderJob_Run_IntervalModeSendsOneBatch(t *testing.T) {
+	mockSender := new(MockMessageSenderService)
+	j := newTestJob(t, mockSender)
+
+	mockSender.On("SendPendingMessages", mock.Anything).Return(&service.SendReport{Claimed: 2, Sent: 2, Full: true}, nil).Once()
+
+	err := j.run(context.Background())
+
+	assert.NoError(t, err)
+	mockSender.AssertNumberOfCalls(t, "SendPendingMessages", 1)
+}
+
+func TestMessageSenderJob_Run_DrainModeUntilQueueEmpty(t *testing.T) {
+	mockSender := new(MockMessageSenderService)
+	j := newTestJob(t, mockSender, WithDrain(100, time.Minute))
+
+	mockSender.On("SendPendingMessages", mock.Anything).Return(&service.SendReport{Claimed: 2, Sent: 2, Full: true}, nil).Twice()
+	mockSender.On("SendPendingMessages", mock.Anything).Return(&service.SendReport{Claimed: 1, Sent: 1}, nil).Once()
+
+	err := j.run(context.Background())
+
+	assert.NoError(t, err)
+	mockSender.AssertNumberOfCalls(t, "SendPendingMessages", 3)
+}
+
+func TestMessageSenderJob_Run_DrainModeStopsAtMessageBudget(t *testing.T) {
+	mockSender := new(MockMessageSenderService)
+	j := newTestJob(t, mockSender, WithDrain(4, time.Minute))
+
+	mockSender.On("SendPendingMessages", mock.Anything).Return(&service.SendReport{Claimed: 2, Sent: 2, Full: true}, nil)
+
+	err := j.run(context.Background())
+
+	assert.NoError(t, err)
+	mockSender.AssertNumberOfCalls(t, "SendPendingMessages", 2)
+}
+
+func TestMessageSenderJob_Run_DrainModeStopsWithoutProgress(t *testing.T) {
+	mockSender := new(MockMessageSenderService)
+	j := newTestJob(t, mockSender, WithDrain(100, time.Minute))
+
+	// Every claimed message was handed back, claiming again would return the same rows
+	mockSender.On("SendPendingMessages", mock.Anything).Return(&service.SendReport{Claimed: 2, Skipped: 2, Full: true}, nil)
+
+	err := j.run(context.Background())
+
+	assert.NoError(t, err)
+	mockSender.AssertNumberOfCalls(t, "SendPendingMessages", 1)
+}
+
+func TestMessageSenderJob_Run_DrainModeStopsOnError(t *testing.T) {
+	mockSender := new(MockMessageSenderService)
+	j := newTestJob(t, mockSender, WithDrain(100, time.Minute))
+
+	mockSender.On("SendPendingMessages", mock.Anything).Return(&service.SendReport{Claimed: 2, Failed: 2, Full: true}, errors.New("all failed"))
+
+	err := j.run(context.Background())
+
+	assert.Error(t, err)
+	mockSender.AssertNumberOfCalls(t, "SendPendingMessages", 1)
+}
+
+func TestMessageSenderJob_Run_DrainModeStopsWhenCancelled(t *testing.T) {
+	mockSender := new(MockMessageSenderService)
+	j := newTestJob(t, mockSender, WithDrain(100, time.Minute))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockSender.On("SendPendingMessages", mock.Anything).Run(func(args mock.Arguments) {
+		cancel()
+	}).Return(&service.SendReport{Claimed: 2, Sent: 2, Full: true}, nil)
+
+	err := j.run(ctx)
+
+	assert.NoError(t, err)
+	mockSender.AssertNumberOfCalls(t, "SendPendingMessages", 1)
+}
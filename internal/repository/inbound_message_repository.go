@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/srcndev/message-service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InboundMessageRepository defines the interface for inbound message data operations
+type InboundMessageRepository interface {
+	Create(ctx context.Context, message *domain.InboundMessage) (bool, error)
+	GetByMessageID(ctx context.Context, messageID string) (*domain.InboundMessage, error)
+	List(ctx context.Context, phoneNumber string, limit, offset int) ([]*domain.InboundMessage, error)
+	SetReplyMessageID(ctx context.Context, id, replyMessageID uint) error
+}
+
+type inboundMessageRepository struct {
+	db *gorm.DB
+}
+
+// Compile-time interface compliance check
+var _ InboundMessageRepository = (*inboundMessageRepository)(nil)
+
+// NewInboundMessageRepository creates a new inbound message repository
+func NewInboundMessageRepository(db *gorm.DB) InboundMessageRepository {
+	return &inboundMessageRepository{db: db}
+}
+
+// Create stores an inbound message, it reports false when a message with the same gateway ID was already stored
+func (r *inboundMessageRepository) Create(ctx context.Context, message *domain.InboundMessage) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "message_id"}}, DoNothing: true}).
+		Create(message)
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetByMessageID retrieves an inbound message by its gateway ID
+func (r *inboundMessageRepository) GetByMessageID(ctx context.Context, messageID string) (*domain.InboundMessage, error) {
+	var message domain.InboundMessage
+	err := r.db.WithContext(ctx).Where("message_id = ?", messageID).First(&message).Error
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// List retrieves inbound messages, most recently received first, optionally only those of one phone number
+func (r *inboundMessageRepository) List(ctx context.Context, phoneNumber string, limit, offset int) ([]*domain.InboundMessage, error) {
+	query := r.db.WithContext(ctx)
+	if phoneNumber != "" {
+		query = query.Where("phone_number = ?", phoneNumber)
+	}
+
+	var messages []*domain.InboundMessage
+	err := query.
+		Limit(limit).
+		Offset(offset).
+		Order("received_at DESC, id DESC").
+		Find(&messages).Error
+	return messages, err
+}
+
+// SetReplyMessageID links an inbound message to the auto-reply queued for it
+func (r *inboundMessageRepository) SetReplyMessageID(ctx context.Context, id, replyMessageID uint) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.InboundMessage{}).
+		Where("id = ?", id).
+		Update("reply_message_id", replyMessageID).Error
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestInboundMessageRepository_Create_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewInboundMessageRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "inbound_messages"`) + `.*` + regexp.QuoteMeta(`ON CONFLICT ("message_id") DO NOTHING`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "action"}).AddRow(1, domain.InboundActionStop))
+	mock.ExpectCommit()
+
+	messageID := "in-1"
+	message := &domain.InboundMessage{MessageID: &messageID, PhoneNumber: "+905551111111", Content: "STOP", Action: domain.InboundActionStop, ReceivedAt: time.Now()}
+	created, err := repo.Create(context.Background(), message)
+
+	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, uint(1), message.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInboundMessageRepository_Create_Duplicate(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewInboundMessageRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "inbound_messages"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "action"}))
+	mock.ExpectCommit()
+
+	messageID := "in-1"
+	created, err := repo.Create(context.Background(), &domain.InboundMessage{MessageID: &messageID, PhoneNumber: "+905551111111", Content: "Hi"})
+
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInboundMessageRepository_GetByMessageID_NotFound(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewInboundMessageRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "inbound_messages" WHERE message_id = $1`)).
+		WithArgs("in-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	message, err := repo.GetByMessageID(context.Background(), "in-1")
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, message)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInboundMessageRepository_List_ByPhoneNumber(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewInboundMessageRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "phone_number", "content", "action", "received_at"}).
+		AddRow(2, "+905551111111", "START", domain.InboundActionStart, time.Now()).
+		AddRow(1, "+905551111111", "STOP", domain.InboundActionStop, time.Now().Add(-time.Hour))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "inbound_messages" WHERE phone_number = $1 ORDER BY received_at DESC, id DESC LIMIT $2 OFFSET $3`)).
+		WithArgs("+905551111111", 10, 5).
+		WillReturnRows(rows)
+
+	messages, err := repo.List(context.Background(), "+905551111111", 10, 5)
+
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, domain.InboundActionStart, messages[0].Action)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInboundMessageRepository_SetReplyMessageID(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewInboundMessageRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "inbound_messages" SET "reply_message_id"=$1 WHERE id = $2`)).
+		WithArgs(42, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.SetReplyMessageID(context.Background(), 1, 42)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
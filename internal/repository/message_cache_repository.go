@@ -11,13 +11,14 @@ import (
 
 // MessageCacheRepository interface defines cache operations for messages
 type MessageCacheRepository interface {
-	CacheSentMessage(ctx context.Context, messageID string, sentAt time.Time) error
+	CacheSentMessage(ctx context.Context, messageID string, id uint, sentAt time.Time) error
 	GetCachedMessage(ctx context.Context, messageID string) (*CachedMessage, error)
 	IsCached(ctx context.Context, messageID string) (bool, error)
 }
 
 // CachedMessage represents a cached message in Redis
 type CachedMessage struct {
+	ID        uint      `json:"id"` // Database ID, lets delivery receipts skip the messageId index lookup
 	MessageID string    `json:"messageId"`
 	SentAt    time.Time `json:"sentAt"`
 }
@@ -40,8 +41,9 @@ func NewMessageCacheRepository(redisClient redis.Client) MessageCacheRepository
 // CacheSentMessage stores message send information in Redis
 // Key format: message:{messageId}
 // TTL: 30 days (can be adjusted)
-func (r *messageCacheRepository) CacheSentMessage(ctx context.Context, messageID string, sentAt time.Time) error {
+func (r *messageCacheRepository) CacheSentMessage(ctx context.Context, messageID string, id uint, sentAt time.Time) error {
 	cached := CachedMessage{
+		ID:        id,
 		MessageID: messageID,
 		SentAt:    sentAt,
 	}
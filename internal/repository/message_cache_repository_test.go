@@ -34,7 +34,11 @@ func (c *testRedisClient) Set(ctx context.Context, key string, value interface{}
 }
 
 func (c *testRedisClient) Get(ctx context.Context, key string) (string, error) {
-	return c.rdb.Get(ctx, key).Result()
+	val, err := c.rdb.Get(ctx, key).Result()
+	if err == goredis.Nil {
+		return "", redis.ErrRedisKeyNotFound
+	}
+	return val, err
 }
 
 func (c *testRedisClient) Del(ctx context.Context, keys ...string) error {
@@ -45,6 +49,10 @@ func (c *testRedisClient) Exists(ctx context.Context, keys ...string) (int64, er
 	return c.rdb.Exists(ctx, keys...).Result()
 }
 
+func (c *testRedisClient) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	return c.rdb.Eval(ctx, script, keys, args...).Result()
+}
+
 func (c *testRedisClient) Close() error {
 	return c.rdb.Close()
 }
@@ -62,7 +70,7 @@ func TestMessageCacheRepository_CacheSentMessage_Success(t *testing.T) {
 	messageID := "test-message-id-123"
 	sentAt := time.Now()
 
-	err := repo.CacheSentMessage(context.Background(), messageID, sentAt)
+	err := repo.CacheSentMessage(context.Background(), messageID, 1, sentAt)
 
 	assert.NoError(t, err)
 
@@ -86,13 +94,14 @@ func TestMessageCacheRepository_GetCachedMessage_Success(t *testing.T) {
 	sentAt := time.Now()
 
 	// First cache it
-	_ = repo.CacheSentMessage(context.Background(), messageID, sentAt)
+	_ = repo.CacheSentMessage(context.Background(), messageID, 1, sentAt)
 
 	// Then retrieve it
 	cached, err := repo.GetCachedMessage(context.Background(), messageID)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, cached)
+	assert.Equal(t, uint(1), cached.ID)
 	assert.Equal(t, messageID, cached.MessageID)
 	assert.WithinDuration(t, sentAt, cached.SentAt, time.Second)
 }
@@ -120,7 +129,7 @@ func TestMessageCacheRepository_IsCached_True(t *testing.T) {
 	sentAt := time.Now()
 
 	// Cache the message
-	_ = repo.CacheSentMessage(context.Background(), messageID, sentAt)
+	_ = repo.CacheSentMessage(context.Background(), messageID, 1, sentAt)
 
 	// Check if cached
 	isCached, err := repo.IsCached(context.Background(), messageID)
@@ -151,7 +160,7 @@ func TestMessageCacheRepository_CacheSentMessage_TTL(t *testing.T) {
 	messageID := "ttl-test-message-id"
 	sentAt := time.Now()
 
-	err := repo.CacheSentMessage(context.Background(), messageID, sentAt)
+	err := repo.CacheSentMessage(context.Background(), messageID, 1, sentAt)
 	assert.NoError(t, err)
 
 	// Check TTL in miniredis
@@ -173,7 +182,7 @@ func TestMessageCacheRepository_CacheSentMessage_KeyFormat(t *testing.T) {
 	messageID := "key-format-test-id"
 	sentAt := time.Now()
 
-	err := repo.CacheSentMessage(context.Background(), messageID, sentAt)
+	err := repo.CacheSentMessage(context.Background(), messageID, 1, sentAt)
 	assert.NoError(t, err)
 
 	// Verify key format: message:{messageId}
@@ -217,7 +226,7 @@ func TestMessageCacheRepository_MultipleCachedMessages(t *testing.T) {
 
 	// Cache all messages
 	for _, msg := range messages {
-		err := repo.CacheSentMessage(context.Background(), msg.id, msg.sentAt)
+		err := repo.CacheSentMessage(context.Background(), msg.id, 1, msg.sentAt)
 		assert.NoError(t, err)
 	}
 
@@ -2,19 +2,39 @@ package repository
 
 import (
 	"context"
+	"slices"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/srcndev/message-service/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // MessageRepository defines the interface for message data operations
 type MessageRepository interface {
 	Create(ctx context.Context, message *domain.Message) error
+	CreateBatch(ctx context.Context, messages []*domain.Message) error
 	GetByID(ctx context.Context, id uint) (*domain.Message, error)
+	GetByMessageID(ctx context.Context, messageID string) (*domain.Message, error)
 	List(ctx context.Context, limit, offset int) ([]*domain.Message, error)
+	ListFiltered(ctx context.Context, filter domain.MessageFilter, limit, offset int) ([]*domain.Message, error)
+	ListPage(ctx context.Context, filter domain.MessageFilter, page domain.MessagePageQuery) (*domain.MessagePage, error)
 	GetPendingMessages(ctx context.Context, limit int) ([]*domain.Message, error)
+	ClaimPendingMessages(ctx context.Context, owner string, limit int, reserved map[domain.MessagePriority]int, leaseDuration time.Duration) ([]*domain.Message, error)
+	MarkUnknown(ctx context.Context, id uint, owner string) (bool, error)
+	ResolveUnknown(ctx context.Context, id uint, status domain.MessageStatus) (bool, error)
+	ReleaseExpiredLeases(ctx context.Context) (int64, error)
+	ReleaseLease(ctx context.Context, id uint, owner string) error
+	ExpirePendingMessages(ctx context.Context) (int64, error)
 	GetSentMessages(ctx context.Context, limit, offset int) ([]*domain.Message, error)
+	GetFailedMessages(ctx context.Context, limit, offset int) ([]*domain.Message, error)
+	Stream(ctx context.Context, filter domain.MessageFilter, fn func(*domain.Message) error) error
 	Update(ctx context.Context, message *domain.Message) error
+	UpdatePending(ctx context.Context, message *domain.Message) (bool, error)
+	UpdateLeased(ctx context.Context, id uint, owner string, fields map[string]interface{}) (bool, error)
+	ApplyReceipt(ctx context.Context, id uint, status domain.MessageStatus, receiptAt time.Time, errorCode *string) (bool, error)
 	Delete(ctx context.Context, id uint) error
 }
 
@@ -25,6 +45,12 @@ type messageRepository struct {
 // Compile-time interface compliance check
 var _ MessageRepository = (*messageRepository)(nil)
 
+// insertBatchSize is the number of rows per INSERT statement when creating messages in bulk
+const insertBatchSize = 100
+
+// priorityOrder sorts messages from the most to the least urgent lane, oldest first within a lane
+const priorityOrder = "CASE priority WHEN 'high' THEN 0 WHEN 'low' THEN 2 ELSE 1 END, created_at ASC"
+
 // NewMessageRepository creates a new message repository
 func NewMessageRepository(db *gorm.DB) MessageRepository {
 	return &messageRepository{db: db}
@@ -35,6 +61,11 @@ func (r *messageRepository) Create(ctx context.Context, message *domain.Message)
 	return r.db.WithContext(ctx).Create(message).Error
 }
 
+// CreateBatch inserts the messages in chunks within one transaction, either all of them are stored or none
+func (r *messageRepository) CreateBatch(ctx context.Context, messages []*domain.Message) error {
+	return r.db.WithContext(ctx).CreateInBatches(messages, insertBatchSize).Error
+}
+
 // GetByID retrieves a message by its ID
 func (r *messageRepository) GetByID(ctx context.Context, id uint) (*domain.Message, error) {
 	var message domain.Message
@@ -45,10 +76,25 @@ func (r *messageRepository) GetByID(ctx context.Context, id uint) (*domain.Messa
 	return &message, nil
 }
 
+// GetByMessageID retrieves a message by the ID the provider returned when it was sent
+func (r *messageRepository) GetByMessageID(ctx context.Context, messageID string) (*domain.Message, error) {
+	var message domain.Message
+	err := r.db.WithContext(ctx).Where("message_id = ?", messageID).First(&message).Error
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
 // List retrieves all messages with pagination
 func (r *messageRepository) List(ctx context.Context, limit, offset int) ([]*domain.Message, error) {
+	return r.ListFiltered(ctx, domain.MessageFilter{}, limit, offset)
+}
+
+// ListFiltered retrieves the messages matching the filter, newest first, with pagination
+func (r *messageRepository) ListFiltered(ctx context.Context, filter domain.MessageFilter, limit, offset int) ([]*domain.Message, error) {
 	var messages []*domain.Message
-	err := r.db.WithContext(ctx).
+	err := applyFilter(r.db.WithContext(ctx), filter).
 		Limit(limit).
 		Offset(offset).
 		Order("created_at DESC").
@@ -56,17 +102,237 @@ func (r *messageRepository) List(ctx context.Context, limit, offset int) ([]*dom
 	return messages, err
 }
 
-// GetPendingMessages retrieves pending messages with limit
+// ListPage retrieves a page of the messages matching the filter with keyset pagination on (sort column, id).
+// Unlike offsets, a cursor stays on the same row while the sender inserts and updates messages.
+func (r *messageRepository) ListPage(ctx context.Context, filter domain.MessageFilter, page domain.MessagePageQuery) (*domain.MessagePage, error) {
+	column := string(domain.SortByCreatedAt)
+	if page.SortBy.IsValid() {
+		column = string(page.SortBy)
+	}
+
+	base := applyFilter(r.db.WithContext(ctx).Model(&domain.Message{}), filter).
+		Where(column + " IS NOT NULL").
+		Session(&gorm.Session{})
+
+	result := &domain.MessagePage{}
+	if page.WithTotal {
+		var total int64
+		if err := base.Count(&total).Error; err != nil {
+			return nil, err
+		}
+		result.Total = &total
+	}
+
+	// The previous page is read in ascending order from the cursor and flipped back afterwards
+	query := base.Order(column + " DESC, id DESC")
+	switch {
+	case page.Before != nil:
+		query = base.Where("("+column+", id) > (?, ?)", page.Before.Time, page.Before.ID).Order(column + " ASC, id ASC")
+	case page.After != nil:
+		query = query.Where("("+column+", id) < (?, ?)", page.After.Time, page.After.ID)
+	}
+
+	// One extra row tells whether there is more in the direction of travel
+	var messages []*domain.Message
+	if err := query.Limit(page.Limit + 1).Find(&messages).Error; err != nil {
+		return nil, err
+	}
+	more := len(messages) > page.Limit
+	if more {
+		messages = messages[:page.Limit]
+	}
+
+	if page.Before != nil {
+		slices.Reverse(messages)
+		result.HasPrev = more
+		result.HasNext = true
+	} else {
+		result.HasNext = more
+		result.HasPrev = page.After != nil
+	}
+	result.Messages = messages
+
+	return result, nil
+}
+
+// GetPendingMessages retrieves pending messages whose scheduled time and next attempt are due, with limit
 func (r *messageRepository) GetPendingMessages(ctx context.Context, limit int) ([]*domain.Message, error) {
 	var messages []*domain.Message
 	err := r.db.WithContext(ctx).
 		Where("status = ?", domain.StatusPending).
-		Order("created_at ASC").
+		Where("next_attempt_at IS NULL OR next_attempt_at <= ?", time.Now()).
+		Where("send_at IS NULL OR send_at <= ?", time.Now()).
+		Order(priorityOrder).
 		Limit(limit).
 		Find(&messages).Error
 	return messages, err
 }
 
+// ClaimPendingMessages atomically leases due pending messages to the given owner.
+// Rows locked by another instance are skipped, so concurrent senders never claim the same message.
+// Each lane first gets up to its reserved number of messages, the rest of the batch goes to the
+// most urgent due messages, so a burst in one lane cannot starve the others.
+func (r *messageRepository) ClaimPendingMessages(ctx context.Context, owner string, limit int, reserved map[domain.MessagePriority]int, leaseDuration time.Duration) ([]*domain.Message, error) {
+	var messages []*domain.Message
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		due := func() *gorm.DB {
+			return tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+				Where("status = ?", domain.StatusPending).
+				Where("next_attempt_at IS NULL OR next_attempt_at <= ?", now).
+				Where("send_at IS NULL OR send_at <= ?", now)
+		}
+
+		for _, lane := range domain.Priorities {
+			quota := min(reserved[lane], limit-len(messages))
+			if quota <= 0 {
+				continue
+			}
+
+			var laneMessages []*domain.Message
+			if err := due().
+				Where("priority = ?", lane).
+				Order("created_at ASC").
+				Limit(quota).
+				Find(&laneMessages).Error; err != nil {
+				return err
+			}
+			messages = append(messages, laneMessages...)
+		}
+
+		if remaining := limit - len(messages); remaining > 0 {
+			query := due()
+			if len(messages) > 0 {
+				query = query.Where("id NOT IN ?", messageIDs(messages))
+			}
+
+			var rest []*domain.Message
+			if err := query.
+				Order(priorityOrder).
+				Limit(remaining).
+				Find(&rest).Error; err != nil {
+				return err
+			}
+			messages = append(messages, rest...)
+		}
+
+		if len(messages) == 0 {
+			return nil
+		}
+
+		// Send the most urgent messages of the batch first
+		sort.SliceStable(messages, func(i, j int) bool {
+			return messages[i].Priority.Rank() < messages[j].Priority.Rank()
+		})
+
+		leaseExpiresAt := now.Add(leaseDuration)
+		for _, message := range messages {
+			message.Status = domain.StatusProcessing
+			message.LeaseOwner = &owner
+			message.LeaseExpiresAt = &leaseExpiresAt
+		}
+
+		return tx.Model(&domain.Message{}).
+			Where("id IN ?", messageIDs(messages)).
+			Updates(map[string]interface{}{
+				"status":           domain.StatusProcessing,
+				"lease_owner":      owner,
+				"lease_expires_at": leaseExpiresAt,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// messageIDs returns the IDs of the messages
+func messageIDs(messages []*domain.Message) []uint {
+	ids := make([]uint, len(messages))
+	for i, message := range messages {
+		ids[i] = message.ID
+	}
+	return ids
+}
+
+// MarkUnknown holds a message whose webhook call has no recorded outcome for review, as long as no running
+// instance is sending it: it is pending, its lease expired or the owner holds it. An empty owner holds no lease.
+// It reports false otherwise.
+func (r *messageRepository) MarkUnknown(ctx context.Context, id uint, owner string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.Message{}).
+		Where("id = ? AND (status = ? OR (status = ? AND (lease_expires_at < ? OR lease_owner = ?)))",
+			id, domain.StatusPending, domain.StatusProcessing, time.Now(), owner).
+		Updates(map[string]interface{}{
+			"status":           domain.StatusUnknown,
+			"lease_owner":      nil,
+			"lease_expires_at": nil,
+			"next_attempt_at":  nil,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ResolveUnknown moves a message held as unknown to the status an operator chose, reporting false when
+// the message is no longer unknown
+func (r *messageRepository) ResolveUnknown(ctx context.Context, id uint, status domain.MessageStatus) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.Message{}).
+		Where("id = ? AND status = ?", id, domain.StatusUnknown).
+		Updates(map[string]interface{}{
+			"status":          status,
+			"next_attempt_at": nil,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ReleaseExpiredLeases returns messages whose lease has expired to pending. A message whose webhook call
+// has no recorded outcome is left to send intent recovery, sending it again could deliver it twice.
+func (r *messageRepository) ReleaseExpiredLeases(ctx context.Context) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.Message{}).
+		Where("status = ? AND lease_expires_at < ?", domain.StatusProcessing, time.Now()).
+		Where("NOT EXISTS (SELECT 1 FROM send_intents WHERE send_intents.message_id = messages.id AND send_intents.status = ?)",
+			domain.IntentInFlight).
+		Updates(map[string]interface{}{
+			"status":           domain.StatusPending,
+			"lease_owner":      nil,
+			"lease_expires_at": nil,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// ReleaseLease returns a message claimed by the owner to pending without recording an attempt
+func (r *messageRepository) ReleaseLease(ctx context.Context, id uint, owner string) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.Message{}).
+		Where("id = ? AND status = ? AND lease_owner = ?", id, domain.StatusProcessing, owner).
+		Updates(map[string]interface{}{
+			"status":           domain.StatusPending,
+			"lease_owner":      nil,
+			"lease_expires_at": nil,
+		}).Error
+}
+
+// ExpirePendingMessages marks pending messages whose validity has elapsed as expired
+func (r *messageRepository) ExpirePendingMessages(ctx context.Context) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.Message{}).
+		Where("status = ? AND expires_at <= ?", domain.StatusPending, time.Now()).
+		Updates(map[string]interface{}{
+			"status":          domain.StatusExpired,
+			"next_attempt_at": nil,
+		})
+	return result.RowsAffected, result.Error
+}
+
 // GetSentMessages retrieves sent messages with pagination
 func (r *messageRepository) GetSentMessages(ctx context.Context, limit, offset int) ([]*domain.Message, error) {
 	var messages []*domain.Message
@@ -79,11 +345,120 @@ func (r *messageRepository) GetSentMessages(ctx context.Context, limit, offset i
 	return messages, err
 }
 
+// GetFailedMessages retrieves failed messages with pagination
+func (r *messageRepository) GetFailedMessages(ctx context.Context, limit, offset int) ([]*domain.Message, error) {
+	var messages []*domain.Message
+	err := r.db.WithContext(ctx).
+		Where("status = ?", domain.StatusFailed).
+		Limit(limit).
+		Offset(offset).
+		Order("failed_at DESC").
+		Find(&messages).Error
+	return messages, err
+}
+
+// Stream walks the messages matching the filter oldest first through a database cursor, one row in memory at a time.
+// It stops at the first error returned by fn.
+func (r *messageRepository) Stream(ctx context.Context, filter domain.MessageFilter, fn func(*domain.Message) error) error {
+	rows, err := applyFilter(r.db.WithContext(ctx).Model(&domain.Message{}), filter).
+		Order("created_at ASC, id ASC").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var message domain.Message
+		if err := r.db.ScanRows(rows, &message); err != nil {
+			return err
+		}
+		if err := fn(&message); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// applyFilter adds the conditions of a message filter to the query
+func applyFilter(query *gorm.DB, filter domain.MessageFilter) *gorm.DB {
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.PhoneNumber != "" {
+		query = query.Where("phone_number = ?", filter.PhoneNumber)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+	if filter.SentFrom != nil {
+		query = query.Where("sent_at >= ?", *filter.SentFrom)
+	}
+	if filter.SentTo != nil {
+		query = query.Where("sent_at < ?", *filter.SentTo)
+	}
+	if filter.Search != "" {
+		// Served by the trigram index on content
+		query = query.Where("content ILIKE ?", "%"+likeEscaper.Replace(filter.Search)+"%")
+	}
+	return query
+}
+
+// likeEscaper escapes the LIKE wildcards so a search matches them literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 // Update updates an existing message
 func (r *messageRepository) Update(ctx context.Context, message *domain.Message) error {
 	return r.db.WithContext(ctx).Save(message).Error
 }
 
+// UpdatePending saves a message only while it is still pending in the database,
+// reporting false when the sender has already picked it up
+func (r *messageRepository) UpdatePending(ctx context.Context, message *domain.Message) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(message).
+		Where("status = ?", domain.StatusPending).
+		Select("*").
+		Updates(message)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ApplyReceipt moves a sent message to the delivery status of a receipt, reporting false when the message
+// is no longer sent, e.g. because a concurrent receipt was applied first
+func (r *messageRepository) ApplyReceipt(ctx context.Context, id uint, status domain.MessageStatus, receiptAt time.Time, errorCode *string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.Message{}).
+		Where("id = ? AND status = ?", id, domain.StatusSent).
+		Updates(map[string]interface{}{
+			"status":             status,
+			"receipt_at":         receiptAt,
+			"receipt_error_code": errorCode,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateLeased updates a message only while the owner still holds its lease,
+// reporting false when the lease expired and the message was reaped or claimed elsewhere
+func (r *messageRepository) UpdateLeased(ctx context.Context, id uint, owner string, fields map[string]interface{}) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&domain.Message{}).
+		Where("id = ? AND status = ? AND lease_owner = ?", id, domain.StatusProcessing, owner).
+		Updates(fields)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
 // Delete soft deletes a message
 func (r *messageRepository) Delete(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&domain.Message{}, id).Error
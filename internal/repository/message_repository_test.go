@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"regexp"
 	"testing"
 	"time"
@@ -79,6 +80,48 @@ func TestMessageRepository_Create_Error(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestMessageRepository_CreateBatch_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	messages := []*domain.Message{
+		{PhoneNumber: "+905551111111", Content: "One", Status: domain.StatusPending},
+		{PhoneNumber: "+905552222222", Content: "Two", Status: domain.StatusPending},
+	}
+
+	// Both rows go into a single INSERT statement
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "messages"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectCommit()
+
+	err := repo.CreateBatch(context.Background(), messages)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), messages[0].ID)
+	assert.Equal(t, uint(2), messages[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_CreateBatch_Error(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "messages"`)).
+		WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+
+	err := repo.CreateBatch(context.Background(), []*domain.Message{{PhoneNumber: "+905551111111", Content: "One"}})
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestMessageRepository_GetByID_Success(t *testing.T) {
 	db, mock, cleanup := setupMockDB(t)
 	defer cleanup()
@@ -108,6 +151,50 @@ func TestMessageRepository_GetByID_Success(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestMessageRepository_GetByMessageID_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{
+		"id", "created_at", "updated_at", "deleted_at",
+		"phone_number", "content", "status", "message_id", "sent_at",
+	}).AddRow(
+		1, now, now, nil,
+		"+905551234567", "Test message", domain.StatusSent, "webhook-id-1", now,
+	)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages" WHERE message_id = $1`)).
+		WithArgs("webhook-id-1", 1).
+		WillReturnRows(rows)
+
+	message, err := repo.GetByMessageID(context.Background(), "webhook-id-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), message.ID)
+	assert.Equal(t, "webhook-id-1", *message.MessageID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_GetByMessageID_NotFound(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages" WHERE message_id = $1`)).
+		WithArgs("unknown", 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	message, err := repo.GetByMessageID(context.Background(), "unknown")
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, message)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestMessageRepository_GetByID_NotFound(t *testing.T) {
 	db, mock, cleanup := setupMockDB(t)
 	defer cleanup()
@@ -175,6 +262,120 @@ func TestMessageRepository_List_Empty(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestMessageRepository_ListFiltered_AppliesFilters(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	from := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	filter := domain.MessageFilter{
+		Status:      domain.StatusSent,
+		PhoneNumber: "+905551111111",
+		CreatedFrom: &from,
+		CreatedTo:   &to,
+		SentFrom:    &from,
+		SentTo:      &to,
+		Search:      "50%_off",
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages" WHERE status = $1 AND phone_number = $2 AND created_at >= $3 AND created_at < $4 AND sent_at >= $5 AND sent_at < $6 AND content ILIKE $7`)).
+		WithArgs(domain.StatusSent, "+905551111111", from, to, from, to, `%50\%\_off%`, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "phone_number", "content", "status"}).
+			AddRow(1, "+905551111111", "Get 50%_off today", domain.StatusSent))
+
+	messages, err := repo.ListFiltered(context.Background(), filter, 10, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, messages, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_ListPage_FirstPage(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "created_at", "status"}).
+		AddRow(3, now, domain.StatusSent).
+		AddRow(2, now.Add(-time.Second), domain.StatusSent).
+		AddRow(1, now.Add(-2*time.Second), domain.StatusSent)
+
+	// The total is counted with the same filter, one extra row is read to detect a next page
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "messages" WHERE status = $1 AND sent_at IS NOT NULL`)).
+		WithArgs(domain.StatusSent).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages" WHERE status = $1 AND sent_at IS NOT NULL AND "messages"."deleted_at" IS NULL ORDER BY sent_at DESC, id DESC LIMIT $2`)).
+		WithArgs(domain.StatusSent, 3).
+		WillReturnRows(rows)
+
+	page, err := repo.ListPage(context.Background(), domain.MessageFilter{Status: domain.StatusSent}, domain.MessagePageQuery{
+		SortBy:    domain.SortBySentAt,
+		Limit:     2,
+		WithTotal: true,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Messages, 2)
+	assert.True(t, page.HasNext)
+	assert.False(t, page.HasPrev)
+	assert.Equal(t, int64(3), *page.Total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_ListPage_After(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	cursor := domain.MessageCursor{Time: time.Now(), ID: 10}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages" WHERE created_at IS NOT NULL AND (created_at, id) < ($1, $2) AND "messages"."deleted_at" IS NULL ORDER BY created_at DESC, id DESC LIMIT $3`)).
+		WithArgs(cursor.Time, cursor.ID, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9).AddRow(8))
+
+	page, err := repo.ListPage(context.Background(), domain.MessageFilter{}, domain.MessagePageQuery{
+		SortBy: domain.SortByCreatedAt,
+		Limit:  2,
+		After:  &cursor,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Messages, 2)
+	assert.False(t, page.HasNext)
+	assert.True(t, page.HasPrev)
+	assert.Nil(t, page.Total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_ListPage_Before(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	// Newer rows are read oldest first and returned newest first
+	cursor := domain.MessageCursor{Time: time.Now(), ID: 10}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages" WHERE created_at IS NOT NULL AND (created_at, id) > ($1, $2) AND "messages"."deleted_at" IS NULL ORDER BY created_at ASC, id ASC LIMIT $3`)).
+		WithArgs(cursor.Time, cursor.ID, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11).AddRow(12).AddRow(13))
+
+	page, err := repo.ListPage(context.Background(), domain.MessageFilter{}, domain.MessagePageQuery{
+		SortBy: domain.SortByCreatedAt,
+		Limit:  2,
+		Before: &cursor,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{12, 11}, []uint{page.Messages[0].ID, page.Messages[1].ID})
+	assert.True(t, page.HasNext)
+	assert.True(t, page.HasPrev)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestMessageRepository_GetPendingMessages_Success(t *testing.T) {
 	db, mock, cleanup := setupMockDB(t)
 	defer cleanup()
@@ -189,7 +390,8 @@ func TestMessageRepository_GetPendingMessages_Success(t *testing.T) {
 		AddRow(1, now, now, nil, "+905551111111", "Pending 1", domain.StatusPending, nil, nil).
 		AddRow(2, now, now, nil, "+905552222222", "Pending 2", domain.StatusPending, nil, nil)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages" WHERE status = $1`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages" WHERE status = $1 AND (next_attempt_at IS NULL OR next_attempt_at <= $2) AND (send_at IS NULL OR send_at <= $3)`)).
+		WithArgs(domain.StatusPending, sqlmock.AnyArg(), sqlmock.AnyArg(), 2).
 		WillReturnRows(rows)
 
 	messages, err := repo.GetPendingMessages(context.Background(), 2)
@@ -224,6 +426,239 @@ func TestMessageRepository_GetPendingMessages_NoPending(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestMessageRepository_ClaimPendingMessages_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{
+		"id", "created_at", "updated_at", "deleted_at",
+		"phone_number", "content", "status", "message_id", "sent_at",
+	}).
+		AddRow(1, now, now, nil, "+905551111111", "Pending 1", domain.StatusPending, nil, nil).
+		AddRow(2, now, now, nil, "+905552222222", "Pending 2", domain.StatusPending, nil, nil)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages" WHERE status = $1 AND (next_attempt_at IS NULL OR next_attempt_at <= $2) AND (send_at IS NULL OR send_at <= $3)`)).
+		WithArgs(domain.StatusPending, sqlmock.AnyArg(), sqlmock.AnyArg(), 2).
+		WillReturnRows(rows)
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "messages" SET`)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	messages, err := repo.ClaimPendingMessages(context.Background(), "instance-1", 2, nil, time.Minute)
+
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+	for _, message := range messages {
+		assert.Equal(t, domain.StatusProcessing, message.Status)
+		assert.Equal(t, "instance-1", *message.LeaseOwner)
+		assert.NotNil(t, message.LeaseExpiresAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_ClaimPendingMessages_ReservedLanes(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	now := time.Now()
+	columns := []string{"id", "created_at", "updated_at", "deleted_at", "phone_number", "content", "status", "priority"}
+	lowRows := sqlmock.NewRows(columns).
+		AddRow(3, now, now, nil, "+905553333333", "Newsletter", domain.StatusPending, domain.PriorityLow)
+	restRows := sqlmock.NewRows(columns).
+		AddRow(1, now, now, nil, "+905551111111", "OTP", domain.StatusPending, domain.PriorityHigh)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages" WHERE status = $1 AND (next_attempt_at IS NULL OR next_attempt_at <= $2) AND (send_at IS NULL OR send_at <= $3) AND priority = $4`)).
+		WithArgs(domain.StatusPending, sqlmock.AnyArg(), sqlmock.AnyArg(), domain.PriorityLow, 1).
+		WillReturnRows(lowRows)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages" WHERE status = $1 AND (next_attempt_at IS NULL OR next_attempt_at <= $2) AND (send_at IS NULL OR send_at <= $3) AND id NOT IN ($4)`)).
+		WithArgs(domain.StatusPending, sqlmock.AnyArg(), sqlmock.AnyArg(), 3, 1).
+		WillReturnRows(restRows)
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "messages" SET`)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	reserved := map[domain.MessagePriority]int{domain.PriorityLow: 1}
+	messages, err := repo.ClaimPendingMessages(context.Background(), "instance-1", 2, reserved, time.Minute)
+
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, domain.PriorityHigh, messages[0].Priority)
+	assert.Equal(t, domain.PriorityLow, messages[1].Priority)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_ClaimPendingMessages_SkipsLockedRows(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	rows := sqlmock.NewRows([]string{
+		"id", "created_at", "updated_at", "deleted_at",
+		"phone_number", "content", "status", "message_id", "sent_at",
+	})
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FOR UPDATE SKIP LOCKED`).
+		WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	messages, err := repo.ClaimPendingMessages(context.Background(), "instance-1", 2, nil, time.Minute)
+
+	assert.NoError(t, err)
+	assert.Len(t, messages, 0)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_MarkUnknown_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "messages" SET`)+`.*`+regexp.QuoteMeta(`WHERE (id = $6 AND (status = $7 OR (status = $8 AND (lease_expires_at < $9 OR lease_owner = $10))))`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, domain.StatusUnknown, sqlmock.AnyArg(), 1, domain.StatusPending, domain.StatusProcessing, sqlmock.AnyArg(), "instance-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	marked, err := repo.MarkUnknown(context.Background(), 1, "instance-1")
+
+	assert.NoError(t, err)
+	assert.True(t, marked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_MarkUnknown_HeldByRunningInstance(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "messages" SET`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	marked, err := repo.MarkUnknown(context.Background(), 1, "instance-1")
+
+	assert.NoError(t, err)
+	assert.False(t, marked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_ResolveUnknown(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     int64
+		expected bool
+	}{
+		{name: "unknown message resolved", rows: 1, expected: true},
+		{name: "message no longer unknown", rows: 0, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, cleanup := setupMockDB(t)
+			defer cleanup()
+
+			repo := NewMessageRepository(db)
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "messages" SET "next_attempt_at"=$1,"status"=$2,"updated_at"=$3 WHERE (id = $4 AND status = $5)`)).
+				WithArgs(nil, domain.StatusPending, sqlmock.AnyArg(), 1, domain.StatusUnknown).
+				WillReturnResult(sqlmock.NewResult(0, tt.rows))
+			mock.ExpectCommit()
+
+			resolved, err := repo.ResolveUnknown(context.Background(), 1, domain.StatusPending)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, resolved)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMessageRepository_ClaimPendingMessages_Error(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages"`)).
+		WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+
+	messages, err := repo.ClaimPendingMessages(context.Background(), "instance-1", 2, nil, time.Minute)
+
+	assert.Error(t, err)
+	assert.Nil(t, messages)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_ReleaseExpiredLeases_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	// Messages whose webhook call is still in flight are left to send intent recovery
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "messages" SET`)+`.*`+regexp.QuoteMeta(`NOT EXISTS (SELECT 1 FROM send_intents WHERE send_intents.message_id = messages.id AND send_intents.status = $7)`)).
+		WithArgs(nil, nil, domain.StatusPending, sqlmock.AnyArg(), domain.StatusProcessing, sqlmock.AnyArg(), domain.IntentInFlight).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	released, err := repo.ReleaseExpiredLeases(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), released)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_ExpirePendingMessages_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "messages" SET`)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	expired, err := repo.ExpirePendingMessages(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), expired)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_ReleaseLease_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "messages" SET`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.ReleaseLease(context.Background(), 1, "instance-1")
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestMessageRepository_GetSentMessages_Success(t *testing.T) {
 	db, mock, cleanup := setupMockDB(t)
 	defer cleanup()
@@ -280,6 +715,89 @@ func TestMessageRepository_GetSentMessages_NoSent(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestMessageRepository_Stream_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	rows := sqlmock.NewRows([]string{
+		"id", "created_at", "updated_at", "deleted_at",
+		"phone_number", "content", "status",
+	}).
+		AddRow(1, time.Now(), time.Now(), nil, "+905551234567", "Message 1", domain.StatusSent).
+		AddRow(2, time.Now(), time.Now(), nil, "+905551234568", "Message 2", domain.StatusSent)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages" WHERE status = $1`)).
+		WithArgs(domain.StatusSent).
+		WillReturnRows(rows)
+
+	var ids []uint
+	err := repo.Stream(context.Background(), domain.MessageFilter{Status: domain.StatusSent}, func(message *domain.Message) error {
+		ids = append(ids, message.ID)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{1, 2}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_Stream_StopsOnError(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "phone_number", "content", "status"}).
+		AddRow(1, "+905551234567", "Message 1", domain.StatusSent).
+		AddRow(2, "+905551234568", "Message 2", domain.StatusSent)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages"`)).
+		WillReturnRows(rows)
+
+	calls := 0
+	writeErr := errors.New("client went away")
+	err := repo.Stream(context.Background(), domain.MessageFilter{}, func(message *domain.Message) error {
+		calls++
+		return writeErr
+	})
+
+	assert.ErrorIs(t, err, writeErr)
+	assert.Equal(t, 1, calls)
+}
+
+func TestMessageRepository_GetFailedMessages_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	failedAt := time.Now()
+	rows := sqlmock.NewRows([]string{
+		"id", "created_at", "updated_at", "deleted_at",
+		"phone_number", "content", "status", "message_id", "sent_at",
+		"attempt_count", "last_error_code", "failed_at",
+	}).
+		AddRow(1, time.Now(), time.Now(), nil, "+905551234567", "Message 1", domain.StatusFailed, nil, nil, 5, "WEBHOOK_SERVER_ERROR", failedAt)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "messages" WHERE status = $1`)).
+		WithArgs(domain.StatusFailed, 10).
+		WillReturnRows(rows)
+
+	messages, err := repo.GetFailedMessages(context.Background(), 10, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, messages, 1)
+	if len(messages) == 1 {
+		assert.Equal(t, domain.StatusFailed, messages[0].Status)
+		assert.Equal(t, 5, messages[0].AttemptCount)
+		assert.NotNil(t, messages[0].LastErrorCode)
+		assert.NotNil(t, messages[0].FailedAt)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestMessageRepository_Update_Success(t *testing.T) {
 	db, mock, cleanup := setupMockDB(t)
 	defer cleanup()
@@ -304,6 +822,124 @@ func TestMessageRepository_Update_Success(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestMessageRepository_UpdatePending_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	message := &domain.Message{ID: 1, PhoneNumber: "+905551234567", Content: "Scheduled", Status: domain.StatusCancelled}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "messages" SET`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	updated, err := repo.UpdatePending(context.Background(), message)
+
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_UpdatePending_AlreadyClaimed(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	message := &domain.Message{ID: 1, PhoneNumber: "+905551234567", Content: "Scheduled", Status: domain.StatusCancelled}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "messages" SET`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	updated, err := repo.UpdatePending(context.Background(), message)
+
+	assert.NoError(t, err)
+	assert.False(t, updated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_UpdateLeased_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "messages" SET "attempt_count"=attempt_count + 1,"status"=$1,"updated_at"=$2 WHERE (id = $3 AND status = $4 AND lease_owner = $5)`)).
+		WithArgs(domain.StatusSent, sqlmock.AnyArg(), 1, domain.StatusProcessing, "instance-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	updated, err := repo.UpdateLeased(context.Background(), 1, "instance-1", map[string]interface{}{
+		"status":        domain.StatusSent,
+		"attempt_count": gorm.Expr("attempt_count + 1"),
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_UpdateLeased_LeaseLost(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "messages" SET`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	updated, err := repo.UpdateLeased(context.Background(), 1, "instance-1", map[string]interface{}{"status": domain.StatusSent})
+
+	assert.NoError(t, err)
+	assert.False(t, updated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_ApplyReceipt(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+	receiptAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "messages" SET "receipt_at"=$1,"receipt_error_code"=$2,"status"=$3,"updated_at"=$4 WHERE (id = $5 AND status = $6)`)).
+		WithArgs(receiptAt, nil, domain.StatusDelivered, sqlmock.AnyArg(), 1, domain.StatusSent).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	applied, err := repo.ApplyReceipt(context.Background(), 1, domain.StatusDelivered, receiptAt, nil)
+
+	assert.NoError(t, err)
+	assert.True(t, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageRepository_ApplyReceipt_NotSent(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewMessageRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "messages" SET`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	applied, err := repo.ApplyReceipt(context.Background(), 1, domain.StatusDelivered, time.Now(), nil)
+
+	assert.NoError(t, err)
+	assert.False(t, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestMessageRepository_Update_Error(t *testing.T) {
 	db, mock, cleanup := setupMockDB(t)
 	defer cleanup()
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/srcndev/message-service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SendIntentRepository defines the interface for send intent data operations
+type SendIntentRepository interface {
+	Begin(ctx context.Context, intent *domain.SendIntent) error
+	Resolve(ctx context.Context, messageID uint, status domain.SendIntentStatus) error
+	ListInFlight(ctx context.Context, startedBefore time.Time, limit int) ([]*domain.SendIntent, error)
+}
+
+type sendIntentRepository struct {
+	db *gorm.DB
+}
+
+// Compile-time interface compliance check
+var _ SendIntentRepository = (*sendIntentRepository)(nil)
+
+// NewSendIntentRepository creates a new send intent repository
+func NewSendIntentRepository(db *gorm.DB) SendIntentRepository {
+	return &sendIntentRepository{db: db}
+}
+
+// Begin records an in-flight webhook call, replacing the intent of the previous attempt of the message
+func (r *sendIntentRepository) Begin(ctx context.Context, intent *domain.SendIntent) error {
+	intent.Status = domain.IntentInFlight
+	intent.ResolvedAt = nil
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "message_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"idempotency_key", "status", "attempt", "instance_id", "started_at", "resolved_at", "updated_at"}),
+	}).Create(intent).Error
+}
+
+// Resolve records the outcome of the in-flight call of a message
+func (r *sendIntentRepository) Resolve(ctx context.Context, messageID uint, status domain.SendIntentStatus) error {
+	return r.db.WithContext(ctx).
+		Model(&domain.SendIntent{}).
+		Where("message_id = ? AND status = ?", messageID, domain.IntentInFlight).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"resolved_at": time.Now(),
+		}).Error
+}
+
+// ListInFlight retrieves the oldest calls started before the given time whose outcome was never recorded
+func (r *sendIntentRepository) ListInFlight(ctx context.Context, startedBefore time.Time, limit int) ([]*domain.SendIntent, error) {
+	var intents []*domain.SendIntent
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND started_at < ?", domain.IntentInFlight, startedBefore).
+		Order("started_at ASC").
+		Limit(limit).
+		Find(&intents).Error
+	return intents, err
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendIntentRepository_Begin_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewSendIntentRepository(db)
+
+	resolvedAt := time.Now()
+	intent := &domain.SendIntent{
+		MessageID:      1,
+		IdempotencyKey: "msg-1-1762682400000000",
+		Status:         domain.IntentFailed,
+		Attempt:        2,
+		InstanceID:     "instance-1",
+		StartedAt:      time.Now(),
+		ResolvedAt:     &resolvedAt,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "send_intents"`) + `.*` + regexp.QuoteMeta(`ON CONFLICT ("message_id") DO UPDATE SET`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Begin(context.Background(), intent)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.IntentInFlight, intent.Status)
+	assert.Nil(t, intent.ResolvedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSendIntentRepository_Begin_Error(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewSendIntentRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "send_intents"`)).
+		WillReturnError(errors.New("database error"))
+	mock.ExpectRollback()
+
+	err := repo.Begin(context.Background(), &domain.SendIntent{MessageID: 1})
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSendIntentRepository_Resolve_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewSendIntentRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "send_intents" SET "resolved_at"=$1,"status"=$2,"updated_at"=$3 WHERE message_id = $4 AND status = $5`)).
+		WithArgs(sqlmock.AnyArg(), domain.IntentSent, sqlmock.AnyArg(), 1, domain.IntentInFlight).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Resolve(context.Background(), 1, domain.IntentSent)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSendIntentRepository_ListInFlight_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewSendIntentRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"message_id", "idempotency_key", "status", "attempt", "instance_id", "started_at"}).
+		AddRow(1, "msg-1-1762682400000000", domain.IntentInFlight, 1, "instance-1", now.Add(-time.Minute)).
+		AddRow(2, "msg-2-1762682400000000", domain.IntentInFlight, 3, "instance-2", now)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "send_intents" WHERE status = $1 AND started_at < $2 ORDER BY started_at ASC LIMIT $3`)).
+		WithArgs(domain.IntentInFlight, now, 100).
+		WillReturnRows(rows)
+
+	intents, err := repo.ListInFlight(context.Background(), now, 100)
+
+	assert.NoError(t, err)
+	assert.Len(t, intents, 2)
+	assert.Equal(t, uint(1), intents[0].MessageID)
+	assert.Equal(t, "instance-2", intents[1].InstanceID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/srcndev/message-service/pkg/redis"
+)
+
+// SuppressionCacheRepository interface defines cached suppression lookups
+type SuppressionCacheRepository interface {
+	Get(ctx context.Context, phoneNumber string) (suppressed bool, found bool, err error)
+	Set(ctx context.Context, phoneNumber string, suppressed bool) error
+}
+
+// suppressionCacheRepository is the private implementation
+type suppressionCacheRepository struct {
+	redis redis.Client
+	ttl   time.Duration
+}
+
+// Compile-time interface compliance check
+var _ SuppressionCacheRepository = (*suppressionCacheRepository)(nil)
+
+// NewSuppressionCacheRepository creates a new suppression cache repository, lookups are kept for ttl
+func NewSuppressionCacheRepository(redisClient redis.Client, ttl time.Duration) SuppressionCacheRepository {
+	return &suppressionCacheRepository{
+		redis: redisClient,
+		ttl:   ttl,
+	}
+}
+
+// Get returns the cached lookup of a phone number, found is false on a cache miss
+// Key format: suppression:{phoneNumber}
+func (r *suppressionCacheRepository) Get(ctx context.Context, phoneNumber string) (bool, bool, error) {
+	value, err := r.redis.Get(ctx, suppressionKey(phoneNumber))
+	if errors.Is(err, redis.ErrRedisKeyNotFound) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+	return value == "1", true, nil
+}
+
+// Set caches the lookup of a phone number, numbers that are not suppressed are cached too
+func (r *suppressionCacheRepository) Set(ctx context.Context, phoneNumber string, suppressed bool) error {
+	value := "0"
+	if suppressed {
+		value = "1"
+	}
+	return r.redis.Set(ctx, suppressionKey(phoneNumber), value, r.ttl)
+}
+
+// suppressionKey returns the cache key of a phone number
+func suppressionKey(phoneNumber string) string {
+	return fmt.Sprintf("suppression:%s", phoneNumber)
+}
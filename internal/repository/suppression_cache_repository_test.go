@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuppressionCacheRepository_Get_Miss(t *testing.T) {
+	_, client := setupMiniRedis(t)
+	repo := NewSuppressionCacheRepository(client, time.Hour)
+
+	suppressed, found, err := repo.Get(context.Background(), "+905551111111")
+
+	assert.NoError(t, err)
+	assert.False(t, found)
+	assert.False(t, suppressed)
+}
+
+func TestSuppressionCacheRepository_SetAndGet(t *testing.T) {
+	mr, client := setupMiniRedis(t)
+	repo := NewSuppressionCacheRepository(client, time.Hour)
+	ctx := context.Background()
+
+	assert.NoError(t, repo.Set(ctx, "+905551111111", true))
+	assert.NoError(t, repo.Set(ctx, "+905552222222", false))
+
+	suppressed, found, err := repo.Get(ctx, "+905551111111")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.True(t, suppressed)
+
+	// Numbers that are not suppressed are cached as well
+	suppressed, found, err = repo.Get(ctx, "+905552222222")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.False(t, suppressed)
+
+	assert.Equal(t, time.Hour, mr.TTL("suppression:+905551111111"))
+}
+
+func TestSuppressionCacheRepository_Get_Expired(t *testing.T) {
+	mr, client := setupMiniRedis(t)
+	repo := NewSuppressionCacheRepository(client, time.Minute)
+	ctx := context.Background()
+
+	assert.NoError(t, repo.Set(ctx, "+905551111111", true))
+	mr.FastForward(2 * time.Minute)
+
+	_, found, err := repo.Get(ctx, "+905551111111")
+
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
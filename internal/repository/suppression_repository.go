@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/srcndev/message-service/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SuppressionRepository defines the interface for suppression list data operations
+type SuppressionRepository interface {
+	Create(ctx context.Context, suppression *domain.Suppression) (bool, error)
+	GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.Suppression, error)
+	List(ctx context.Context, limit, offset int) ([]*domain.Suppression, error)
+	Delete(ctx context.Context, phoneNumber string) (bool, error)
+	Exists(ctx context.Context, phoneNumber string) (bool, error)
+}
+
+type suppressionRepository struct {
+	db *gorm.DB
+}
+
+// Compile-time interface compliance check
+var _ SuppressionRepository = (*suppressionRepository)(nil)
+
+// NewSuppressionRepository creates a new suppression repository
+func NewSuppressionRepository(db *gorm.DB) SuppressionRepository {
+	return &suppressionRepository{db: db}
+}
+
+// Create adds a phone number to the list, it reports false when the number was already suppressed
+func (r *suppressionRepository) Create(ctx context.Context, suppression *domain.Suppression) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "phone_number"}}, DoNothing: true}).
+		Create(suppression)
+	return result.RowsAffected > 0, result.Error
+}
+
+// GetByPhoneNumber retrieves the suppression of a phone number
+func (r *suppressionRepository) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.Suppression, error) {
+	var suppression domain.Suppression
+	err := r.db.WithContext(ctx).Where("phone_number = ?", phoneNumber).First(&suppression).Error
+	if err != nil {
+		return nil, err
+	}
+	return &suppression, nil
+}
+
+// List retrieves suppressions, most recent first, with pagination
+func (r *suppressionRepository) List(ctx context.Context, limit, offset int) ([]*domain.Suppression, error) {
+	var suppressions []*domain.Suppression
+	err := r.db.WithContext(ctx).
+		Limit(limit).
+		Offset(offset).
+		Order("created_at DESC, id DESC").
+		Find(&suppressions).Error
+	return suppressions, err
+}
+
+// Delete removes a phone number from the list, it reports false when the number was not suppressed
+func (r *suppressionRepository) Delete(ctx context.Context, phoneNumber string) (bool, error) {
+	result := r.db.WithContext(ctx).Where("phone_number = ?", phoneNumber).Delete(&domain.Suppression{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Exists checks whether a phone number is suppressed
+func (r *suppressionRepository) Exists(ctx context.Context, phoneNumber string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&domain.Suppression{}).
+		Where("phone_number = ?", phoneNumber).
+		Count(&count).Error
+	return count > 0, err
+}
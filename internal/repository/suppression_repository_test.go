@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestSuppressionRepository_Create_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewSuppressionRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "suppressions"`) + `.*` + regexp.QuoteMeta(`ON CONFLICT ("phone_number") DO NOTHING`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	suppression := &domain.Suppression{PhoneNumber: "+905551111111", Reason: domain.SuppressionReasonOptOut}
+	created, err := repo.Create(context.Background(), suppression)
+
+	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, uint(1), suppression.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSuppressionRepository_Create_AlreadySuppressed(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewSuppressionRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "suppressions"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	created, err := repo.Create(context.Background(), &domain.Suppression{PhoneNumber: "+905551111111"})
+
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSuppressionRepository_GetByPhoneNumber_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewSuppressionRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "phone_number", "reason", "note", "created_at", "updated_at"}).
+		AddRow(1, "+905551111111", domain.SuppressionReasonOptOut, "", now, now)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suppressions" WHERE phone_number = $1`)).
+		WithArgs("+905551111111", 1).
+		WillReturnRows(rows)
+
+	suppression, err := repo.GetByPhoneNumber(context.Background(), "+905551111111")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.SuppressionReasonOptOut, suppression.Reason)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSuppressionRepository_GetByPhoneNumber_NotFound(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewSuppressionRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suppressions"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	suppression, err := repo.GetByPhoneNumber(context.Background(), "+905551111111")
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, suppression)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSuppressionRepository_List_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewSuppressionRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "phone_number", "reason", "created_at"}).
+		AddRow(2, "+905552222222", domain.SuppressionReasonManual, now).
+		AddRow(1, "+905551111111", domain.SuppressionReasonOptOut, now.Add(-time.Hour))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "suppressions" ORDER BY created_at DESC, id DESC LIMIT $1 OFFSET $2`)).
+		WithArgs(10, 5).
+		WillReturnRows(rows)
+
+	suppressions, err := repo.List(context.Background(), 10, 5)
+
+	assert.NoError(t, err)
+	assert.Len(t, suppressions, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSuppressionRepository_Delete(t *testing.T) {
+	tests := []struct {
+		name     string
+		affected int64
+		expected bool
+	}{
+		{name: "suppressed number", affected: 1, expected: true},
+		{name: "number not suppressed", affected: 0, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, cleanup := setupMockDB(t)
+			defer cleanup()
+
+			repo := NewSuppressionRepository(db)
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "suppressions" WHERE phone_number = $1`)).
+				WithArgs("+905551111111").
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			mock.ExpectCommit()
+
+			deleted, err := repo.Delete(context.Background(), "+905551111111")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, deleted)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSuppressionRepository_Exists(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewSuppressionRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "suppressions" WHERE phone_number = $1`)).
+		WithArgs("+905551111111").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	suppressed, err := repo.Exists(context.Background(), "+905551111111")
+
+	assert.NoError(t, err)
+	assert.True(t, suppressed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSuppressionRepository_Exists_Error(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewSuppressionRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "suppressions"`)).
+		WillReturnError(errors.New("database error"))
+
+	_, err := repo.Exists(context.Background(), "+905551111111")
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
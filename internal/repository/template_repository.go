@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/srcndev/message-service/internal/domain"
+	"gorm.io/gorm"
+)
+
+// TemplateRepository defines the interface for template data operations
+type TemplateRepository interface {
+	Create(ctx context.Context, template *domain.Template) error
+	GetByID(ctx context.Context, id uint) (*domain.Template, error)
+	List(ctx context.Context, limit, offset int) ([]*domain.Template, error)
+	Update(ctx context.Context, template *domain.Template) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type templateRepository struct {
+	db *gorm.DB
+}
+
+// Compile-time interface compliance check
+var _ TemplateRepository = (*templateRepository)(nil)
+
+// NewTemplateRepository creates a new template repository
+func NewTemplateRepository(db *gorm.DB) TemplateRepository {
+	return &templateRepository{db: db}
+}
+
+// Create inserts a new template into the database
+func (r *templateRepository) Create(ctx context.Context, template *domain.Template) error {
+	return r.db.WithContext(ctx).Create(template).Error
+}
+
+// GetByID retrieves a template by its ID
+func (r *templateRepository) GetByID(ctx context.Context, id uint) (*domain.Template, error) {
+	var template domain.Template
+	err := r.db.WithContext(ctx).First(&template, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// List retrieves templates ordered by name with pagination
+func (r *templateRepository) List(ctx context.Context, limit, offset int) ([]*domain.Template, error) {
+	var templates []*domain.Template
+	err := r.db.WithContext(ctx).
+		Limit(limit).
+		Offset(offset).
+		Order("name ASC").
+		Find(&templates).Error
+	return templates, err
+}
+
+// Update updates an existing template
+func (r *templateRepository) Update(ctx context.Context, template *domain.Template) error {
+	return r.db.WithContext(ctx).Save(template).Error
+}
+
+// Delete soft deletes a template by ID
+func (r *templateRepository) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&domain.Template{}, id).Error
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateRepository_Create_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTemplateRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "templates"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	template := &domain.Template{Name: "otp", Content: "Your code is {{code}}"}
+	err := repo.Create(context.Background(), template)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), template.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTemplateRepository_GetByID_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTemplateRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "name", "content", "created_at", "updated_at", "deleted_at"}).
+		AddRow(1, "otp", "Your code is {{code}}", now, now, nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "templates" WHERE "templates"."id" = $1`)).
+		WithArgs(1, 1).
+		WillReturnRows(rows)
+
+	template, err := repo.GetByID(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "otp", template.Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTemplateRepository_GetByID_NotFound(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTemplateRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "templates"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	template, err := repo.GetByID(context.Background(), 99)
+
+	assert.Error(t, err)
+	assert.Nil(t, template)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTemplateRepository_List_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTemplateRepository(db)
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "name", "content", "created_at", "updated_at", "deleted_at"}).
+		AddRow(2, "marketing", "Hi {{name}}!", now, now, nil).
+		AddRow(1, "otp", "Your code is {{code}}", now, now, nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "templates" WHERE "templates"."deleted_at" IS NULL ORDER BY name ASC LIMIT $1`)).
+		WithArgs(10).
+		WillReturnRows(rows)
+
+	templates, err := repo.List(context.Background(), 10, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, templates, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTemplateRepository_Update_Error(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTemplateRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "templates"`)).
+		WillReturnError(sql.ErrConnDone)
+	mock.ExpectRollback()
+
+	err := repo.Update(context.Background(), &domain.Template{ID: 1, Name: "otp", Content: "Code: {{code}}"})
+
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTemplateRepository_Delete_Success(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	repo := NewTemplateRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "templates" SET "deleted_at"`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err := repo.Delete(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/internal/repository"
+	"github.com/srcndev/message-service/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// DeliveryReceiptService defines the delivery receipt service interface
+type DeliveryReceiptService interface {
+	// ProcessReceipt moves the message identified by the provider messageId to delivered or undelivered
+	ProcessReceipt(ctx context.Context, req dto.DeliveryReceiptRequest) (*domain.Message, error)
+}
+
+type deliveryReceiptService struct {
+	repo      repository.MessageRepository
+	cacheRepo repository.MessageCacheRepository
+}
+
+// Compile-time interface compliance check
+var _ DeliveryReceiptService = (*deliveryReceiptService)(nil)
+
+// NewDeliveryReceiptService creates a new delivery receipt service, cacheRepo may be nil when Redis is disabled
+func NewDeliveryReceiptService(repo repository.MessageRepository, cacheRepo repository.MessageCacheRepository) DeliveryReceiptService {
+	return &deliveryReceiptService{
+		repo:      repo,
+		cacheRepo: cacheRepo,
+	}
+}
+
+// ProcessReceipt applies a delivery receipt, repeated receipts with the same status are accepted as is
+func (s *deliveryReceiptService) ProcessReceipt(ctx context.Context, req dto.DeliveryReceiptRequest) (*domain.Message, error) {
+	message, err := s.findMessage(ctx, req.MessageID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.ErrMessageNotFound
+		}
+		return nil, apperror.ErrMessageUpdateFailed.WithError(err)
+	}
+
+	// Providers may retry receipts, the first one wins
+	if message.Status == req.Status {
+		return message, nil
+	}
+	if message.Status != domain.StatusSent {
+		return nil, apperror.ErrReceiptInvalidTransition
+	}
+
+	receiptAt := time.Now()
+	if req.Timestamp != nil {
+		receiptAt = *req.Timestamp
+	}
+
+	// Only a message still sent takes the receipt, the row may have changed since it was read
+	applied, err := s.repo.ApplyReceipt(ctx, message.ID, req.Status, receiptAt, req.ErrorCode)
+	if err != nil {
+		return nil, apperror.ErrMessageUpdateFailed.WithError(err)
+	}
+	if !applied {
+		current, err := s.repo.GetByID(ctx, message.ID)
+		if err != nil {
+			return nil, apperror.ErrMessageUpdateFailed.WithError(err)
+		}
+		if current.Status == req.Status {
+			return current, nil
+		}
+		return nil, apperror.ErrReceiptInvalidTransition
+	}
+
+	message.Status = req.Status
+	message.ReceiptAt = &receiptAt
+	message.ReceiptErrorCode = req.ErrorCode
+
+	logger.Info("Message %d marked as %s by delivery receipt (webhook messageId: %s)", message.ID, message.Status, req.MessageID)
+	return message, nil
+}
+
+// findMessage resolves the provider messageId through the Redis cache first, then the messages.message_id index
+func (s *deliveryReceiptService) findMessage(ctx context.Context, messageID string) (*domain.Message, error) {
+	if s.cacheRepo != nil {
+		cached, err := s.cacheRepo.GetCachedMessage(ctx, messageID)
+		if err == nil && cached.ID != 0 {
+			// A stale cache entry must not route the receipt to another message
+			message, err := s.repo.GetByID(ctx, cached.ID)
+			if err == nil && message.MessageID != nil && *message.MessageID == messageID {
+				return message, nil
+			}
+			if err == nil {
+				err = errors.New("provider messageId does not match")
+			}
+			logger.Error("Cached message %d for messageId %s not loaded: %v (falling back to index)", cached.ID, messageID, err)
+		}
+	}
+
+	return s.repo.GetByMessageID(ctx, messageID)
+}
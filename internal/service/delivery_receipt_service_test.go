@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func sentMessage() *domain.Message {
+	messageID := "webhook-id-1"
+	return &domain.Message{ID: 1, Status: domain.StatusSent, MessageID: &messageID}
+}
+
+func TestDeliveryReceiptService_ProcessReceipt_CacheHit(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	mockCache := new(MockCacheRepository)
+	service := NewDeliveryReceiptService(mockRepo, mockCache)
+	receiptAt := time.Date(2025, 11, 9, 10, 30, 5, 0, time.UTC)
+
+	mockCache.On("GetCachedMessage", mock.Anything, "webhook-id-1").Return(&repository.CachedMessage{ID: 1, MessageID: "webhook-id-1"}, nil)
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(sentMessage(), nil)
+	mockRepo.On("ApplyReceipt", mock.Anything, uint(1), domain.StatusDelivered, receiptAt, (*string)(nil)).Return(true, nil)
+
+	message, err := service.ProcessReceipt(context.Background(), dto.DeliveryReceiptRequest{
+		MessageID: "webhook-id-1",
+		Status:    domain.StatusDelivered,
+		Timestamp: &receiptAt,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusDelivered, message.Status)
+	mockRepo.AssertNotCalled(t, "GetByMessageID", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeliveryReceiptService_ProcessReceipt_CacheMissFallsBackToIndex(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	mockCache := new(MockCacheRepository)
+	service := NewDeliveryReceiptService(mockRepo, mockCache)
+	errorCode := "ABSENT_SUBSCRIBER"
+
+	mockCache.On("GetCachedMessage", mock.Anything, "webhook-id-1").Return(nil, errors.New("redis: nil"))
+	mockRepo.On("GetByMessageID", mock.Anything, "webhook-id-1").Return(sentMessage(), nil)
+	mockRepo.On("ApplyReceipt", mock.Anything, uint(1), domain.StatusUndelivered, mock.Anything, &errorCode).Return(true, nil)
+
+	_, err := service.ProcessReceipt(context.Background(), dto.DeliveryReceiptRequest{
+		MessageID: "webhook-id-1",
+		Status:    domain.StatusUndelivered,
+		ErrorCode: &errorCode,
+	})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeliveryReceiptService_ProcessReceipt_WithoutCache(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewDeliveryReceiptService(mockRepo, nil)
+
+	mockRepo.On("GetByMessageID", mock.Anything, "webhook-id-1").Return(sentMessage(), nil)
+	mockRepo.On("ApplyReceipt", mock.Anything, uint(1), domain.StatusDelivered, mock.Anything, (*string)(nil)).Return(true, nil)
+
+	_, err := service.ProcessReceipt(context.Background(), dto.DeliveryReceiptRequest{MessageID: "webhook-id-1", Status: domain.StatusDelivered})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeliveryReceiptService_ProcessReceipt_NotFound(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewDeliveryReceiptService(mockRepo, nil)
+
+	mockRepo.On("GetByMessageID", mock.Anything, "unknown").Return(nil, gorm.ErrRecordNotFound)
+
+	_, err := service.ProcessReceipt(context.Background(), dto.DeliveryReceiptRequest{MessageID: "unknown", Status: domain.StatusDelivered})
+
+	assert.ErrorIs(t, err, apperror.ErrMessageNotFound)
+}
+
+func TestDeliveryReceiptService_ProcessReceipt_DuplicateIsIgnored(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewDeliveryReceiptService(mockRepo, nil)
+	message := sentMessage()
+	message.Status = domain.StatusDelivered
+
+	mockRepo.On("GetByMessageID", mock.Anything, "webhook-id-1").Return(message, nil)
+
+	result, err := service.ProcessReceipt(context.Background(), dto.DeliveryReceiptRequest{MessageID: "webhook-id-1", Status: domain.StatusDelivered})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusDelivered, result.Status)
+	mockRepo.AssertNotCalled(t, "ApplyReceipt", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeliveryReceiptService_ProcessReceipt_InvalidTransition(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewDeliveryReceiptService(mockRepo, nil)
+	message := sentMessage()
+	message.Status = domain.StatusDelivered
+
+	mockRepo.On("GetByMessageID", mock.Anything, "webhook-id-1").Return(message, nil)
+
+	_, err := service.ProcessReceipt(context.Background(), dto.DeliveryReceiptRequest{MessageID: "webhook-id-1", Status: domain.StatusUndelivered})
+
+	assert.ErrorIs(t, err, apperror.ErrReceiptInvalidTransition)
+	mockRepo.AssertNotCalled(t, "ApplyReceipt", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeliveryReceiptService_ProcessReceipt_UpdateError(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewDeliveryReceiptService(mockRepo, nil)
+
+	mockRepo.On("GetByMessageID", mock.Anything, "webhook-id-1").Return(sentMessage(), nil)
+	mockRepo.On("ApplyReceipt", mock.Anything, uint(1), domain.StatusDelivered, mock.Anything, (*string)(nil)).Return(false, errors.New("db error"))
+
+	_, err := service.ProcessReceipt(context.Background(), dto.DeliveryReceiptRequest{MessageID: "webhook-id-1", Status: domain.StatusDelivered})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), apperror.ErrCodeMessageUpdateFailed)
+}
+
+func TestDeliveryReceiptService_ProcessReceipt_StaleCacheEntry(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	mockCache := new(MockCacheRepository)
+	service := NewDeliveryReceiptService(mockRepo, mockCache)
+	otherID := "webhook-id-9"
+
+	mockCache.On("GetCachedMessage", mock.Anything, "webhook-id-1").Return(&repository.CachedMessage{ID: 9, MessageID: "webhook-id-1"}, nil)
+	mockRepo.On("GetByID", mock.Anything, uint(9)).Return(&domain.Message{ID: 9, Status: domain.StatusSent, MessageID: &otherID}, nil)
+	mockRepo.On("GetByMessageID", mock.Anything, "webhook-id-1").Return(sentMessage(), nil)
+	mockRepo.On("ApplyReceipt", mock.Anything, uint(1), domain.StatusDelivered, mock.Anything, (*string)(nil)).Return(true, nil)
+
+	message, err := service.ProcessReceipt(context.Background(), dto.DeliveryReceiptRequest{MessageID: "webhook-id-1", Status: domain.StatusDelivered})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), message.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeliveryReceiptService_ProcessReceipt_ConcurrentReceipt(t *testing.T) {
+	tests := []struct {
+		name    string
+		current domain.MessageStatus
+		wantErr error
+	}{
+		{name: "same status applied first", current: domain.StatusDelivered},
+		{name: "other status applied first", current: domain.StatusUndelivered, wantErr: apperror.ErrReceiptInvalidTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockMessageRepository)
+			service := NewDeliveryReceiptService(mockRepo, nil)
+			current := sentMessage()
+			current.Status = tt.current
+
+			mockRepo.On("GetByMessageID", mock.Anything, "webhook-id-1").Return(sentMessage(), nil)
+			mockRepo.On("ApplyReceipt", mock.Anything, uint(1), domain.StatusDelivered, mock.Anything, (*string)(nil)).Return(false, nil)
+			mockRepo.On("GetByID", mock.Anything, uint(1)).Return(current, nil)
+
+			message, err := service.ProcessReceipt(context.Background(), dto.DeliveryReceiptRequest{MessageID: "webhook-id-1", Status: domain.StatusDelivered})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, domain.StatusDelivered, message.Status)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/internal/repository"
+	"github.com/srcndev/message-service/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// InboundMessageService defines the interface for replies received from recipients
+type InboundMessageService interface {
+	// Receive stores a reply and applies the action of the keyword it matches
+	Receive(ctx context.Context, req dto.InboundMessageRequest) (*domain.InboundMessage, error)
+	List(ctx context.Context, phoneNumber string, limit, offset int) ([]*domain.InboundMessage, error)
+}
+
+type inboundMessageService struct {
+	repo         repository.InboundMessageRepository
+	suppressions SuppressionService
+	messages     MessageService
+	keywords     map[string]domain.InboundAction
+	replies      map[domain.InboundAction]string
+}
+
+// Compile-time interface compliance check
+var _ InboundMessageService = (*inboundMessageService)(nil)
+
+// InboundMessageOption configures optional inbound message service behavior
+type InboundMessageOption func(*inboundMessageService)
+
+// WithKeywords makes replies consisting of one of the keywords trigger the action, a non-empty reply is
+// queued as an auto-reply to the sender
+func WithKeywords(action domain.InboundAction, keywords []string, reply string) InboundMessageOption {
+	return func(s *inboundMessageService) {
+		for _, keyword := range keywords {
+			s.keywords[strings.ToUpper(keyword)] = action
+		}
+		if reply != "" {
+			s.replies[action] = reply
+		}
+	}
+}
+
+// NewInboundMessageService creates a new inbound message service
+func NewInboundMessageService(repo repository.InboundMessageRepository, suppressions SuppressionService, messages MessageService, opts ...InboundMessageOption) InboundMessageService {
+	s := &inboundMessageService{
+		repo:         repo,
+		suppressions: suppressions,
+		messages:     messages,
+		keywords:     make(map[string]domain.InboundAction),
+		replies:      make(map[domain.InboundAction]string),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Receive applies the keyword action before storing the reply, so a failed suppression update is retried
+// by the gateway instead of being lost. Repeated deliveries with the same messageId return the stored reply.
+func (s *inboundMessageService) Receive(ctx context.Context, req dto.InboundMessageRequest) (*domain.InboundMessage, error) {
+	if req.MessageID != nil {
+		existing, err := s.repo.GetByMessageID(ctx, *req.MessageID)
+		if err == nil {
+			return existing, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.ErrInboundMessageCreateFailed.WithError(err)
+		}
+	}
+
+	receivedAt := time.Now()
+	if req.Timestamp != nil {
+		receivedAt = *req.Timestamp
+	}
+
+	inbound := &domain.InboundMessage{
+		MessageID:   req.MessageID,
+		PhoneNumber: req.From,
+		To:          req.To,
+		Content:     req.Content,
+		Action:      domain.InboundActionNone,
+		ReceivedAt:  receivedAt,
+	}
+
+	keyword := normalizeKeyword(req.Content)
+	if action, ok := s.keywords[keyword]; ok {
+		inbound.Keyword = keyword
+		inbound.Action = action
+	}
+
+	if err := s.applyAction(ctx, inbound); err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.Create(ctx, inbound)
+	if err != nil {
+		return nil, apperror.ErrInboundMessageCreateFailed.WithError(err)
+	}
+	if !created {
+		// A concurrent delivery of the same reply was stored first, it queues the auto-reply
+		existing, err := s.repo.GetByMessageID(ctx, *req.MessageID)
+		if err != nil {
+			return nil, apperror.ErrInboundMessageCreateFailed.WithError(err)
+		}
+		return existing, nil
+	}
+
+	s.queueReply(ctx, inbound)
+	return inbound, nil
+}
+
+// List retrieves inbound messages with pagination, optionally only those of one phone number
+func (s *inboundMessageService) List(ctx context.Context, phoneNumber string, limit, offset int) ([]*domain.InboundMessage, error) {
+	messages, err := s.repo.List(ctx, phoneNumber, limit, offset)
+	if err != nil {
+		return nil, apperror.ErrInboundMessageListFailed.WithError(err)
+	}
+
+	return messages, nil
+}
+
+// applyAction updates the suppression list for stop and start keywords, both are safe to repeat
+func (s *inboundMessageService) applyAction(ctx context.Context, inbound *domain.InboundMessage) error {
+	switch inbound.Action {
+	case domain.InboundActionStop:
+		_, err := s.suppressions.Create(ctx, dto.CreateSuppressionRequest{
+			PhoneNumber: inbound.PhoneNumber,
+			Reason:      domain.SuppressionReasonOptOut,
+			Note:        "Inbound keyword " + inbound.Keyword,
+		})
+		if err != nil && !errors.Is(err, apperror.ErrSuppressionExists) {
+			return err
+		}
+		logger.Info("Phone number %s opted out with keyword %s", inbound.PhoneNumber, inbound.Keyword)
+
+	case domain.InboundActionStart:
+		// Only an opt-out is lifted by the recipient, complaints and manual suppressions stay
+		suppression, err := s.suppressions.GetByPhoneNumber(ctx, inbound.PhoneNumber)
+		if errors.Is(err, apperror.ErrSuppressionNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if suppression.Reason != domain.SuppressionReasonOptOut {
+			logger.Info("Phone number %s sent %s but stays suppressed (%s)", inbound.PhoneNumber, inbound.Keyword, suppression.Reason)
+			return nil
+		}
+		if err := s.suppressions.Delete(ctx, inbound.PhoneNumber); err != nil && !errors.Is(err, apperror.ErrSuppressionNotFound) {
+			return err
+		}
+		logger.Info("Phone number %s opted in with keyword %s", inbound.PhoneNumber, inbound.Keyword)
+	}
+
+	return nil
+}
+
+// queueReply queues the auto-reply of the action through the pending queue. The reply is best effort,
+// a failure is only logged since the keyword action already took effect.
+func (s *inboundMessageService) queueReply(ctx context.Context, inbound *domain.InboundMessage) {
+	content, ok := s.replies[inbound.Action]
+	if !ok {
+		return
+	}
+
+	reply, err := s.messages.CreateReply(ctx, inbound.PhoneNumber, content)
+	if err != nil {
+		logger.Error("Failed to queue %s auto-reply to %s: %v", inbound.Action, inbound.PhoneNumber, err)
+		return
+	}
+
+	if err := s.repo.SetReplyMessageID(ctx, inbound.ID, reply.ID); err != nil {
+		logger.Error("Failed to link auto-reply %d to inbound message %d: %v", reply.ID, inbound.ID, err)
+		return
+	}
+	inbound.ReplyMessageID = &reply.ID
+}
+
+// normalizeKeyword turns a reply like " stop! " into "STOP", keywords match the whole reply only
+func normalizeKeyword(content string) string {
+	trimmed := strings.TrimFunc(content, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	})
+	return strings.ToUpper(trimmed)
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// Mock InboundMessageRepository
+type MockInboundMessageRepository struct {
+	mock.Mock
+}
+
+func (m *MockInboundMessageRepository) Create(ctx context.Context, message *domain.InboundMessage) (bool, error) {
+	args := m.Called(ctx, message)
+	if args.Bool(0) {
+		message.ID = 1
+	}
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockInboundMessageRepository) GetByMessageID(ctx context.Context, messageID string) (*domain.InboundMessage, error) {
+	args := m.Called(ctx, messageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InboundMessage), args.Error(1)
+}
+
+func (m *MockInboundMessageRepository) List(ctx context.Context, phoneNumber string, limit, offset int) ([]*domain.InboundMessage, error) {
+	args := m.Called(ctx, phoneNumber, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.InboundMessage), args.Error(1)
+}
+
+func (m *MockInboundMessageRepository) SetReplyMessageID(ctx context.Context, id, replyMessageID uint) error {
+	args := m.Called(ctx, id, replyMessageID)
+	return args.Error(0)
+}
+
+// newTestInboundService wires the inbound service with the default keywords and a stop auto-reply
+func newTestInboundService(repo *MockInboundMessageRepository, suppressions *MockSuppressionRepository, messages *MockMessageService) InboundMessageService {
+	return NewInboundMessageService(
+		repo,
+		NewSuppressionService(suppressions, nil),
+		messages,
+		WithKeywords(domain.InboundActionStop, []string{"STOP", "UNSUBSCRIBE"}, "You are unsubscribed"),
+		WithKeywords(domain.InboundActionStart, []string{"START"}, ""),
+		WithKeywords(domain.InboundActionHelp, []string{"HELP"}, ""),
+	)
+}
+
+func TestInboundMessageService_Receive_Stop(t *testing.T) {
+	mockRepo := new(MockInboundMessageRepository)
+	mockSuppressions := new(MockSuppressionRepository)
+	mockMessages := new(MockMessageService)
+	service := newTestInboundService(mockRepo, mockSuppressions, mockMessages)
+
+	messageID := "in-1"
+	mockRepo.On("GetByMessageID", mock.Anything, messageID).Return(nil, gorm.ErrRecordNotFound)
+	mockSuppressions.On("Create", mock.Anything, mock.MatchedBy(func(s *domain.Suppression) bool {
+		return s.PhoneNumber == "+905551111111" && s.Reason == domain.SuppressionReasonOptOut
+	})).Return(true, nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(m *domain.InboundMessage) bool {
+		return m.Keyword == "UNSUBSCRIBE" && m.Action == domain.InboundActionStop
+	})).Return(true, nil)
+	mockMessages.On("CreateReply", mock.Anything, "+905551111111", "You are unsubscribed").Return(&domain.Message{ID: 42}, nil)
+	mockRepo.On("SetReplyMessageID", mock.Anything, uint(1), uint(42)).Return(nil)
+
+	inbound, err := service.Receive(context.Background(), dto.InboundMessageRequest{
+		MessageID: &messageID,
+		From:      "+905551111111",
+		Content:   " unsubscribe! ",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.InboundActionStop, inbound.Action)
+	assert.Equal(t, uint(42), *inbound.ReplyMessageID)
+	mockRepo.AssertExpectations(t)
+	mockSuppressions.AssertExpectations(t)
+	mockMessages.AssertExpectations(t)
+}
+
+func TestInboundMessageService_Receive_StopAlreadySuppressed(t *testing.T) {
+	mockRepo := new(MockInboundMessageRepository)
+	mockSuppressions := new(MockSuppressionRepository)
+	mockMessages := new(MockMessageService)
+	service := newTestInboundService(mockRepo, mockSuppressions, mockMessages)
+
+	mockSuppressions.On("Create", mock.Anything, mock.Anything).Return(false, nil)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(true, nil)
+	mockMessages.On("CreateReply", mock.Anything, "+905551111111", "You are unsubscribed").Return(nil, apperror.ErrMessageCreateFailed)
+
+	inbound, err := service.Receive(context.Background(), dto.InboundMessageRequest{From: "+905551111111", Content: "STOP"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.InboundActionStop, inbound.Action)
+	assert.Nil(t, inbound.ReplyMessageID)
+	mockRepo.AssertNotCalled(t, "SetReplyMessageID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInboundMessageService_Receive_StopError(t *testing.T) {
+	mockRepo := new(MockInboundMessageRepository)
+	mockSuppressions := new(MockSuppressionRepository)
+	mockMessages := new(MockMessageService)
+	service := newTestInboundService(mockRepo, mockSuppressions, mockMessages)
+
+	mockSuppressions.On("Create", mock.Anything, mock.Anything).Return(false, errors.New("db error"))
+
+	inbound, err := service.Receive(context.Background(), dto.InboundMessageRequest{From: "+905551111111", Content: "STOP"})
+
+	assert.Nil(t, inbound)
+	assert.Contains(t, err.Error(), apperror.ErrCodeSuppressionCreateFailed)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestInboundMessageService_Receive_Start(t *testing.T) {
+	tests := []struct {
+		name        string
+		suppression *domain.Suppression
+		expectLift  bool
+	}{
+		{name: "opt-out lifted", suppression: &domain.Suppression{Reason: domain.SuppressionReasonOptOut}, expectLift: true},
+		{name: "complaint kept", suppression: &domain.Suppression{Reason: domain.SuppressionReasonComplaint}},
+		{name: "not suppressed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockInboundMessageRepository)
+			mockSuppressions := new(MockSuppressionRepository)
+			mockMessages := new(MockMessageService)
+			service := newTestInboundService(mockRepo, mockSuppressions, mockMessages)
+
+			if tt.suppression != nil {
+				mockSuppressions.On("GetByPhoneNumber", mock.Anything, "+905551111111").Return(tt.suppression, nil)
+			} else {
+				mockSuppressions.On("GetByPhoneNumber", mock.Anything, "+905551111111").Return(nil, gorm.ErrRecordNotFound)
+			}
+			if tt.expectLift {
+				mockSuppressions.On("Delete", mock.Anything, "+905551111111").Return(true, nil)
+			}
+			mockRepo.On("Create", mock.Anything, mock.Anything).Return(true, nil)
+
+			inbound, err := service.Receive(context.Background(), dto.InboundMessageRequest{From: "+905551111111", Content: "Start"})
+
+			assert.NoError(t, err)
+			assert.Equal(t, domain.InboundActionStart, inbound.Action)
+			if !tt.expectLift {
+				mockSuppressions.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			}
+			mockSuppressions.AssertExpectations(t)
+			mockMessages.AssertNotCalled(t, "CreateReply", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestInboundMessageService_Receive_NoKeyword(t *testing.T) {
+	mockRepo := new(MockInboundMessageRepository)
+	mockSuppressions := new(MockSuppressionRepository)
+	mockMessages := new(MockMessageService)
+	service := newTestInboundService(mockRepo, mockSuppressions, mockMessages)
+
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(m *domain.InboundMessage) bool {
+		return m.Keyword == "" && m.Action == domain.InboundActionNone
+	})).Return(true, nil)
+
+	inbound, err := service.Receive(context.Background(), dto.InboundMessageRequest{From: "+905551111111", Content: "Please stop calling me"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.InboundActionNone, inbound.Action)
+	mockSuppressions.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestInboundMessageService_Receive_Duplicate(t *testing.T) {
+	mockRepo := new(MockInboundMessageRepository)
+	mockSuppressions := new(MockSuppressionRepository)
+	mockMessages := new(MockMessageService)
+	service := newTestInboundService(mockRepo, mockSuppressions, mockMessages)
+
+	messageID := "in-1"
+	existing := &domain.InboundMessage{ID: 7, MessageID: &messageID, Action: domain.InboundActionStop}
+	mockRepo.On("GetByMessageID", mock.Anything, messageID).Return(existing, nil)
+
+	inbound, err := service.Receive(context.Background(), dto.InboundMessageRequest{MessageID: &messageID, From: "+905551111111", Content: "STOP"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, existing, inbound)
+	mockSuppressions.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockMessages.AssertNotCalled(t, "CreateReply", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInboundMessageService_Receive_ConcurrentDuplicate(t *testing.T) {
+	mockRepo := new(MockInboundMessageRepository)
+	mockSuppressions := new(MockSuppressionRepository)
+	mockMessages := new(MockMessageService)
+	service := newTestInboundService(mockRepo, mockSuppressions, mockMessages)
+
+	messageID := "in-1"
+	existing := &domain.InboundMessage{ID: 7, MessageID: &messageID, Action: domain.InboundActionHelp}
+	mockRepo.On("GetByMessageID", mock.Anything, messageID).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(false, nil)
+	mockRepo.On("GetByMessageID", mock.Anything, messageID).Return(existing, nil).Once()
+
+	inbound, err := service.Receive(context.Background(), dto.InboundMessageRequest{MessageID: &messageID, From: "+905551111111", Content: "HELP"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, existing, inbound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestInboundMessageService_Receive_CreateError(t *testing.T) {
+	mockRepo := new(MockInboundMessageRepository)
+	mockSuppressions := new(MockSuppressionRepository)
+	mockMessages := new(MockMessageService)
+	service := newTestInboundService(mockRepo, mockSuppressions, mockMessages)
+
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(false, errors.New("db error"))
+
+	inbound, err := service.Receive(context.Background(), dto.InboundMessageRequest{From: "+905551111111", Content: "Hi"})
+
+	assert.Nil(t, inbound)
+	assert.Contains(t, err.Error(), apperror.ErrCodeInboundMessageCreateFailed)
+}
+
+func TestInboundMessageService_List_Error(t *testing.T) {
+	mockRepo := new(MockInboundMessageRepository)
+	service := newTestInboundService(mockRepo, new(MockSuppressionRepository), new(MockMessageService))
+
+	mockRepo.On("List", mock.Anything, "", 10, 0).Return(nil, errors.New("db error"))
+
+	messages, err := service.List(context.Background(), "", 10, 0)
+
+	assert.Nil(t, messages)
+	assert.Contains(t, err.Error(), apperror.ErrCodeInboundMessageListFailed)
+}
+
+func TestNormalizeKeyword(t *testing.T) {
+	assert.Equal(t, "STOP", normalizeKeyword("  stop!\n"))
+	assert.Equal(t, "STOP ALL", normalizeKeyword("Stop all."))
+	assert.Equal(t, "", normalizeKeyword("..."))
+}
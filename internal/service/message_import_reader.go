@@ -0,0 +1,201 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/srcndev/message-service/internal/dto"
+)
+
+// ImportFormat is the file format of a message import
+type ImportFormat string
+
+// Supported import formats
+const (
+	ImportFormatCSV    ImportFormat = "csv"
+	ImportFormatNDJSON ImportFormat = "ndjson"
+)
+
+// maxImportLineSize bounds a single NDJSON line
+const maxImportLineSize = 1 << 20
+
+// ImportFormatFromFilename guesses the import format from the file extension
+func ImportFormatFromFilename(name string) ImportFormat {
+	switch {
+	case strings.HasSuffix(strings.ToLower(name), ".csv"):
+		return ImportFormatCSV
+	case strings.HasSuffix(strings.ToLower(name), ".ndjson"), strings.HasSuffix(strings.ToLower(name), ".jsonl"):
+		return ImportFormatNDJSON
+	default:
+		return ""
+	}
+}
+
+// importRow is one parsed row, err is set when the row itself could not be parsed
+type importRow struct {
+	line int
+	req  dto.CreateMessageRequest
+	err  error
+}
+
+// importReader reads import rows one at a time, it returns io.EOF after the last row
+type importReader interface {
+	next() (importRow, error)
+}
+
+// newImportReader creates the reader for the format
+func newImportReader(format ImportFormat, r io.Reader) (importReader, error) {
+	switch format {
+	case ImportFormatCSV:
+		return newCSVImportReader(r)
+	case ImportFormatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineSize)
+		return &ndjsonImportReader{scanner: scanner}, nil
+	default:
+		return nil, apperror.ErrImportFormatUnsupported
+	}
+}
+
+// csvImportReader reads rows of a CSV file with a header line naming the columns.
+// Columns are matched case-insensitively to the CreateMessageRequest JSON fields, unknown columns are ignored.
+type csvImportReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+// newCSVImportReader reads the header and checks the required columns are present
+func newCSVImportReader(r io.Reader) (*csvImportReader, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = errors.New("file is empty")
+		}
+		return nil, apperror.ErrImportFileInvalid.WithError(err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Spreadsheet exports often start with a UTF-8 byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if name == "phone" {
+			name = "phonenumber"
+		}
+		columns[name] = i
+	}
+
+	for _, required := range []string{"phonenumber", "content"} {
+		if _, ok := columns[required]; !ok {
+			return nil, apperror.ErrImportFileInvalid.WithError(fmt.Errorf("missing %q column", required))
+		}
+	}
+
+	return &csvImportReader{reader: reader, columns: columns}, nil
+}
+
+// next reads the next CSV record, malformed records are reported on the row
+func (r *csvImportReader) next() (importRow, error) {
+	record, err := r.reader.Read()
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return importRow{line: parseErr.StartLine, err: parseErr.Err}, nil
+	}
+	if err != nil {
+		return importRow{}, err
+	}
+
+	line, _ := r.reader.FieldPos(0)
+	row := importRow{line: line}
+	row.req, row.err = r.parse(record)
+	return row, nil
+}
+
+// parse maps a record to a create request
+func (r *csvImportReader) parse(record []string) (dto.CreateMessageRequest, error) {
+	req := dto.CreateMessageRequest{
+		// Exports quote phone numbers with an apostrophe so spreadsheets keep the leading +
+		PhoneNumber: strings.TrimPrefix(r.value(record, "phonenumber"), "'"),
+		Content:     r.value(record, "content"),
+		Priority:    domain.MessagePriority(r.value(record, "priority")),
+	}
+
+	var err error
+	if req.SendAt, err = r.timeValue(record, "sendat"); err != nil {
+		return req, err
+	}
+	if req.ExpiresAt, err = r.timeValue(record, "expiresat"); err != nil {
+		return req, err
+	}
+	if value := r.value(record, "ttl"); value != "" {
+		ttl, err := strconv.Atoi(value)
+		if err != nil {
+			return req, fmt.Errorf("invalid ttl %q", value)
+		}
+		req.TTL = &ttl
+	}
+
+	return req, nil
+}
+
+// value returns the trimmed value of a column, empty when the column is absent
+func (r *csvImportReader) value(record []string, column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// timeValue parses an optional RFC3339 column
+func (r *csvImportReader) timeValue(record []string, column string) (*time.Time, error) {
+	value := r.value(record, column)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q, expected RFC3339", column, value)
+	}
+	return &t, nil
+}
+
+// ndjsonImportReader reads one JSON object per line in the POST /messages request shape, blank lines are skipped
+type ndjsonImportReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// next decodes the next non-blank line
+func (r *ndjsonImportReader) next() (importRow, error) {
+	for r.scanner.Scan() {
+		r.line++
+		data := bytes.TrimSpace(r.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		row := importRow{line: r.line}
+		row.err = json.Unmarshal(data, &row.req)
+		return row, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return importRow{}, err
+	}
+	return importRow{}, io.EOF
+}
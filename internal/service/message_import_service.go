@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/dto"
+)
+
+// importChunkSize is the number of rows inserted per transaction
+const importChunkSize = 100
+
+// maxImportErrors bounds the rows listed in a report, further failures are only counted
+const maxImportErrors = 1000
+
+// MessageImportService defines the interface for importing messages from files
+type MessageImportService interface {
+	// Import reads the rows of a CSV or NDJSON file and creates a pending message for every valid row
+	Import(ctx context.Context, format ImportFormat, r io.Reader) (*ImportReport, error)
+}
+
+// ImportReport summarizes an import, Errors lists the rejected rows by their line in the file.
+// StoppedAt is the line an aborted import stopped at, rows from that line on were not imported.
+type ImportReport struct {
+	Rows      int
+	Created   int
+	Failed    int
+	Errors    []ImportRowError
+	StoppedAt int
+}
+
+// ImportRowError is the reason a row was rejected
+type ImportRowError struct {
+	Line int
+	Err  error
+}
+
+// messageImportService is the private implementation
+type messageImportService struct {
+	messages MessageService
+}
+
+// Compile-time interface compliance check
+var _ MessageImportService = (*messageImportService)(nil)
+
+// NewMessageImportService creates a new message import service
+func NewMessageImportService(messages MessageService) MessageImportService {
+	return &messageImportService{
+		messages: messages,
+	}
+}
+
+// Import validates every row with the same rules as POST /messages and inserts the valid rows in chunks,
+// so the file is never held in memory. Chunks already inserted stay when a later chunk fails or the file
+// turns out to be unreadable, the partial report is then returned with the error.
+func (s *messageImportService) Import(ctx context.Context, format ImportFormat, r io.Reader) (*ImportReport, error) {
+	reader, err := newImportReader(format, r)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ImportReport{}
+	chunk := make([]dto.CreateMessageRequest, 0, importChunkSize)
+	lines := make([]int, 0, importChunkSize)
+	lastLine := 0
+
+	// abort stops at the first row not inserted yet, the pending chunk or the line after the last row read
+	abort := func(err error) (*ImportReport, error) {
+		report.StoppedAt = lastLine + 1
+		if len(lines) > 0 {
+			report.StoppedAt = lines[0]
+		}
+		report.sortErrors()
+		return report, err
+	}
+
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+
+		results, err := s.messages.CreateBatch(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		for i, result := range results {
+			if result.Err != nil {
+				report.fail(lines[i], result.Err)
+				continue
+			}
+			report.Created++
+		}
+
+		chunk = chunk[:0]
+		lines = lines[:0]
+		return nil
+	}
+
+	for {
+		row, err := reader.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return abort(apperror.ErrImportFileInvalid.WithError(err))
+		}
+		report.Rows++
+		lastLine = row.line
+
+		if row.err == nil {
+			row.err = binding.Validator.ValidateStruct(&row.req)
+		}
+		if row.err != nil {
+			report.fail(row.line, apperror.ErrImportRowInvalid.WithError(row.err))
+			continue
+		}
+
+		chunk = append(chunk, row.req)
+		lines = append(lines, row.line)
+		if len(chunk) == importChunkSize {
+			if err := flush(); err != nil {
+				return abort(err)
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return abort(err)
+	}
+
+	report.sortErrors()
+	return report, nil
+}
+
+// sortErrors keeps the report in file order, insert failures are only known after their chunk
+func (r *ImportReport) sortErrors() {
+	sort.SliceStable(r.Errors, func(i, j int) bool {
+		return r.Errors[i].Line < r.Errors[j].Line
+	})
+}
+
+// fail records a rejected row
+func (r *ImportReport) fail(line int, err error) {
+	r.Failed++
+	if len(r.Errors) < maxImportErrors {
+		r.Errors = append(r.Errors, ImportRowError{Line: line, Err: err})
+	}
+}
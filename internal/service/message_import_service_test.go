@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/pkg/customerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// createdResults returns n successful batch results
+func createdResults(n int) []BatchResult {
+	results := make([]BatchResult, n)
+	for i := range results {
+		results[i].Message = &domain.Message{ID: uint(i + 1)}
+	}
+	return results
+}
+
+// assertImportCode checks the row error carries the expected error code
+func assertImportCode(t *testing.T, err error, code string) {
+	var appErr *customerror.CustomError
+	if assert.ErrorAs(t, err, &appErr) {
+		assert.Equal(t, code, appErr.Code)
+	}
+}
+
+func TestMessageImportService_Import_CSV(t *testing.T) {
+	mockService := new(MockMessageService)
+	importService := NewMessageImportService(mockService)
+
+	file := "\ufeffPhone,Content,SendAt,Priority,Note\n" +
+		"+905551111111,Hello,,high,vip\n" +
+		"123,Bad phone,,,\n" +
+		"+905552222222,Bad time,tomorrow,,\n" +
+		"+905553333333,Too few fields\n" +
+		"'+905554444444,\"Hello, again\",2030-01-01T10:00:00Z,,\n"
+
+	mockService.On("CreateBatch", mock.Anything, mock.MatchedBy(func(reqs []dto.CreateMessageRequest) bool {
+		return len(reqs) == 2 &&
+			reqs[0].PhoneNumber == "+905551111111" && reqs[0].Priority == domain.PriorityHigh &&
+			reqs[1].PhoneNumber == "+905554444444" && reqs[1].Content == "Hello, again" && reqs[1].SendAt != nil
+	})).Return(createdResults(2), nil)
+
+	report, err := importService.Import(context.Background(), ImportFormatCSV, strings.NewReader(file))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, report.Rows)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 3, report.Failed)
+	assert.Equal(t, []int{3, 4, 5}, []int{report.Errors[0].Line, report.Errors[1].Line, report.Errors[2].Line})
+	for _, rowErr := range report.Errors {
+		assertImportCode(t, rowErr.Err, apperror.ErrCodeImportRowInvalid)
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestMessageImportService_Import_NDJSON(t *testing.T) {
+	mockService := new(MockMessageService)
+	importService := NewMessageImportService(mockService)
+
+	file := `{"phoneNumber": "+905551111111", "content": "Hello"}
+
+{"phoneNumber": "+905552222222", "content": "Past", "sendAt": "2020-01-01T00:00:00Z"}
+not json
+`
+
+	mockService.On("CreateBatch", mock.Anything, mock.MatchedBy(func(reqs []dto.CreateMessageRequest) bool {
+		return len(reqs) == 2
+	})).Return([]BatchResult{
+		{Message: &domain.Message{ID: 1}},
+		{Err: apperror.ErrSendAtInPast},
+	}, nil)
+
+	report, err := importService.Import(context.Background(), ImportFormatNDJSON, strings.NewReader(file))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Rows)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 2, report.Failed)
+	assert.Equal(t, 3, report.Errors[0].Line)
+	assertImportCode(t, report.Errors[0].Err, apperror.ErrCodeSendAtInPast)
+	assert.Equal(t, 4, report.Errors[1].Line)
+	assertImportCode(t, report.Errors[1].Err, apperror.ErrCodeImportRowInvalid)
+}
+
+func TestMessageImportService_Import_InsertsInChunks(t *testing.T) {
+	mockService := new(MockMessageService)
+	importService := NewMessageImportService(mockService)
+
+	var file strings.Builder
+	file.WriteString("phoneNumber,content\n")
+	for i := 0; i < 2*importChunkSize+50; i++ {
+		fmt.Fprintf(&file, "+9055500%05d,Hello\n", i)
+	}
+
+	mockService.On("CreateBatch", mock.Anything, mock.MatchedBy(func(reqs []dto.CreateMessageRequest) bool {
+		return len(reqs) == importChunkSize
+	})).Return(createdResults(importChunkSize), nil).Twice()
+	mockService.On("CreateBatch", mock.Anything, mock.MatchedBy(func(reqs []dto.CreateMessageRequest) bool {
+		return len(reqs) == 50
+	})).Return(createdResults(50), nil).Once()
+
+	report, err := importService.Import(context.Background(), ImportFormatCSV, strings.NewReader(file.String()))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2*importChunkSize+50, report.Created)
+	assert.Empty(t, report.Errors)
+	mockService.AssertExpectations(t)
+}
+
+func TestMessageImportService_Import_InsertError(t *testing.T) {
+	mockService := new(MockMessageService)
+	importService := NewMessageImportService(mockService)
+
+	mockService.On("CreateBatch", mock.Anything, mock.Anything).Return(nil, apperror.ErrMessageCreateFailed.WithError(errors.New("db error")))
+
+	report, err := importService.Import(context.Background(), ImportFormatCSV, strings.NewReader("phone,content\n+905551111111,Hello\n"))
+
+	assertImportCode(t, err, apperror.ErrCodeMessageCreateFailed)
+	assert.Equal(t, 1, report.Rows)
+	assert.Equal(t, 0, report.Created)
+	assert.Equal(t, 2, report.StoppedAt)
+}
+
+func TestMessageImportService_Import_InsertErrorAfterCommittedChunk(t *testing.T) {
+	mockService := new(MockMessageService)
+	importService := NewMessageImportService(mockService)
+
+	var file strings.Builder
+	file.WriteString("phoneNumber,content\n")
+	for i := 0; i < 2*importChunkSize; i++ {
+		fmt.Fprintf(&file, "+9055500%05d,Hello\n", i)
+	}
+
+	mockService.On("CreateBatch", mock.Anything, mock.Anything).Return(createdResults(importChunkSize), nil).Once()
+	mockService.On("CreateBatch", mock.Anything, mock.Anything).Return(nil, apperror.ErrMessageCreateFailed.WithError(errors.New("db error"))).Once()
+
+	report, err := importService.Import(context.Background(), ImportFormatCSV, strings.NewReader(file.String()))
+
+	assertImportCode(t, err, apperror.ErrCodeMessageCreateFailed)
+	assert.Equal(t, importChunkSize, report.Created)
+	// The header is line 1, the failed chunk starts right after the first one
+	assert.Equal(t, importChunkSize+2, report.StoppedAt)
+	mockService.AssertExpectations(t)
+}
+
+func TestMessageImportService_Import_UnreadableFileAfterCommittedChunk(t *testing.T) {
+	mockService := new(MockMessageService)
+	importService := NewMessageImportService(mockService)
+
+	var file strings.Builder
+	for i := 0; i < importChunkSize+1; i++ {
+		fmt.Fprintf(&file, "{\"phoneNumber\":\"+9055500%05d\",\"content\":\"Hello\"}\n", i)
+	}
+	file.WriteString(strings.Repeat("x", maxImportLineSize+1))
+
+	mockService.On("CreateBatch", mock.Anything, mock.Anything).Return(createdResults(importChunkSize), nil).Once()
+
+	report, err := importService.Import(context.Background(), ImportFormatNDJSON, strings.NewReader(file.String()))
+
+	assertImportCode(t, err, apperror.ErrCodeImportFileInvalid)
+	assert.Equal(t, importChunkSize, report.Created)
+	// The last valid row was read but not inserted yet
+	assert.Equal(t, importChunkSize+1, report.StoppedAt)
+	mockService.AssertExpectations(t)
+}
+
+func TestMessageImportService_Import_InvalidFile(t *testing.T) {
+	tests := []struct {
+		name   string
+		format ImportFormat
+		file   string
+		code   string
+	}{
+		{name: "unsupported format", format: "xlsx", file: "", code: apperror.ErrCodeImportFormatUnsupported},
+		{name: "empty csv", format: ImportFormatCSV, file: "", code: apperror.ErrCodeImportFileInvalid},
+		{name: "csv without content column", format: ImportFormatCSV, file: "phone,text\n+905551111111,Hello\n", code: apperror.ErrCodeImportFileInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockMessageService)
+			importService := NewMessageImportService(mockService)
+
+			report, err := importService.Import(context.Background(), tt.format, strings.NewReader(tt.file))
+
+			assert.Nil(t, report)
+			assertImportCode(t, err, tt.code)
+			mockService.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestImportFormatFromFilename(t *testing.T) {
+	assert.Equal(t, ImportFormatCSV, ImportFormatFromFilename("Recipients.CSV"))
+	assert.Equal(t, ImportFormatNDJSON, ImportFormatFromFilename("messages.ndjson"))
+	assert.Equal(t, ImportFormatNDJSON, ImportFormatFromFilename("messages.jsonl"))
+	assert.Equal(t, ImportFormat(""), ImportFormatFromFilename("messages.xlsx"))
+}
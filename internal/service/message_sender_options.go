@@ -0,0 +1,97 @@
+package service
+
+import (
+	"time"
+
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/srcndev/message-service/internal/repository"
+	"github.com/srcndev/message-service/pkg/backoff"
+	"github.com/srcndev/message-service/pkg/circuitbreaker"
+)
+
+// Default values for optional message sender settings
+const (
+	defaultMaxAttempts       = 5
+	defaultBackoffBase       = 30 * time.Second
+	defaultBackoffMax        = 1 * time.Hour
+	defaultBackoffMultiplier = 2.0
+	defaultInstanceID        = "message-sender"
+	defaultLeaseDuration     = 5 * time.Minute
+	defaultConcurrency       = 1
+)
+
+// MessageSenderOption configures optional message sender behavior
+type MessageSenderOption func(*messageSenderService)
+
+// WithMaxAttempts sets how many delivery attempts a message gets before it is marked as failed
+func WithMaxAttempts(maxAttempts int) MessageSenderOption {
+	return func(s *messageSenderService) {
+		if maxAttempts > 0 {
+			s.maxAttempts = maxAttempts
+		}
+	}
+}
+
+// WithBackoff sets the retry delay policy applied after a failed delivery attempt
+func WithBackoff(policy backoff.Policy) MessageSenderOption {
+	return func(s *messageSenderService) {
+		s.backoff = policy
+	}
+}
+
+// WithInstanceID sets the lease owner recorded on messages claimed by this instance
+func WithInstanceID(instanceID string) MessageSenderOption {
+	return func(s *messageSenderService) {
+		if instanceID != "" {
+			s.instanceID = instanceID
+		}
+	}
+}
+
+// WithLeaseDuration sets how long a claimed message stays reserved for this instance
+func WithLeaseDuration(leaseDuration time.Duration) MessageSenderOption {
+	return func(s *messageSenderService) {
+		if leaseDuration > 0 {
+			s.leaseDuration = leaseDuration
+		}
+	}
+}
+
+// WithConcurrency sets how many messages of a batch are sent in parallel
+func WithConcurrency(concurrency int) MessageSenderOption {
+	return func(s *messageSenderService) {
+		if concurrency > 0 {
+			s.concurrency = concurrency
+		}
+	}
+}
+
+// WithCircuitBreaker skips sending cycles while the webhook circuit breaker is open
+func WithCircuitBreaker(breaker circuitbreaker.Breaker) MessageSenderOption {
+	return func(s *messageSenderService) {
+		s.breaker = breaker
+	}
+}
+
+// WithPriorityShares reserves a percentage of each batch per priority lane so busy lanes cannot starve the others
+func WithPriorityShares(shares map[domain.MessagePriority]int) MessageSenderOption {
+	return func(s *messageSenderService) {
+		s.priorityShares = shares
+	}
+}
+
+// WithSendIntents records every webhook call before it is made, so calls interrupted by a crash are reconciled
+// by RecoverSendIntents and ReconcileSendIntents instead of being sent again blindly
+func WithSendIntents(intents repository.SendIntentRepository) MessageSenderOption {
+	return func(s *messageSenderService) {
+		s.intents = intents
+	}
+}
+
+// WithSuppressionCheck checks every recipient against the suppression list right before sending,
+// since a number may be suppressed after its messages were created
+func WithSuppressionCheck(suppressions SuppressionService) MessageSenderOption {
+	return func(s *messageSenderService) {
+		s.suppressions = suppressions
+	}
+}
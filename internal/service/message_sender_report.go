@@ -0,0 +1,72 @@
+package service
+
+import "time"
+
+// SendResult holds the outcome of a single message in a sending cycle
+type SendResult struct {
+	MessageID        uint
+	WebhookMessageID string
+	Err              error
+	Skipped          bool // Not sent because the cycle was cancelled, or the circuit breaker or rate limiter rejected it
+	Expired          bool // Not sent because its validity elapsed before it could be sent
+	Suppressed       bool // Not sent because the recipient is on the suppression list
+}
+
+// SendReport summarizes a sending cycle
+type SendReport struct {
+	Claimed     int
+	Sent        int
+	Failed      int
+	Skipped     int
+	Expired     int
+	Suppressed  int
+	Full        bool // A whole batch was claimed, so more messages are likely pending
+	CircuitOpen bool // The cycle was skipped because the webhook circuit breaker is open
+	Duration    time.Duration
+	Results     []SendResult
+}
+
+// newSendReport aggregates per-message results into a cycle report
+func newSendReport(results []SendResult, duration time.Duration) *SendReport {
+	report := &SendReport{
+		Claimed:  len(results),
+		Duration: duration,
+		Results:  results,
+	}
+
+	for _, result := range results {
+		switch {
+		case result.Expired:
+			report.Expired++
+		case result.Suppressed:
+			report.Suppressed++
+		case result.Skipped:
+			report.Skipped++
+		case result.Err != nil:
+			report.Failed++
+		default:
+			report.Sent++
+		}
+	}
+
+	return report
+}
+
+// AllFailed reports whether every attempted message in the cycle failed
+func (r *SendReport) AllFailed() bool {
+	return r.Failed > 0 && r.Sent == 0
+}
+
+// Progressed reports whether the cycle moved any message out of the due queue. A cycle that only handed
+// its messages back (limiter, breaker, suppression lookup failure) would claim the same rows again.
+func (r *SendReport) Progressed() bool {
+	return r.Sent+r.Failed+r.Expired+r.Suppressed > 0
+}
+
+// RecoveryReport summarizes the reconciliation of webhook calls whose outcome was never recorded
+type RecoveryReport struct {
+	InFlight int // Calls found without a recorded outcome
+	Closed   int // Calls whose message had reached a final state, nothing was sent
+	Held     int // Calls whose message is leased by another running instance, left to it
+	Unknown  int // Calls that may have reached the provider, their message is held as unknown for review
+}
@@ -2,27 +2,53 @@ package service
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"time"
 
 	"github.com/srcndev/message-service/internal/apperror"
 	"github.com/srcndev/message-service/internal/domain"
 	"github.com/srcndev/message-service/internal/repository"
+	"github.com/srcndev/message-service/pkg/backoff"
+	"github.com/srcndev/message-service/pkg/circuitbreaker"
+	"github.com/srcndev/message-service/pkg/customerror"
 	"github.com/srcndev/message-service/pkg/logger"
+	"github.com/srcndev/message-service/pkg/ratelimit"
 	"github.com/srcndev/message-service/pkg/webhook"
 )
 
 // MessageSenderService defines the message sender service interface
 type MessageSenderService interface {
-	// SendPendingMessages fetches and sends pending messages
-	SendPendingMessages(ctx context.Context) error
+	// SendPendingMessages claims and sends pending messages, returning a cycle report
+	SendPendingMessages(ctx context.Context) (*SendReport, error)
+
+	// RecoverSendIntents reconciles webhook calls interrupted before their outcome was recorded,
+	// it must run before this instance starts sending
+	RecoverSendIntents(ctx context.Context) (*RecoveryReport, error)
+
+	// ReconcileSendIntents reconciles interrupted webhook calls whose message lease expired, whatever
+	// instance made them, it is safe to run on a schedule while sending
+	ReconcileSendIntents(ctx context.Context) (*RecoveryReport, error)
 }
 
+// maxRecoveredIntents bounds the in-flight calls reconciled by a single recovery run
+const maxRecoveredIntents = 1000
+
 type messageSenderService struct {
 	messageService MessageService
 	cacheRepo      repository.MessageCacheRepository
 	webhookClient  webhook.Client
 	batchSize      int
 	cacheEnabled   bool
+	maxAttempts    int
+	backoff        backoff.Policy
+	instanceID     string
+	leaseDuration  time.Duration
+	concurrency    int
+	breaker        circuitbreaker.Breaker
+	priorityShares map[domain.MessagePriority]int
+	intents        repository.SendIntentRepository
+	suppressions   SuppressionService
 }
 
 // Compile-time interface compliance check
@@ -35,77 +61,359 @@ func NewMessageSenderService(
 	webhookClient webhook.Client,
 	batchSize int,
 	cacheEnabled bool,
+	opts ...MessageSenderOption,
 ) MessageSenderService {
 	if batchSize <= 0 {
 		batchSize = 2 // Default batch size from case study
 	}
 
-	return &messageSenderService{
+	s := &messageSenderService{
 		messageService: messageService,
 		cacheRepo:      cacheRepo,
 		webhookClient:  webhookClient,
 		batchSize:      batchSize,
 		cacheEnabled:   cacheEnabled,
+		maxAttempts:    defaultMaxAttempts,
+		backoff:        backoff.NewPolicy(defaultBackoffBase, defaultBackoffMax, defaultBackoffMultiplier),
+		instanceID:     defaultInstanceID,
+		leaseDuration:  defaultLeaseDuration,
+		concurrency:    defaultConcurrency,
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
+
+	return s
 }
 
-// SendPendingMessages fetches and sends pending messages in batches
-func (s *messageSenderService) SendPendingMessages(ctx context.Context) error {
-	// Get pending messages
-	messages, err := s.messageService.GetPendingMessages(ctx, s.batchSize)
+// SendPendingMessages claims pending messages and sends them with a bounded worker pool
+func (s *messageSenderService) SendPendingMessages(ctx context.Context) (*SendReport, error) {
+	startedAt := time.Now()
+
+	// Skip the cycle quickly while the webhook is known to be down, a half-open breaker gets a single probe
+	limit := s.batchSize
+	if s.breaker != nil {
+		switch s.breaker.State() {
+		case circuitbreaker.StateOpen:
+			logger.Info("Webhook circuit breaker is open, skipping sending cycle")
+			report := newSendReport(nil, time.Since(startedAt))
+			report.CircuitOpen = true
+			return report, nil
+		case circuitbreaker.StateHalfOpen:
+			limit = 1
+		}
+	}
+
+	// Claim pending messages so other instances skip them
+	messages, err := s.messageService.ClaimPendingMessages(ctx, s.instanceID, limit, reserveLanes(limit, s.priorityShares), s.leaseDuration)
 	if err != nil {
-		return apperror.ErrMessageListFailed.WithError(err)
+		return nil, apperror.ErrMessageListFailed.WithError(err)
 	}
 
 	// No pending messages
 	if len(messages) == 0 {
+		return newSendReport(nil, time.Since(startedAt)), nil
+	}
+
+	results := s.sendAll(ctx, messages)
+	report := newSendReport(results, time.Since(startedAt))
+	report.Full = len(messages) >= s.batchSize
+	report.CircuitOpen = s.breaker != nil && s.breaker.State() == circuitbreaker.StateOpen
+
+	// If all messages failed, return error alongside the report
+	if report.AllFailed() {
+		return report, apperror.ErrMessageSendFailed
+	}
+
+	return report, nil
+}
+
+// RecoverSendIntents looks at every webhook call whose outcome was never recorded, usually because the process
+// died during the call, and must run before this instance starts sending. A message that reached a final state
+// only gets its intent closed. The providers offer no status lookup, so nothing proves a call never reached
+// the provider: instead of being sent again, a message still waiting is held as unknown for review.
+func (s *messageSenderService) RecoverSendIntents(ctx context.Context) (*RecoveryReport, error) {
+	return s.recoverIntents(ctx, time.Now(), s.instanceID)
+}
+
+// ReconcileSendIntents recovers the calls left behind by an instance that restarted under another ID or never
+// came back. Only calls older than the lease are listed and only messages whose lease expired are held, so the
+// calls running instances are still making are left alone.
+func (s *messageSenderService) ReconcileSendIntents(ctx context.Context) (*RecoveryReport, error) {
+	return s.recoverIntents(ctx, time.Now().Add(-s.leaseDuration), "")
+}
+
+// recoverIntents reconciles the in-flight calls started before the given time, holding messages that are
+// pending, past their lease or leased to owner
+func (s *messageSenderService) recoverIntents(ctx context.Context, startedBefore time.Time, owner string) (*RecoveryReport, error) {
+	report := &RecoveryReport{}
+	if s.intents == nil {
+		return report, nil
+	}
+
+	intents, err := s.intents.ListInFlight(ctx, startedBefore, maxRecoveredIntents)
+	if err != nil {
+		return nil, apperror.ErrSendIntentRecoveryFailed.WithError(err)
+	}
+	report.InFlight = len(intents)
+
+	for _, intent := range intents {
+		msg, err := s.messageService.GetByID(ctx, intent.MessageID)
+		if err != nil && !errors.Is(err, apperror.ErrMessageNotFound) {
+			return report, apperror.ErrSendIntentRecoveryFailed.WithError(err)
+		}
+
+		status := domain.IntentClosed
+		switch {
+		case msg != nil && (msg.Status == domain.StatusPending || msg.Status == domain.StatusProcessing):
+			// Only a message no running instance is sending is held, the lease owner resolves its own call
+			marked, err := s.messageService.MarkUnknown(ctx, intent.MessageID, owner)
+			if err != nil {
+				return report, apperror.ErrSendIntentRecoveryFailed.WithError(err)
+			}
+			if !marked {
+				report.Held++
+				continue
+			}
+			logger.Info("Message %d was in flight (attempt %d by %s), holding it as unknown for review",
+				intent.MessageID, intent.Attempt, intent.InstanceID)
+			status = domain.IntentUnknown
+			report.Unknown++
+		case msg != nil && msg.SentAt != nil:
+			status = domain.IntentSent
+			report.Closed++
+		default:
+			report.Closed++
+		}
+
+		if err := s.intents.Resolve(ctx, intent.MessageID, status); err != nil {
+			return report, apperror.ErrSendIntentRecoveryFailed.WithError(err)
+		}
+	}
+
+	return report, nil
+}
+
+// reserveLanes splits the percentage shares of a batch into message counts per lane.
+// Counts are rounded down and the leftover slots go to the lanes with the largest remainders,
+// more urgent lanes first on ties. When the batch has a slot for every lane with a share,
+// each of them gets at least one so a small share is never rounded away.
+func reserveLanes(limit int, shares map[domain.MessagePriority]int) map[domain.MessagePriority]int {
+	if len(shares) == 0 {
 		return nil
 	}
 
-	// Send each message
-	var sendErrors []error
-	for _, msg := range messages {
-		if err := s.sendMessage(ctx, msg); err != nil {
-			// Log error but continue with other messages
-			logger.Error("Failed to send message %d: %v", msg.ID, err)
-			sendErrors = append(sendErrors, err)
+	reserved := make(map[domain.MessagePriority]int, len(shares))
+	remainders := make(map[domain.MessagePriority]int, len(shares))
+	total, percent := 0, 0
+	for _, lane := range domain.Priorities {
+		reserved[lane] = limit * shares[lane] / 100
+		remainders[lane] = limit * shares[lane] % 100
+		total += reserved[lane]
+		percent += shares[lane]
+	}
+
+	// Only hand out the slots the shares cover, the rest of the batch stays unreserved
+	for leftover := limit*percent/100 - total; leftover > 0; leftover-- {
+		best := domain.Priorities[0]
+		for _, lane := range domain.Priorities[1:] {
+			if remainders[lane] > remainders[best] {
+				best = lane
+			}
+		}
+		if remainders[best] == 0 {
+			break
+		}
+		reserved[best]++
+		remainders[best] = 0
+	}
+
+	lanes := 0
+	for _, lane := range domain.Priorities {
+		if shares[lane] > 0 {
+			lanes++
+		}
+	}
+	if limit < lanes {
+		return reserved
+	}
+
+	for _, lane := range domain.Priorities {
+		if shares[lane] == 0 || reserved[lane] > 0 {
 			continue
 		}
+		// Take the slot from the lane holding the most, or from the unreserved part of the batch
+		largest := domain.Priorities[0]
+		for _, other := range domain.Priorities[1:] {
+			if reserved[other] > reserved[largest] {
+				largest = other
+			}
+		}
+		if reserved[largest] > 1 {
+			reserved[largest]--
+		}
+		reserved[lane] = 1
+	}
+
+	return reserved
+}
+
+// sendAll sends messages in parallel, never running more than the configured concurrency at once
+func (s *messageSenderService) sendAll(ctx context.Context, messages []*domain.Message) []SendResult {
+	results := make([]SendResult, len(messages))
+	jobs := make(chan int)
+
+	workers := s.concurrency
+	if workers > len(messages) {
+		workers = len(messages)
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = s.sendOne(ctx, messages[i])
+			}
+		}()
+	}
+
+	// Stop handing out work once the scheduler cancels the cycle
+	dispatched := 0
+dispatch:
+	for ; dispatched < len(messages); dispatched++ {
+		select {
+		case jobs <- dispatched:
+		case <-ctx.Done():
+			break dispatch
+		}
 	}
+	close(jobs)
+	wg.Wait()
 
-	// If all messages failed, return error
-	if len(sendErrors) > 0 && len(sendErrors) == len(messages) {
-		return apperror.ErrMessageSendFailed
+	for i := dispatched; i < len(messages); i++ {
+		results[i] = SendResult{MessageID: messages[i].ID, Skipped: true}
 	}
 
-	return nil
+	return results
 }
 
-// sendMessage sends a single message via webhook
-func (s *messageSenderService) sendMessage(ctx context.Context, msg *domain.Message) error {
-	// Prepare webhook request
+// sendOne sends a single message and converts the outcome into a result
+func (s *messageSenderService) sendOne(ctx context.Context, msg *domain.Message) SendResult {
+	if ctx.Err() != nil {
+		return SendResult{MessageID: msg.ID, Skipped: true}
+	}
+
+	// A message past its validity is worthless to the recipient, so it is never sent
+	if msg.ExpiresAt != nil && !msg.ExpiresAt.After(time.Now()) {
+		if err := s.messageService.ExpireMessage(ctx, msg.ID, s.instanceID); err != nil {
+			logger.Error("Failed to expire message %d: %v", msg.ID, err)
+			return SendResult{MessageID: msg.ID, Err: err}
+		}
+		logger.Info("Message %d expired at %s, skipping", msg.ID, msg.ExpiresAt.Format(time.RFC3339))
+		return SendResult{MessageID: msg.ID, Expired: true}
+	}
+
+	if result, held := s.checkSuppression(ctx, msg); held {
+		return result
+	}
+
+	webhookMessageID, err := s.sendMessage(ctx, msg)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			logger.Info("Sending message %d cancelled, leaving it for the lease reaper or send intent recovery", msg.ID)
+			return SendResult{MessageID: msg.ID, Skipped: true}
+		}
+		if notAttempted(err) {
+			// Never reached the webhook, so hand it back without spending an attempt
+			logger.Info("Message %d was not sent (%v), releasing it", msg.ID, err)
+			if releaseErr := s.messageService.ReleaseLease(ctx, msg.ID, s.instanceID); releaseErr != nil {
+				logger.Error("Failed to release message %d: %v", msg.ID, releaseErr)
+			}
+			return SendResult{MessageID: msg.ID, Skipped: true}
+		}
+		logger.Error("Failed to send message %d: %v", msg.ID, err)
+	}
+
+	return SendResult{MessageID: msg.ID, WebhookMessageID: webhookMessageID, Err: err}
+}
+
+// checkSuppression keeps a message to a suppressed number from being sent, reporting true when it was held back
+func (s *messageSenderService) checkSuppression(ctx context.Context, msg *domain.Message) (SendResult, bool) {
+	if s.suppressions == nil || msg.KeywordReply {
+		return SendResult{}, false
+	}
+
+	suppressed, err := s.suppressions.IsSuppressed(ctx, msg.PhoneNumber)
+	if err != nil {
+		// Never send without knowing, hand it back without spending an attempt
+		logger.Error("Failed to check suppression of message %d: %v", msg.ID, err)
+		if releaseErr := s.messageService.ReleaseLease(ctx, msg.ID, s.instanceID); releaseErr != nil {
+			logger.Error("Failed to release message %d: %v", msg.ID, releaseErr)
+		}
+		return SendResult{MessageID: msg.ID, Skipped: true}, true
+	}
+	if !suppressed {
+		return SendResult{}, false
+	}
+
+	if err := s.messageService.SuppressMessage(ctx, msg.ID, s.instanceID); err != nil {
+		logger.Error("Failed to suppress message %d: %v", msg.ID, err)
+		return SendResult{MessageID: msg.ID, Err: err}, true
+	}
+	logger.Info("Message %d recipient is on the suppression list, skipping", msg.ID)
+	return SendResult{MessageID: msg.ID, Suppressed: true}, true
+}
+
+// sendMessage sends a single message via webhook and returns the webhook message ID
+func (s *messageSenderService) sendMessage(ctx context.Context, msg *domain.Message) (string, error) {
+	// Prepare webhook request, every attempt of the message carries the same idempotency key
 	req := &webhook.SendMessageRequest{
-		To:      msg.PhoneNumber,
-		Content: msg.Content,
+		To:             msg.PhoneNumber,
+		Content:        msg.Content,
+		IdempotencyKey: msg.IdempotencyKey(),
+	}
+
+	// Record the call before making it, so a crash before its outcome is stored can be reconciled
+	if err := s.beginIntent(ctx, msg, req.IdempotencyKey); err != nil {
+		return "", err
 	}
 
 	// Send via webhook
 	resp, err := s.webhookClient.SendMessage(ctx, req)
 	if err != nil {
-		// Don't mark as failed - leave it pending for retry in next cycle
-		logger.Error("Failed to send message %d: %v (will retry in next cycle)", msg.ID, err)
-		return apperror.ErrWebhookCallFailed.WithError(err)
+		// A cancelled cycle is not a delivery failure, the lease expires and the message is retried.
+		// The call may have reached the provider, so its intent stays in flight unless the cycle
+		// stopped while waiting for a rate limit token, before anything was sent.
+		if ctx.Err() != nil {
+			if ratelimit.IsWaitCancelled(err) {
+				s.abandonIntent(context.WithoutCancel(ctx), msg.ID)
+			}
+			return "", ctx.Err()
+		}
+		// Resolve the call first, a message back to pending with its call in flight would be held as unknown
+		s.resolveIntent(ctx, msg.ID, domain.IntentFailed)
+		if notAttempted(err) {
+			return "", err
+		}
+		return "", s.handleSendFailure(ctx, msg, err)
 	}
 
-	// Mark as sent with messageID from webhook
-	if err := s.messageService.SetSent(ctx, msg.ID, resp.MessageID); err != nil {
-		return apperror.ErrMarkSentFailed.WithError(err)
+	// Record the outcome even if the cycle is cancelled after the webhook accepted the message
+	ctx = context.WithoutCancel(ctx)
+
+	// Mark as sent with messageID and provider from webhook, on failure the intent stays in flight for recovery
+	if err := s.messageService.SetSent(ctx, msg.ID, s.instanceID, resp.MessageID, resp.Provider); err != nil {
+		return "", apperror.ErrMarkSentFailed.WithError(err)
 	}
+	s.resolveIntent(ctx, msg.ID, domain.IntentSent)
 
 	// Cache to Redis if enabled (Bonus feature)
 	if s.cacheEnabled && s.cacheRepo != nil {
 		sentAt := time.Now()
-		if cacheErr := s.cacheRepo.CacheSentMessage(ctx, resp.MessageID, sentAt); cacheErr != nil {
+		if cacheErr := s.cacheRepo.CacheSentMessage(ctx, resp.MessageID, msg.ID, sentAt); cacheErr != nil {
 			// Log but don't fail the operation
 			logger.Error("Failed to cache message %s to Redis: %v", resp.MessageID, cacheErr)
 		} else {
@@ -113,6 +421,85 @@ func (s *messageSenderService) sendMessage(ctx context.Context, msg *domain.Mess
 		}
 	}
 
-	logger.Info("Message %d sent successfully (webhook messageId: %s)", msg.ID, resp.MessageID)
-	return nil
+	logger.Info("Message %d sent successfully (webhook messageId: %s, provider: %s)", msg.ID, resp.MessageID, resp.Provider)
+	return resp.MessageID, nil
+}
+
+// beginIntent records the webhook call about to be made, without it the message is handed back unsent
+func (s *messageSenderService) beginIntent(ctx context.Context, msg *domain.Message, idempotencyKey string) error {
+	if s.intents == nil {
+		return nil
+	}
+
+	err := s.intents.Begin(ctx, &domain.SendIntent{
+		MessageID:      msg.ID,
+		IdempotencyKey: idempotencyKey,
+		Attempt:        msg.AttemptCount + 1,
+		InstanceID:     s.instanceID,
+		StartedAt:      time.Now(),
+	})
+	if err == nil {
+		return nil
+	}
+
+	if releaseErr := s.messageService.ReleaseLease(ctx, msg.ID, s.instanceID); releaseErr != nil {
+		logger.Error("Failed to release message %d: %v", msg.ID, releaseErr)
+	}
+	return apperror.ErrSendIntentFailed.WithError(err)
+}
+
+// abandonIntent closes the intent of a call that was never made and hands the message back unsent
+func (s *messageSenderService) abandonIntent(ctx context.Context, messageID uint) {
+	s.resolveIntent(ctx, messageID, domain.IntentFailed)
+	if err := s.messageService.ReleaseLease(ctx, messageID, s.instanceID); err != nil {
+		logger.Error("Failed to release message %d: %v", messageID, err)
+	}
+}
+
+// resolveIntent records the outcome of the webhook call, a failure only leaves work for the next recovery
+func (s *messageSenderService) resolveIntent(ctx context.Context, messageID uint, status domain.SendIntentStatus) {
+	if s.intents == nil {
+		return
+	}
+	if err := s.intents.Resolve(ctx, messageID, status); err != nil {
+		logger.Error("Failed to resolve send intent of message %d: %v", messageID, err)
+	}
+}
+
+// handleSendFailure records a failed attempt and marks the message as failed once max attempts is reached
+func (s *messageSenderService) handleSendFailure(ctx context.Context, msg *domain.Message, sendErr error) error {
+	errCode, errMessage := errorDetails(sendErr)
+	attempt := msg.AttemptCount + 1
+
+	if attempt >= s.maxAttempts {
+		logger.Error("Failed to send message %d: %v (attempt %d/%d, marking as failed)", msg.ID, sendErr, attempt, s.maxAttempts)
+		if err := s.messageService.SetFailed(ctx, msg.ID, s.instanceID, errCode, errMessage); err != nil {
+			return apperror.ErrMarkFailedFailed.WithError(err)
+		}
+		return apperror.ErrWebhookCallFailed.WithError(sendErr)
+	}
+
+	// Leave it pending and push the next attempt out with exponential backoff
+	nextAttemptAt := time.Now().Add(s.backoff.Next(attempt))
+	logger.Error("Failed to send message %d: %v (attempt %d/%d, will retry at %s)", msg.ID, sendErr, attempt, s.maxAttempts, nextAttemptAt.Format(time.RFC3339))
+	if err := s.messageService.RecordFailedAttempt(ctx, msg.ID, s.instanceID, errCode, errMessage, nextAttemptAt); err != nil {
+		return apperror.ErrRecordAttemptFailed.WithError(err)
+	}
+
+	return apperror.ErrWebhookCallFailed.WithError(sendErr)
+}
+
+// notAttempted reports whether the webhook call was rejected before it was made, by an open circuit breaker
+// or a rate limiter whose backend is down. Such a failure is not the message's fault and costs no attempt.
+func notAttempted(err error) bool {
+	return circuitbreaker.IsOpen(err) || ratelimit.IsUnavailable(err)
+}
+
+// errorDetails extracts an error code and message to store on the message
+func errorDetails(err error) (string, string) {
+	var customErr *customerror.CustomError
+	if errors.As(err, &customErr) {
+		return customErr.Code, customErr.Error()
+	}
+	return apperror.ErrCodeWebhookCallFailed, err.Error()
 }
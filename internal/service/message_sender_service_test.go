@@ -3,12 +3,18 @@ package service
 import (
 	"context"
 	"errors"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/srcndev/message-service/internal/apperror"
 	"github.com/srcndev/message-service/internal/domain"
 	"github.com/srcndev/message-service/internal/dto"
 	"github.com/srcndev/message-service/internal/repository"
+	"github.com/srcndev/message-service/pkg/backoff"
+	"github.com/srcndev/message-service/pkg/circuitbreaker"
+	"github.com/srcndev/message-service/pkg/ratelimit"
 	"github.com/srcndev/message-service/pkg/webhook"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -27,6 +33,14 @@ func (m *MockMessageService) Create(ctx context.Context, req dto.CreateMessageRe
 	return args.Get(0).(*domain.Message), args.Error(1)
 }
 
+func (m *MockMessageService) CreateBatch(ctx context.Context, reqs []dto.CreateMessageRequest) ([]BatchResult, error) {
+	args := m.Called(ctx, reqs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]BatchResult), args.Error(1)
+}
+
 func (m *MockMessageService) GetByID(ctx context.Context, id uint) (*domain.Message, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -35,14 +49,22 @@ func (m *MockMessageService) GetByID(ctx context.Context, id uint) (*domain.Mess
 	return args.Get(0).(*domain.Message), args.Error(1)
 }
 
-func (m *MockMessageService) List(ctx context.Context, limit, offset int) ([]*domain.Message, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockMessageService) List(ctx context.Context, filter domain.MessageFilter, limit, offset int) ([]*domain.Message, error) {
+	args := m.Called(ctx, filter, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*domain.Message), args.Error(1)
 }
 
+func (m *MockMessageService) ListPage(ctx context.Context, filter domain.MessageFilter, page domain.MessagePageQuery) (*domain.MessagePage, error) {
+	args := m.Called(ctx, filter, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MessagePage), args.Error(1)
+}
+
 func (m *MockMessageService) GetPendingMessages(ctx context.Context, limit int) ([]*domain.Message, error) {
 	args := m.Called(ctx, limit)
 	if args.Get(0) == nil {
@@ -51,6 +73,52 @@ func (m *MockMessageService) GetPendingMessages(ctx context.Context, limit int)
 	return args.Get(0).([]*domain.Message), args.Error(1)
 }
 
+func (m *MockMessageService) MarkUnknown(ctx context.Context, id uint, owner string) (bool, error) {
+	args := m.Called(ctx, id, owner)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMessageService) ClaimPendingMessages(ctx context.Context, owner string, limit int, reserved map[domain.MessagePriority]int, leaseDuration time.Duration) ([]*domain.Message, error) {
+	args := m.Called(ctx, owner, limit, reserved, leaseDuration)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+
+func (m *MockMessageService) ExpireMessage(ctx context.Context, id uint, owner string) error {
+	args := m.Called(ctx, id, owner)
+	return args.Error(0)
+}
+
+func (m *MockMessageService) CreateReply(ctx context.Context, phoneNumber, content string) (*domain.Message, error) {
+	args := m.Called(ctx, phoneNumber, content)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+
+func (m *MockMessageService) SuppressMessage(ctx context.Context, id uint, owner string) error {
+	args := m.Called(ctx, id, owner)
+	return args.Error(0)
+}
+
+func (m *MockMessageService) ExpirePendingMessages(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMessageService) ReleaseExpiredLeases(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMessageService) ReleaseLease(ctx context.Context, id uint, owner string) error {
+	args := m.Called(ctx, id, owner)
+	return args.Error(0)
+}
+
 func (m *MockMessageService) ListSentMessages(ctx context.Context, limit, offset int) ([]*domain.Message, error) {
 	args := m.Called(ctx, limit, offset)
 	if args.Get(0) == nil {
@@ -59,8 +127,39 @@ func (m *MockMessageService) ListSentMessages(ctx context.Context, limit, offset
 	return args.Get(0).([]*domain.Message), args.Error(1)
 }
 
-func (m *MockMessageService) SetSent(ctx context.Context, id uint, messageID string) error {
-	args := m.Called(ctx, id, messageID)
+func (m *MockMessageService) ListFailedMessages(ctx context.Context, limit, offset int) ([]*domain.Message, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+
+// Export feeds the messages given to Return to fn, then returns the error
+func (m *MockMessageService) Export(ctx context.Context, filter domain.MessageFilter, fn func(*domain.Message) error) error {
+	args := m.Called(ctx, filter)
+	if messages, ok := args.Get(0).([]*domain.Message); ok {
+		for _, message := range messages {
+			if err := fn(message); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockMessageService) SetSent(ctx context.Context, id uint, owner, messageID, provider string) error {
+	args := m.Called(ctx, id, owner, messageID, provider)
+	return args.Error(0)
+}
+
+func (m *MockMessageService) RecordFailedAttempt(ctx context.Context, id uint, owner, errCode, errMessage string, nextAttemptAt time.Time) error {
+	args := m.Called(ctx, id, owner, errCode, errMessage, nextAttemptAt)
+	return args.Error(0)
+}
+
+func (m *MockMessageService) SetFailed(ctx context.Context, id uint, owner, errCode, errMessage string) error {
+	args := m.Called(ctx, id, owner, errCode, errMessage)
 	return args.Error(0)
 }
 
@@ -95,8 +194,8 @@ type MockCacheRepository struct {
 	mock.Mock
 }
 
-func (m *MockCacheRepository) CacheSentMessage(ctx context.Context, messageID string, sentAt time.Time) error {
-	args := m.Called(ctx, messageID, sentAt)
+func (m *MockCacheRepository) CacheSentMessage(ctx context.Context, messageID string, id uint, sentAt time.Time) error {
+	args := m.Called(ctx, messageID, id, sentAt)
 	return args.Error(0)
 }
 
@@ -125,7 +224,7 @@ func TestMessageSenderService_SendPendingMessages_Success(t *testing.T) {
 		{ID: 2, PhoneNumber: "+905552222222", Content: "Message 2", Status: domain.StatusPending},
 	}
 
-	mockMsgService.On("GetPendingMessages", mock.Anything, 2).Return(pendingMessages, nil)
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).Return(pendingMessages, nil)
 
 	// First message
 	mockWebhook.On("SendMessage", mock.Anything, mock.MatchedBy(func(req *webhook.SendMessageRequest) bool {
@@ -133,9 +232,10 @@ func TestMessageSenderService_SendPendingMessages_Success(t *testing.T) {
 	})).Return(&webhook.SendMessageResponse{
 		Message:   "Accepted",
 		MessageID: "webhook-id-1",
+		Provider:  "primary",
 	}, nil)
-	mockMsgService.On("SetSent", mock.Anything, uint(1), "webhook-id-1").Return(nil)
-	mockCache.On("CacheSentMessage", mock.Anything, "webhook-id-1", mock.Anything).Return(nil)
+	mockMsgService.On("SetSent", mock.Anything, uint(1), mock.Anything, "webhook-id-1", "primary").Return(nil)
+	mockCache.On("CacheSentMessage", mock.Anything, "webhook-id-1", uint(1), mock.Anything).Return(nil)
 
 	// Second message
 	mockWebhook.On("SendMessage", mock.Anything, mock.MatchedBy(func(req *webhook.SendMessageRequest) bool {
@@ -144,12 +244,16 @@ func TestMessageSenderService_SendPendingMessages_Success(t *testing.T) {
 		Message:   "Accepted",
 		MessageID: "webhook-id-2",
 	}, nil)
-	mockMsgService.On("SetSent", mock.Anything, uint(2), "webhook-id-2").Return(nil)
-	mockCache.On("CacheSentMessage", mock.Anything, "webhook-id-2", mock.Anything).Return(nil)
+	mockMsgService.On("SetSent", mock.Anything, uint(2), mock.Anything, "webhook-id-2", "").Return(nil)
+	mockCache.On("CacheSentMessage", mock.Anything, "webhook-id-2", uint(2), mock.Anything).Return(nil)
 
-	err := service.SendPendingMessages(context.Background())
+	report, err := service.SendPendingMessages(context.Background())
 
 	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Claimed)
+	assert.Equal(t, 2, report.Sent)
+	assert.Equal(t, 0, report.Failed)
+	assert.True(t, report.Full)
 	mockMsgService.AssertExpectations(t)
 	mockWebhook.AssertExpectations(t)
 	mockCache.AssertExpectations(t)
@@ -162,11 +266,12 @@ func TestMessageSenderService_SendPendingMessages_NoPendingMessages(t *testing.T
 
 	service := NewMessageSenderService(mockMsgService, mockCache, mockWebhook, 2, true)
 
-	mockMsgService.On("GetPendingMessages", mock.Anything, 2).Return([]*domain.Message{}, nil)
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).Return([]*domain.Message{}, nil)
 
-	err := service.SendPendingMessages(context.Background())
+	report, err := service.SendPendingMessages(context.Background())
 
 	assert.NoError(t, err)
+	assert.False(t, report.Full)
 	mockMsgService.AssertExpectations(t)
 	// Webhook should not be called
 	mockWebhook.AssertNotCalled(t, "SendMessage")
@@ -180,9 +285,9 @@ func TestMessageSenderService_SendPendingMessages_GetPendingError(t *testing.T)
 	service := NewMessageSenderService(mockMsgService, mockCache, mockWebhook, 2, false)
 
 	dbError := errors.New("database error")
-	mockMsgService.On("GetPendingMessages", mock.Anything, 2).Return(nil, dbError)
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).Return(nil, dbError)
 
-	err := service.SendPendingMessages(context.Background())
+	_, err := service.SendPendingMessages(context.Background())
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "MESSAGE_LIST_FAILED")
@@ -201,13 +306,15 @@ func TestMessageSenderService_SendPendingMessages_WebhookFailure(t *testing.T) {
 		{ID: 2, PhoneNumber: "+905552222222", Content: "Message 2", Status: domain.StatusPending},
 	}
 
-	mockMsgService.On("GetPendingMessages", mock.Anything, 2).Return(pendingMessages, nil)
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).Return(pendingMessages, nil)
 
 	// Both messages fail webhook
 	webhookError := errors.New("webhook connection error")
 	mockWebhook.On("SendMessage", mock.Anything, mock.Anything).Return(nil, webhookError)
+	mockMsgService.On("RecordFailedAttempt", mock.Anything, uint(1), mock.Anything, "WEBHOOK_CALL_FAILED", "webhook connection error", mock.Anything).Return(nil)
+	mockMsgService.On("RecordFailedAttempt", mock.Anything, uint(2), mock.Anything, "WEBHOOK_CALL_FAILED", "webhook connection error", mock.Anything).Return(nil)
 
-	err := service.SendPendingMessages(context.Background())
+	_, err := service.SendPendingMessages(context.Background())
 
 	// Should return error when ALL messages fail
 	assert.Error(t, err)
@@ -216,6 +323,8 @@ func TestMessageSenderService_SendPendingMessages_WebhookFailure(t *testing.T) {
 	mockWebhook.AssertExpectations(t)
 	// SetSent should NOT be called for failed messages
 	mockMsgService.AssertNotCalled(t, "SetSent")
+	// Messages are below max attempts, so they stay pending
+	mockMsgService.AssertNotCalled(t, "SetFailed")
 }
 
 func TestMessageSenderService_SendPendingMessages_PartialSuccess(t *testing.T) {
@@ -230,7 +339,7 @@ func TestMessageSenderService_SendPendingMessages_PartialSuccess(t *testing.T) {
 		{ID: 2, PhoneNumber: "+905552222222", Content: "Message 2", Status: domain.StatusPending},
 	}
 
-	mockMsgService.On("GetPendingMessages", mock.Anything, 2).Return(pendingMessages, nil)
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).Return(pendingMessages, nil)
 
 	// First message succeeds
 	mockWebhook.On("SendMessage", mock.Anything, mock.MatchedBy(func(req *webhook.SendMessageRequest) bool {
@@ -239,21 +348,289 @@ func TestMessageSenderService_SendPendingMessages_PartialSuccess(t *testing.T) {
 		Message:   "Accepted",
 		MessageID: "webhook-id-1",
 	}, nil)
-	mockMsgService.On("SetSent", mock.Anything, uint(1), "webhook-id-1").Return(nil)
+	mockMsgService.On("SetSent", mock.Anything, uint(1), mock.Anything, "webhook-id-1", "").Return(nil)
 
 	// Second message fails
 	mockWebhook.On("SendMessage", mock.Anything, mock.MatchedBy(func(req *webhook.SendMessageRequest) bool {
 		return req.To == "+905552222222"
 	})).Return(nil, errors.New("webhook error"))
+	mockMsgService.On("RecordFailedAttempt", mock.Anything, uint(2), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	err := service.SendPendingMessages(context.Background())
+	report, err := service.SendPendingMessages(context.Background())
 
 	// Should NOT error because at least one succeeded
 	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Sent)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, "webhook-id-1", report.Results[0].WebhookMessageID)
+	assert.Error(t, report.Results[1].Err)
 	mockMsgService.AssertExpectations(t)
 	mockWebhook.AssertExpectations(t)
 }
 
+func TestMessageSenderService_SendPendingMessages_Concurrent(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+	mockCache := new(MockCacheRepository)
+
+	service := NewMessageSenderService(mockMsgService, mockCache, mockWebhook, 2, false, WithConcurrency(2))
+
+	pendingMessages := []*domain.Message{
+		{ID: 1, PhoneNumber: "+905551111111", Content: "Message 1", Status: domain.StatusPending},
+		{ID: 2, PhoneNumber: "+905552222222", Content: "Message 2", Status: domain.StatusPending},
+	}
+
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).Return(pendingMessages, nil)
+
+	// Each webhook call waits until both are in flight, so a sequential sender would time out
+	var inFlight sync.WaitGroup
+	inFlight.Add(2)
+	mockWebhook.On("SendMessage", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		inFlight.Done()
+		inFlight.Wait()
+	}).Return(&webhook.SendMessageResponse{Message: "Accepted", MessageID: "webhook-id"}, nil)
+	mockMsgService.On("SetSent", mock.Anything, mock.Anything, mock.Anything, "webhook-id", "").Return(nil)
+
+	done := make(chan *SendReport)
+	go func() {
+		report, _ := service.SendPendingMessages(context.Background())
+		done <- report
+	}()
+
+	select {
+	case report := <-done:
+		assert.Equal(t, 2, report.Sent)
+	case <-time.After(2 * time.Second):
+		t.Fatal("messages were not sent in parallel")
+	}
+	mockMsgService.AssertExpectations(t)
+}
+
+func TestMessageSenderService_SendPendingMessages_CancelledContext(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+	mockCache := new(MockCacheRepository)
+
+	service := NewMessageSenderService(mockMsgService, mockCache, mockWebhook, 2, false, WithConcurrency(2))
+
+	pendingMessages := []*domain.Message{
+		{ID: 1, PhoneNumber: "+905551111111", Content: "Message 1", Status: domain.StatusPending},
+		{ID: 2, PhoneNumber: "+905552222222", Content: "Message 2", Status: domain.StatusPending},
+	}
+
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).Return(pendingMessages, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := service.SendPendingMessages(ctx)
+
+	// Cancelled messages are neither sent nor counted as failed attempts
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Skipped)
+	assert.Equal(t, 0, report.Failed)
+	mockWebhook.AssertNotCalled(t, "SendMessage")
+	mockMsgService.AssertNotCalled(t, "RecordFailedAttempt")
+}
+
+func TestMessageSenderService_SendPendingMessages_CircuitOpenSkipsCycle(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+	mockCache := new(MockCacheRepository)
+
+	breaker := circuitbreaker.NewBreaker(circuitbreaker.Config{FailureThreshold: 1, CoolDown: time.Minute})
+	breaker.Failure()
+
+	service := NewMessageSenderService(mockMsgService, mockCache, mockWebhook, 2, false, WithCircuitBreaker(breaker))
+
+	report, err := service.SendPendingMessages(context.Background())
+
+	assert.NoError(t, err)
+	assert.True(t, report.CircuitOpen)
+	assert.Equal(t, 0, report.Claimed)
+	mockMsgService.AssertNotCalled(t, "ClaimPendingMessages", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockWebhook.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+}
+
+func TestMessageSenderService_SendPendingMessages_CircuitHalfOpenClaimsProbe(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+	mockCache := new(MockCacheRepository)
+
+	breaker := circuitbreaker.NewBreaker(circuitbreaker.Config{FailureThreshold: 1, CoolDown: time.Millisecond})
+	breaker.Failure()
+	time.Sleep(5 * time.Millisecond)
+
+	service := NewMessageSenderService(mockMsgService, mockCache, mockWebhook, 2, false, WithCircuitBreaker(breaker))
+
+	pendingMessages := []*domain.Message{
+		{ID: 1, PhoneNumber: "+905551111111", Content: "Message 1", Status: domain.StatusProcessing},
+	}
+
+	// Only a single probe message is claimed while half-open
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 1, mock.Anything, mock.Anything).Return(pendingMessages, nil)
+	mockWebhook.On("SendMessage", mock.Anything, mock.Anything).Return(&webhook.SendMessageResponse{MessageID: "webhook-id-1"}, nil)
+	mockMsgService.On("SetSent", mock.Anything, uint(1), mock.Anything, "webhook-id-1", "").Return(nil)
+
+	report, err := service.SendPendingMessages(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Sent)
+	mockMsgService.AssertExpectations(t)
+}
+
+func TestMessageSenderService_SendPendingMessages_CircuitRejectionReleasesLease(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+	mockCache := new(MockCacheRepository)
+
+	service := NewMessageSenderService(mockMsgService, mockCache, mockWebhook, 2, false, WithInstanceID("instance-1"))
+
+	pendingMessages := []*domain.Message{
+		{ID: 1, PhoneNumber: "+905551111111", Content: "Message 1", Status: domain.StatusProcessing},
+	}
+
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, "instance-1", 2, mock.Anything, mock.Anything).Return(pendingMessages, nil)
+	mockWebhook.On("SendMessage", mock.Anything, mock.Anything).Return(nil, circuitbreaker.ErrOpen)
+	mockMsgService.On("ReleaseLease", mock.Anything, uint(1), "instance-1").Return(nil)
+
+	report, err := service.SendPendingMessages(context.Background())
+
+	// Rejected messages are handed back without recording a failed attempt
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Skipped)
+	mockMsgService.AssertExpectations(t)
+	mockMsgService.AssertNotCalled(t, "RecordFailedAttempt", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMessageSenderService_SendPendingMessages_LimiterUnavailableReleasesLease(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+
+	service := NewMessageSenderService(mockMsgService, nil, mockWebhook, 2, false, WithInstanceID("instance-1"))
+
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, "instance-1", 2, mock.Anything, mock.Anything).
+		Return([]*domain.Message{{ID: 1, PhoneNumber: "+905551111111", Content: "Message 1"}}, nil)
+	mockWebhook.On("SendMessage", mock.Anything, mock.Anything).Return(nil, ratelimit.ErrLimiterUnavailable.WithError(errors.New("redis down")))
+	mockMsgService.On("ReleaseLease", mock.Anything, uint(1), "instance-1").Return(nil)
+
+	report, err := service.SendPendingMessages(context.Background())
+
+	// Nothing was sent during the Redis outage, so no attempt is spent
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Skipped)
+	mockMsgService.AssertExpectations(t)
+	mockMsgService.AssertNotCalled(t, "RecordFailedAttempt", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockMsgService.AssertNotCalled(t, "SetFailed", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMessageSenderService_SendPendingMessages_ExpiredMessageNotSent(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+	mockCache := new(MockCacheRepository)
+
+	service := NewMessageSenderService(mockMsgService, mockCache, mockWebhook, 2, false)
+
+	expiredAt := time.Now().Add(-time.Minute)
+	validUntil := time.Now().Add(time.Hour)
+	pendingMessages := []*domain.Message{
+		{ID: 1, PhoneNumber: "+905551111111", Content: "Your code is 1234", Status: domain.StatusProcessing, ExpiresAt: &expiredAt},
+		{ID: 2, PhoneNumber: "+905552222222", Content: "Message 2", Status: domain.StatusProcessing, ExpiresAt: &validUntil},
+	}
+
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).Return(pendingMessages, nil)
+	mockMsgService.On("ExpireMessage", mock.Anything, uint(1), mock.Anything).Return(nil)
+	mockWebhook.On("SendMessage", mock.Anything, mock.MatchedBy(func(req *webhook.SendMessageRequest) bool {
+		return req.To == "+905552222222"
+	})).Return(&webhook.SendMessageResponse{MessageID: "webhook-id-2"}, nil)
+	mockMsgService.On("SetSent", mock.Anything, uint(2), mock.Anything, "webhook-id-2", "").Return(nil)
+
+	report, err := service.SendPendingMessages(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Expired)
+	assert.Equal(t, 1, report.Sent)
+	assert.True(t, report.Results[0].Expired)
+	mockWebhook.AssertNumberOfCalls(t, "SendMessage", 1)
+	mockMsgService.AssertExpectations(t)
+}
+
+func TestMessageSenderService_SendPendingMessages_SuppressedRecipientNotSent(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+	mockSuppressions := new(MockSuppressionRepository)
+
+	service := NewMessageSenderService(mockMsgService, nil, mockWebhook, 2, false,
+		WithSuppressionCheck(NewSuppressionService(mockSuppressions, nil)))
+
+	pendingMessages := []*domain.Message{
+		{ID: 1, PhoneNumber: "+905551111111", Content: "Message 1", Status: domain.StatusProcessing},
+		{ID: 2, PhoneNumber: "+905552222222", Content: "Message 2", Status: domain.StatusProcessing},
+	}
+
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).Return(pendingMessages, nil)
+	// The number was suppressed after the message was created
+	mockSuppressions.On("Exists", mock.Anything, "+905551111111").Return(true, nil)
+	mockSuppressions.On("Exists", mock.Anything, "+905552222222").Return(false, nil)
+	mockMsgService.On("SuppressMessage", mock.Anything, uint(1), mock.Anything).Return(nil)
+	mockWebhook.On("SendMessage", mock.Anything, mock.MatchedBy(func(req *webhook.SendMessageRequest) bool {
+		return req.To == "+905552222222"
+	})).Return(&webhook.SendMessageResponse{MessageID: "webhook-id-2"}, nil)
+	mockMsgService.On("SetSent", mock.Anything, uint(2), mock.Anything, "webhook-id-2", "").Return(nil)
+
+	report, err := service.SendPendingMessages(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Suppressed)
+	assert.Equal(t, 1, report.Sent)
+	assert.True(t, report.Results[0].Suppressed)
+	mockWebhook.AssertNumberOfCalls(t, "SendMessage", 1)
+	mockMsgService.AssertExpectations(t)
+}
+
+func TestMessageSenderService_SendPendingMessages_KeywordReplySkipsSuppression(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+	mockSuppressions := new(MockSuppressionRepository)
+
+	service := NewMessageSenderService(mockMsgService, nil, mockWebhook, 1, false,
+		WithSuppressionCheck(NewSuppressionService(mockSuppressions, nil)))
+
+	// A STOP confirmation goes to the number it just suppressed
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 1, mock.Anything, mock.Anything).
+		Return([]*domain.Message{{ID: 1, PhoneNumber: "+905551111111", Content: "You are unsubscribed", KeywordReply: true}}, nil)
+	mockWebhook.On("SendMessage", mock.Anything, mock.Anything).Return(&webhook.SendMessageResponse{MessageID: "webhook-id-1"}, nil)
+	mockMsgService.On("SetSent", mock.Anything, uint(1), mock.Anything, "webhook-id-1", "").Return(nil)
+
+	report, err := service.SendPendingMessages(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Sent)
+	mockSuppressions.AssertNotCalled(t, "Exists", mock.Anything, mock.Anything)
+}
+
+func TestMessageSenderService_SendPendingMessages_SuppressionLookupFailure(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+	mockSuppressions := new(MockSuppressionRepository)
+
+	service := NewMessageSenderService(mockMsgService, nil, mockWebhook, 2, false,
+		WithInstanceID("instance-1"), WithSuppressionCheck(NewSuppressionService(mockSuppressions, nil)))
+
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).
+		Return([]*domain.Message{{ID: 1, PhoneNumber: "+905551111111", Content: "Message 1"}}, nil)
+	mockSuppressions.On("Exists", mock.Anything, "+905551111111").Return(false, errors.New("db error"))
+	// Never sent without knowing, handed back without spending an attempt
+	mockMsgService.On("ReleaseLease", mock.Anything, uint(1), "instance-1").Return(nil)
+
+	report, err := service.SendPendingMessages(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Skipped)
+	mockWebhook.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+	mockMsgService.AssertExpectations(t)
+}
+
 func TestMessageSenderService_SendPendingMessages_SetSentFailure(t *testing.T) {
 	mockMsgService := new(MockMessageService)
 	mockWebhook := new(MockWebhookClient)
@@ -265,7 +642,7 @@ func TestMessageSenderService_SendPendingMessages_SetSentFailure(t *testing.T) {
 		{ID: 1, PhoneNumber: "+905551111111", Content: "Message 1", Status: domain.StatusPending},
 	}
 
-	mockMsgService.On("GetPendingMessages", mock.Anything, 2).Return(pendingMessages, nil)
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).Return(pendingMessages, nil)
 
 	mockWebhook.On("SendMessage", mock.Anything, mock.Anything).Return(&webhook.SendMessageResponse{
 		Message:   "Accepted",
@@ -273,9 +650,9 @@ func TestMessageSenderService_SendPendingMessages_SetSentFailure(t *testing.T) {
 	}, nil)
 
 	// SetSent fails
-	mockMsgService.On("SetSent", mock.Anything, uint(1), "webhook-id-1").Return(errors.New("db error"))
+	mockMsgService.On("SetSent", mock.Anything, uint(1), mock.Anything, "webhook-id-1", "").Return(errors.New("db error"))
 
-	err := service.SendPendingMessages(context.Background())
+	_, err := service.SendPendingMessages(context.Background())
 
 	// Should return error because SetSent failed
 	assert.Error(t, err)
@@ -295,14 +672,14 @@ func TestMessageSenderService_SendPendingMessages_CacheDisabled(t *testing.T) {
 		{ID: 1, PhoneNumber: "+905551111111", Content: "Message 1", Status: domain.StatusPending},
 	}
 
-	mockMsgService.On("GetPendingMessages", mock.Anything, 2).Return(pendingMessages, nil)
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).Return(pendingMessages, nil)
 	mockWebhook.On("SendMessage", mock.Anything, mock.Anything).Return(&webhook.SendMessageResponse{
 		Message:   "Accepted",
 		MessageID: "webhook-id-1",
 	}, nil)
-	mockMsgService.On("SetSent", mock.Anything, uint(1), "webhook-id-1").Return(nil)
+	mockMsgService.On("SetSent", mock.Anything, uint(1), mock.Anything, "webhook-id-1", "").Return(nil)
 
-	err := service.SendPendingMessages(context.Background())
+	_, err := service.SendPendingMessages(context.Background())
 
 	assert.NoError(t, err)
 	mockMsgService.AssertExpectations(t)
@@ -322,17 +699,17 @@ func TestMessageSenderService_SendPendingMessages_CacheFailureNonBlocking(t *tes
 		{ID: 1, PhoneNumber: "+905551111111", Content: "Message 1", Status: domain.StatusPending},
 	}
 
-	mockMsgService.On("GetPendingMessages", mock.Anything, 2).Return(pendingMessages, nil)
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).Return(pendingMessages, nil)
 	mockWebhook.On("SendMessage", mock.Anything, mock.Anything).Return(&webhook.SendMessageResponse{
 		Message:   "Accepted",
 		MessageID: "webhook-id-1",
 	}, nil)
-	mockMsgService.On("SetSent", mock.Anything, uint(1), "webhook-id-1").Return(nil)
+	mockMsgService.On("SetSent", mock.Anything, uint(1), mock.Anything, "webhook-id-1", "").Return(nil)
 
 	// Cache fails but should not block operation
-	mockCache.On("CacheSentMessage", mock.Anything, "webhook-id-1", mock.Anything).Return(errors.New("redis error"))
+	mockCache.On("CacheSentMessage", mock.Anything, "webhook-id-1", uint(1), mock.Anything).Return(errors.New("redis error"))
 
-	err := service.SendPendingMessages(context.Background())
+	_, err := service.SendPendingMessages(context.Background())
 
 	// Should still succeed even if cache fails
 	assert.NoError(t, err)
@@ -341,6 +718,200 @@ func TestMessageSenderService_SendPendingMessages_CacheFailureNonBlocking(t *tes
 	mockCache.AssertExpectations(t)
 }
 
+func TestMessageSenderService_SendPendingMessages_MaxAttemptsReached(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+	mockCache := new(MockCacheRepository)
+
+	service := NewMessageSenderService(mockMsgService, mockCache, mockWebhook, 2, false, WithMaxAttempts(3))
+
+	pendingMessages := []*domain.Message{
+		{ID: 1, PhoneNumber: "+905551111111", Content: "Message 1", Status: domain.StatusPending, AttemptCount: 2},
+	}
+
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).Return(pendingMessages, nil)
+	mockWebhook.On("SendMessage", mock.Anything, mock.Anything).Return(nil, webhook.ErrInvalidRequest)
+	mockMsgService.On("SetFailed", mock.Anything, uint(1), mock.Anything, webhook.ErrCodeWebhookInvalidRequest, webhook.ErrInvalidRequest.Error()).Return(nil)
+
+	_, err := service.SendPendingMessages(context.Background())
+
+	assert.Error(t, err)
+	mockMsgService.AssertExpectations(t)
+	mockWebhook.AssertExpectations(t)
+	mockMsgService.AssertNotCalled(t, "RecordFailedAttempt")
+}
+
+func TestMessageSenderService_SendPendingMessages_BackoffSchedulesNextAttempt(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+	mockCache := new(MockCacheRepository)
+
+	policy := backoff.Policy{Base: time.Minute, Max: time.Hour, Multiplier: 2}
+	service := NewMessageSenderService(mockMsgService, mockCache, mockWebhook, 2, false, WithBackoff(policy))
+
+	pendingMessages := []*domain.Message{
+		{ID: 1, PhoneNumber: "+905551111111", Content: "Message 1", Status: domain.StatusPending, AttemptCount: 2},
+	}
+
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).Return(pendingMessages, nil)
+	mockWebhook.On("SendMessage", mock.Anything, mock.Anything).Return(nil, errors.New("webhook error"))
+
+	// Third attempt failed: base * multiplier^2 = 4 minutes
+	start := time.Now()
+	mockMsgService.On("RecordFailedAttempt", mock.Anything, uint(1), mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(next time.Time) bool {
+		delay := next.Sub(start)
+		return delay >= 4*time.Minute && delay < 4*time.Minute+time.Second
+	})).Return(nil)
+
+	_, err := service.SendPendingMessages(context.Background())
+
+	assert.Error(t, err)
+	mockMsgService.AssertExpectations(t)
+}
+
+func TestMessageSenderService_SendPendingMessages_RecordAttemptFailure(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+	mockCache := new(MockCacheRepository)
+
+	service := NewMessageSenderService(mockMsgService, mockCache, mockWebhook, 2, false)
+
+	pendingMessages := []*domain.Message{
+		{ID: 1, PhoneNumber: "+905551111111", Content: "Message 1", Status: domain.StatusPending},
+	}
+
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).Return(pendingMessages, nil)
+	mockWebhook.On("SendMessage", mock.Anything, mock.Anything).Return(nil, errors.New("webhook error"))
+	mockMsgService.On("RecordFailedAttempt", mock.Anything, uint(1), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db error"))
+
+	_, err := service.SendPendingMessages(context.Background())
+
+	assert.Error(t, err)
+	mockMsgService.AssertExpectations(t)
+}
+
+func TestMessageSenderService_SendPendingMessages_SetFailedFailure(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+	mockCache := new(MockCacheRepository)
+
+	service := NewMessageSenderService(mockMsgService, mockCache, mockWebhook, 2, false, WithMaxAttempts(1))
+
+	pendingMessages := []*domain.Message{
+		{ID: 1, PhoneNumber: "+905551111111", Content: "Message 1", Status: domain.StatusPending},
+	}
+
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).Return(pendingMessages, nil)
+	mockWebhook.On("SendMessage", mock.Anything, mock.Anything).Return(nil, errors.New("webhook error"))
+	mockMsgService.On("SetFailed", mock.Anything, uint(1), mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db error"))
+
+	_, err := service.SendPendingMessages(context.Background())
+
+	assert.Error(t, err)
+	mockMsgService.AssertExpectations(t)
+}
+
+func TestMessageSenderService_SendPendingMessages_ReservesPriorityShares(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+	mockCache := new(MockCacheRepository)
+
+	shares := map[domain.MessagePriority]int{domain.PriorityHigh: 60, domain.PriorityNormal: 30, domain.PriorityLow: 10}
+	service := NewMessageSenderService(mockMsgService, mockCache, mockWebhook, 10, false, WithPriorityShares(shares))
+
+	reserved := map[domain.MessagePriority]int{domain.PriorityHigh: 6, domain.PriorityNormal: 3, domain.PriorityLow: 1}
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 10, reserved, mock.Anything).Return([]*domain.Message{}, nil)
+
+	_, err := service.SendPendingMessages(context.Background())
+
+	assert.NoError(t, err)
+	mockMsgService.AssertExpectations(t)
+}
+
+func TestReserveLanes(t *testing.T) {
+	shares := map[domain.MessagePriority]int{domain.PriorityHigh: 60, domain.PriorityNormal: 30, domain.PriorityLow: 10}
+
+	tests := []struct {
+		name     string
+		limit    int
+		shares   map[domain.MessagePriority]int
+		expected map[domain.MessagePriority]int
+	}{
+		{
+			name:     "no shares reserves nothing",
+			limit:    10,
+			shares:   nil,
+			expected: nil,
+		},
+		{
+			name:     "exact split",
+			limit:    10,
+			shares:   shares,
+			expected: map[domain.MessagePriority]int{domain.PriorityHigh: 6, domain.PriorityNormal: 3, domain.PriorityLow: 1},
+		},
+		{
+			name:     "leftover goes to largest remainder",
+			limit:    2,
+			shares:   shares,
+			expected: map[domain.MessagePriority]int{domain.PriorityHigh: 1, domain.PriorityNormal: 1, domain.PriorityLow: 0},
+		},
+		{
+			name:     "every lane gets a slot when the batch has room",
+			limit:    3,
+			shares:   shares,
+			expected: map[domain.MessagePriority]int{domain.PriorityHigh: 1, domain.PriorityNormal: 1, domain.PriorityLow: 1},
+		},
+		{
+			name:     "smallest share takes a slot from the largest",
+			limit:    4,
+			shares:   shares,
+			expected: map[domain.MessagePriority]int{domain.PriorityHigh: 2, domain.PriorityNormal: 1, domain.PriorityLow: 1},
+		},
+		{
+			name:     "batch of two keeps both lanes",
+			limit:    2,
+			shares:   map[domain.MessagePriority]int{domain.PriorityHigh: 80, domain.PriorityLow: 20},
+			expected: map[domain.MessagePriority]int{domain.PriorityHigh: 1, domain.PriorityNormal: 0, domain.PriorityLow: 1},
+		},
+		{
+			name:     "small shares get a slot outside the covered part",
+			limit:    2,
+			shares:   map[domain.MessagePriority]int{domain.PriorityNormal: 25, domain.PriorityLow: 25},
+			expected: map[domain.MessagePriority]int{domain.PriorityHigh: 0, domain.PriorityNormal: 1, domain.PriorityLow: 1},
+		},
+		{
+			name:     "single slot goes to the largest share",
+			limit:    1,
+			shares:   shares,
+			expected: map[domain.MessagePriority]int{domain.PriorityHigh: 1, domain.PriorityNormal: 0, domain.PriorityLow: 0},
+		},
+		{
+			name:     "unreserved part of the batch stays free",
+			limit:    4,
+			shares:   map[domain.MessagePriority]int{domain.PriorityLow: 25},
+			expected: map[domain.MessagePriority]int{domain.PriorityHigh: 0, domain.PriorityNormal: 0, domain.PriorityLow: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, reserveLanes(tt.limit, tt.shares))
+		})
+	}
+}
+
+func TestNewMessageSenderService_DefaultMaxAttempts(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+	mockCache := new(MockCacheRepository)
+
+	service := NewMessageSenderService(mockMsgService, mockCache, mockWebhook, 2, false, WithMaxAttempts(0))
+
+	svc, ok := service.(*messageSenderService)
+	assert.True(t, ok)
+	assert.Equal(t, defaultMaxAttempts, svc.maxAttempts)
+}
+
 func TestNewMessageSenderService_DefaultBatchSize(t *testing.T) {
 	mockMsgService := new(MockMessageService)
 	mockWebhook := new(MockWebhookClient)
@@ -381,3 +952,285 @@ func TestMessageSenderService_InterfaceCompliance(t *testing.T) {
 	service := NewMessageSenderService(mockMsgService, mockCache, mockWebhook, 2, false)
 	assert.NotNil(t, service)
 }
+
+// MockSendIntentRepository mocks SendIntentRepository interface
+type MockSendIntentRepository struct {
+	mock.Mock
+}
+
+func (m *MockSendIntentRepository) Begin(ctx context.Context, intent *domain.SendIntent) error {
+	args := m.Called(ctx, intent)
+	return args.Error(0)
+}
+
+func (m *MockSendIntentRepository) Resolve(ctx context.Context, messageID uint, status domain.SendIntentStatus) error {
+	args := m.Called(ctx, messageID, status)
+	return args.Error(0)
+}
+
+func (m *MockSendIntentRepository) ListInFlight(ctx context.Context, startedBefore time.Time, limit int) ([]*domain.SendIntent, error) {
+	args := m.Called(ctx, startedBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SendIntent), args.Error(1)
+}
+
+func TestMessageSenderService_SendPendingMessages_RecordsSendIntent(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+	mockIntents := new(MockSendIntentRepository)
+
+	service := NewMessageSenderService(mockMsgService, nil, mockWebhook, 2, false,
+		WithInstanceID("instance-1"), WithSendIntents(mockIntents))
+
+	createdAt := time.Date(2025, 11, 9, 10, 0, 0, 0, time.UTC)
+	msg := &domain.Message{ID: 7, PhoneNumber: "+905551111111", Content: "Hello", AttemptCount: 1, CreatedAt: createdAt}
+	key := "msg-7-" + strconv.FormatInt(createdAt.UnixMicro(), 10)
+
+	mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).Return([]*domain.Message{msg}, nil)
+	mockIntents.On("Begin", mock.Anything, mock.MatchedBy(func(intent *domain.SendIntent) bool {
+		return intent.MessageID == 7 && intent.IdempotencyKey == key && intent.Attempt == 2 && intent.InstanceID == "instance-1"
+	})).Return(nil).Once()
+	mockWebhook.On("SendMessage", mock.Anything, mock.MatchedBy(func(req *webhook.SendMessageRequest) bool {
+		return req.IdempotencyKey == key
+	})).Return(&webhook.SendMessageResponse{MessageID: "webhook-id-7"}, nil)
+	mockMsgService.On("SetSent", mock.Anything, uint(7), mock.Anything, "webhook-id-7", "").Return(nil)
+	mockIntents.On("Resolve", mock.Anything, uint(7), domain.IntentSent).Return(nil).Once()
+
+	report, err := service.SendPendingMessages(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Sent)
+	mockMsgService.AssertExpectations(t)
+	mockWebhook.AssertExpectations(t)
+	mockIntents.AssertExpectations(t)
+}
+
+func TestMessageSenderService_SendPendingMessages_CancelledDuringCall(t *testing.T) {
+	tests := []struct {
+		name      string
+		sendErr   error
+		abandoned bool
+	}{
+		{
+			name:      "cancelled while waiting for a rate limit token closes the intent and releases the message",
+			sendErr:   ratelimit.ErrWaitCancelled.WithError(context.Canceled),
+			abandoned: true,
+		},
+		{
+			name:    "cancelled during the call keeps the intent in flight",
+			sendErr: context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMsgService := new(MockMessageService)
+			mockWebhook := new(MockWebhookClient)
+			mockIntents := new(MockSendIntentRepository)
+
+			service := NewMessageSenderService(mockMsgService, nil, mockWebhook, 2, false,
+				WithInstanceID("instance-1"), WithSendIntents(mockIntents))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).
+				Return([]*domain.Message{{ID: 1, PhoneNumber: "+905551111111", Content: "Hello"}}, nil)
+			mockIntents.On("Begin", mock.Anything, mock.Anything).Return(nil)
+			mockWebhook.On("SendMessage", mock.Anything, mock.Anything).Run(func(mock.Arguments) { cancel() }).Return(nil, tt.sendErr)
+			if tt.abandoned {
+				mockIntents.On("Resolve", mock.Anything, uint(1), domain.IntentFailed).Return(nil)
+				mockMsgService.On("ReleaseLease", mock.Anything, uint(1), "instance-1").Return(nil)
+			}
+
+			report, err := service.SendPendingMessages(ctx)
+
+			assert.NoError(t, err)
+			assert.Equal(t, 1, report.Skipped)
+			mockMsgService.AssertExpectations(t)
+			mockIntents.AssertExpectations(t)
+			if !tt.abandoned {
+				mockIntents.AssertNotCalled(t, "Resolve", mock.Anything, mock.Anything, mock.Anything)
+				mockMsgService.AssertNotCalled(t, "ReleaseLease", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestMessageSenderService_SendPendingMessages_SendIntentOutcomes(t *testing.T) {
+	tests := []struct {
+		name      string
+		mockSetup func(*MockMessageService, *MockWebhookClient, *MockSendIntentRepository)
+	}{
+		{
+			name: "webhook failure resolves the intent as failed",
+			mockSetup: func(ms *MockMessageService, wh *MockWebhookClient, in *MockSendIntentRepository) {
+				in.On("Begin", mock.Anything, mock.Anything).Return(nil)
+				wh.On("SendMessage", mock.Anything, mock.Anything).Return(nil, webhook.ErrInvalidRequest)
+				ms.On("RecordFailedAttempt", mock.Anything, uint(1), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				in.On("Resolve", mock.Anything, uint(1), domain.IntentFailed).Return(nil)
+			},
+		},
+		{
+			name: "unrecorded outcome keeps the intent in flight",
+			mockSetup: func(ms *MockMessageService, wh *MockWebhookClient, in *MockSendIntentRepository) {
+				in.On("Begin", mock.Anything, mock.Anything).Return(nil)
+				wh.On("SendMessage", mock.Anything, mock.Anything).Return(&webhook.SendMessageResponse{MessageID: "webhook-id-1"}, nil)
+				ms.On("SetSent", mock.Anything, uint(1), mock.Anything, "webhook-id-1", "").Return(errors.New("db error"))
+			},
+		},
+		{
+			name: "unrecorded intent hands the message back without calling the webhook",
+			mockSetup: func(ms *MockMessageService, wh *MockWebhookClient, in *MockSendIntentRepository) {
+				in.On("Begin", mock.Anything, mock.Anything).Return(errors.New("db error"))
+				ms.On("ReleaseLease", mock.Anything, uint(1), mock.Anything).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMsgService := new(MockMessageService)
+			mockWebhook := new(MockWebhookClient)
+			mockIntents := new(MockSendIntentRepository)
+
+			service := NewMessageSenderService(mockMsgService, nil, mockWebhook, 2, false, WithSendIntents(mockIntents))
+
+			mockMsgService.On("ClaimPendingMessages", mock.Anything, mock.Anything, 2, mock.Anything, mock.Anything).
+				Return([]*domain.Message{{ID: 1, PhoneNumber: "+905551111111", Content: "Hello"}}, nil)
+			tt.mockSetup(mockMsgService, mockWebhook, mockIntents)
+
+			report, err := service.SendPendingMessages(context.Background())
+
+			assert.Error(t, err)
+			assert.Equal(t, 1, report.Failed)
+			mockMsgService.AssertExpectations(t)
+			mockWebhook.AssertExpectations(t)
+			mockIntents.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageSenderService_RecoverSendIntents(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+	mockIntents := new(MockSendIntentRepository)
+
+	service := NewMessageSenderService(mockMsgService, nil, mockWebhook, 2, false,
+		WithInstanceID("instance-1"), WithLeaseDuration(time.Minute), WithSendIntents(mockIntents))
+
+	sentAt := time.Now()
+	mockIntents.On("ListInFlight", mock.Anything, mock.Anything, maxRecoveredIntents).Return([]*domain.SendIntent{
+		{MessageID: 1, Attempt: 1, InstanceID: "instance-0"},
+		{MessageID: 2, Attempt: 1, InstanceID: "instance-0"},
+		{MessageID: 3, Attempt: 1, InstanceID: "instance-2"},
+		{MessageID: 4, Attempt: 1, InstanceID: "instance-0"},
+		{MessageID: 5, Attempt: 2, InstanceID: "instance-0"},
+	}, nil)
+
+	// Message 1 was left by a crashed instance: the call may have reached the provider, so it is held for review
+	mockMsgService.On("GetByID", mock.Anything, uint(1)).Return(&domain.Message{ID: 1, Status: domain.StatusProcessing}, nil)
+	mockMsgService.On("MarkUnknown", mock.Anything, uint(1), "instance-1").Return(true, nil)
+	mockIntents.On("Resolve", mock.Anything, uint(1), domain.IntentUnknown).Return(nil)
+
+	// Message 2 was marked as sent before the crash: only the intent is closed
+	mockMsgService.On("GetByID", mock.Anything, uint(2)).Return(&domain.Message{ID: 2, Status: domain.StatusDelivered, SentAt: &sentAt}, nil)
+	mockIntents.On("Resolve", mock.Anything, uint(2), domain.IntentSent).Return(nil)
+
+	// Message 3 is being sent by another running instance, which resolves its own call
+	mockMsgService.On("GetByID", mock.Anything, uint(3)).Return(&domain.Message{ID: 3, Status: domain.StatusProcessing}, nil)
+	mockMsgService.On("MarkUnknown", mock.Anything, uint(3), "instance-1").Return(false, nil)
+
+	// Message 4 was deleted
+	mockMsgService.On("GetByID", mock.Anything, uint(4)).Return(nil, apperror.ErrMessageNotFound)
+	mockIntents.On("Resolve", mock.Anything, uint(4), domain.IntentClosed).Return(nil)
+
+	// Message 5 was released to pending before its call was resolved, it is held as well
+	mockMsgService.On("GetByID", mock.Anything, uint(5)).Return(&domain.Message{ID: 5, Status: domain.StatusPending}, nil)
+	mockMsgService.On("MarkUnknown", mock.Anything, uint(5), "instance-1").Return(true, nil)
+	mockIntents.On("Resolve", mock.Anything, uint(5), domain.IntentUnknown).Return(nil)
+
+	report, err := service.RecoverSendIntents(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, &RecoveryReport{InFlight: 5, Closed: 2, Held: 1, Unknown: 2}, report)
+	mockMsgService.AssertExpectations(t)
+	mockIntents.AssertExpectations(t)
+	mockIntents.AssertNotCalled(t, "Resolve", mock.Anything, uint(3), mock.Anything)
+	// Nothing is sent again without proof the first call never reached the provider
+	mockWebhook.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+}
+
+func TestMessageSenderService_ReconcileSendIntents(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockWebhook := new(MockWebhookClient)
+	mockIntents := new(MockSendIntentRepository)
+
+	service := NewMessageSenderService(mockMsgService, nil, mockWebhook, 2, false,
+		WithInstanceID("instance-1"), WithLeaseDuration(5*time.Minute), WithSendIntents(mockIntents))
+
+	// Only calls older than the lease are listed, younger ones may still be running
+	mockIntents.On("ListInFlight", mock.Anything, mock.MatchedBy(func(startedBefore time.Time) bool {
+		return startedBefore.Before(time.Now().Add(-4*time.Minute)) && startedBefore.After(time.Now().Add(-6*time.Minute))
+	}), maxRecoveredIntents).Return([]*domain.SendIntent{
+		{MessageID: 1, Attempt: 1, InstanceID: "instance-0"},
+		{MessageID: 2, Attempt: 1, InstanceID: "instance-1"},
+	}, nil)
+
+	// Message 1 was left by an instance that restarted under another ID
+	mockMsgService.On("GetByID", mock.Anything, uint(1)).Return(&domain.Message{ID: 1, Status: domain.StatusProcessing}, nil)
+	mockMsgService.On("MarkUnknown", mock.Anything, uint(1), "").Return(true, nil)
+	mockIntents.On("Resolve", mock.Anything, uint(1), domain.IntentUnknown).Return(nil)
+
+	// Message 2 is still leased, a scheduled run passes no owner so only an expired lease lets it be held
+	mockMsgService.On("GetByID", mock.Anything, uint(2)).Return(&domain.Message{ID: 2, Status: domain.StatusProcessing}, nil)
+	mockMsgService.On("MarkUnknown", mock.Anything, uint(2), "").Return(false, nil)
+
+	report, err := service.ReconcileSendIntents(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, &RecoveryReport{InFlight: 2, Held: 1, Unknown: 1}, report)
+	mockMsgService.AssertExpectations(t)
+	mockIntents.AssertExpectations(t)
+	mockWebhook.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+}
+
+func TestMessageSenderService_RecoverSendIntents_MarkUnknownError(t *testing.T) {
+	mockMsgService := new(MockMessageService)
+	mockIntents := new(MockSendIntentRepository)
+	service := NewMessageSenderService(mockMsgService, nil, new(MockWebhookClient), 2, false,
+		WithInstanceID("instance-1"), WithSendIntents(mockIntents))
+
+	mockIntents.On("ListInFlight", mock.Anything, mock.Anything, maxRecoveredIntents).Return([]*domain.SendIntent{{MessageID: 1}}, nil)
+	mockMsgService.On("GetByID", mock.Anything, uint(1)).Return(&domain.Message{ID: 1, Status: domain.StatusProcessing}, nil)
+	mockMsgService.On("MarkUnknown", mock.Anything, uint(1), "instance-1").Return(false, apperror.ErrMessageMarkUnknownFailed)
+
+	report, err := service.RecoverSendIntents(context.Background())
+
+	assert.Contains(t, err.Error(), apperror.ErrCodeSendIntentRecoveryFailed)
+	assert.Equal(t, 1, report.InFlight)
+	mockIntents.AssertNotCalled(t, "Resolve", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMessageSenderService_RecoverSendIntents_Disabled(t *testing.T) {
+	service := NewMessageSenderService(new(MockMessageService), nil, new(MockWebhookClient), 2, false)
+
+	report, err := service.RecoverSendIntents(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, &RecoveryReport{}, report)
+}
+
+func TestMessageSenderService_RecoverSendIntents_ListError(t *testing.T) {
+	mockIntents := new(MockSendIntentRepository)
+	service := NewMessageSenderService(new(MockMessageService), nil, new(MockWebhookClient), 2, false, WithSendIntents(mockIntents))
+
+	mockIntents.On("ListInFlight", mock.Anything, mock.Anything, maxRecoveredIntents).Return(nil, errors.New("db error"))
+
+	report, err := service.RecoverSendIntents(context.Background())
+
+	assert.Nil(t, report)
+	assert.Contains(t, err.Error(), apperror.ErrCodeSendIntentRecoveryFailed)
+}
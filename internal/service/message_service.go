@@ -3,47 +3,165 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/srcndev/message-service/internal/apperror"
 	"github.com/srcndev/message-service/internal/domain"
 	"github.com/srcndev/message-service/internal/dto"
 	"github.com/srcndev/message-service/internal/repository"
+	"github.com/srcndev/message-service/pkg/customerror"
+	"github.com/srcndev/message-service/pkg/smscontent"
 	"gorm.io/gorm"
 )
 
 // MessageService defines the business logic interface for messages
 type MessageService interface {
 	Create(ctx context.Context, req dto.CreateMessageRequest) (*domain.Message, error)
+	CreateBatch(ctx context.Context, reqs []dto.CreateMessageRequest) ([]BatchResult, error)
+	CreateReply(ctx context.Context, phoneNumber, content string) (*domain.Message, error)
 	GetByID(ctx context.Context, id uint) (*domain.Message, error)
-	List(ctx context.Context, limit, offset int) ([]*domain.Message, error)
+	List(ctx context.Context, filter domain.MessageFilter, limit, offset int) ([]*domain.Message, error)
+	ListPage(ctx context.Context, filter domain.MessageFilter, page domain.MessagePageQuery) (*domain.MessagePage, error)
 	ListSentMessages(ctx context.Context, limit, offset int) ([]*domain.Message, error)
+	ListFailedMessages(ctx context.Context, limit, offset int) ([]*domain.Message, error)
+	Export(ctx context.Context, filter domain.MessageFilter, fn func(*domain.Message) error) error
 	GetPendingMessages(ctx context.Context, limit int) ([]*domain.Message, error)
-	SetSent(ctx context.Context, id uint, messageID string) error
+	ClaimPendingMessages(ctx context.Context, owner string, limit int, reserved map[domain.MessagePriority]int, leaseDuration time.Duration) ([]*domain.Message, error)
+	MarkUnknown(ctx context.Context, id uint, owner string) (bool, error)
+	ReleaseExpiredLeases(ctx context.Context) (int64, error)
+	ReleaseLease(ctx context.Context, id uint, owner string) error
+	ExpireMessage(ctx context.Context, id uint, owner string) error
+	SuppressMessage(ctx context.Context, id uint, owner string) error
+	ExpirePendingMessages(ctx context.Context) (int64, error)
+	SetSent(ctx context.Context, id uint, owner, messageID, provider string) error
+	RecordFailedAttempt(ctx context.Context, id uint, owner, errCode, errMessage string, nextAttemptAt time.Time) error
+	SetFailed(ctx context.Context, id uint, owner, errCode, errMessage string) error
 	Update(ctx context.Context, id uint, req dto.UpdateMessageRequest) (*domain.Message, error)
 	Delete(ctx context.Context, id uint) error
 }
 
+// BatchResult is the outcome of one item of a batch create, either the created message or the error
+type BatchResult struct {
+	Message *domain.Message
+	Err     error
+}
+
+// sendAtTolerance accepts a sendAt slightly in the past to absorb client clock skew and request latency
+const sendAtTolerance = 1 * time.Minute
+
+// defaultMaxSegments is the longest message accepted, in SMS segments, when no limit is configured
+const defaultMaxSegments = 6
+
 type messageService struct {
-	repo repository.MessageRepository
+	repo         repository.MessageRepository
+	templates    TemplateService
+	suppressions SuppressionService
+	autoSuppress bool
+	maxSegments  int
 }
 
 // Compile-time interface compliance check
 var _ MessageService = (*messageService)(nil)
 
+// MessageServiceOption configures optional message service behavior
+type MessageServiceOption func(*messageService)
+
+// WithMaxSegments sets the longest message accepted, in SMS segments
+func WithMaxSegments(maxSegments int) MessageServiceOption {
+	return func(s *messageService) {
+		if maxSegments > 0 {
+			s.maxSegments = maxSegments
+		}
+	}
+}
+
+// WithTemplates lets messages be created from a template ID and variables
+func WithTemplates(templates TemplateService) MessageServiceOption {
+	return func(s *messageService) {
+		s.templates = templates
+	}
+}
+
+// WithSuppressions checks recipients against the suppression list. Messages to a suppressed number are
+// rejected, or stored as suppressed and never sent when autoSuppress is set.
+func WithSuppressions(suppressions SuppressionService, autoSuppress bool) MessageServiceOption {
+	return func(s *messageService) {
+		s.suppressions = suppressions
+		s.autoSuppress = autoSuppress
+	}
+}
+
 // NewMessageService creates a new message service
-func NewMessageService(repo repository.MessageRepository) MessageService {
-	return &messageService{
-		repo: repo,
+func NewMessageService(repo repository.MessageRepository, opts ...MessageServiceOption) MessageService {
+	s := &messageService{
+		repo:        repo,
+		maxSegments: defaultMaxSegments,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 // Create creates a new message
 func (s *messageService) Create(ctx context.Context, req dto.CreateMessageRequest) (*domain.Message, error) {
+	message, err := s.buildMessage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, message); err != nil {
+		return nil, apperror.ErrMessageCreateFailed.WithError(err)
+	}
+
+	return message, nil
+}
+
+// CreateBatch validates every item on its own and inserts the valid ones in a single transaction.
+// Results are in request order, an item either carries the created message or the reason it was rejected.
+func (s *messageService) CreateBatch(ctx context.Context, reqs []dto.CreateMessageRequest) ([]BatchResult, error) {
+	results := make([]BatchResult, len(reqs))
+	messages := make([]*domain.Message, 0, len(reqs))
+	for i, req := range reqs {
+		message, err := s.buildMessage(ctx, req)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Message = message
+		messages = append(messages, message)
+	}
+
+	if len(messages) == 0 {
+		return results, nil
+	}
+
+	if err := s.repo.CreateBatch(ctx, messages); err != nil {
+		return nil, apperror.ErrMessageCreateFailed.WithError(err)
+	}
+
+	return results, nil
+}
+
+// CreateReply queues a high priority auto-reply to an inbound keyword. The recipient just wrote to us,
+// so the reply skips the suppression list, a STOP confirmation goes to a number suppressed a moment ago.
+func (s *messageService) CreateReply(ctx context.Context, phoneNumber, content string) (*domain.Message, error) {
+	info, err := s.analyzeContent(content)
+	if err != nil {
+		return nil, err
+	}
+
 	message := &domain.Message{
-		PhoneNumber: req.PhoneNumber,
-		Content:     req.Content,
-		Status:      domain.StatusPending,
+		PhoneNumber:  phoneNumber,
+		Content:      content,
+		Encoding:     string(info.Encoding),
+		Segments:     info.Segments,
+		Status:       domain.StatusPending,
+		Priority:     domain.PriorityHigh,
+		KeywordReply: true,
 	}
 
 	if err := s.repo.Create(ctx, message); err != nil {
@@ -53,6 +171,85 @@ func (s *messageService) Create(ctx context.Context, req dto.CreateMessageReques
 	return message, nil
 }
 
+// buildMessage validates the request and builds the pending message it describes
+func (s *messageService) buildMessage(ctx context.Context, req dto.CreateMessageRequest) (*domain.Message, error) {
+	if err := validateSendAt(req.SendAt); err != nil {
+		return nil, err
+	}
+
+	expiresAt, err := resolveExpiresAt(req)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.resolveContent(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := s.analyzeContent(content)
+	if err != nil {
+		return nil, err
+	}
+
+	priority := req.Priority
+	if priority == "" {
+		priority = domain.PriorityNormal
+	}
+
+	message := &domain.Message{
+		PhoneNumber: req.PhoneNumber,
+		Content:     content,
+		TemplateID:  req.TemplateID,
+		Encoding:    string(info.Encoding),
+		Segments:    info.Segments,
+		Status:      domain.StatusPending,
+		Priority:    priority,
+		SendAt:      req.SendAt,
+		ExpiresAt:   expiresAt,
+	}
+
+	if err := s.checkSuppression(ctx, message); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// checkSuppression rejects a message to a suppressed number, or marks it as suppressed in auto-suppress mode
+func (s *messageService) checkSuppression(ctx context.Context, message *domain.Message) error {
+	if s.suppressions == nil {
+		return nil
+	}
+
+	suppressed, err := s.suppressions.IsSuppressed(ctx, message.PhoneNumber)
+	if err != nil {
+		return err
+	}
+	if !suppressed {
+		return nil
+	}
+	if !s.autoSuppress {
+		return apperror.ErrRecipientSuppressed
+	}
+
+	markSuppressed(message)
+	return nil
+}
+
+// markSuppressed moves a message to the suppressed state with the reason as its last error
+func markSuppressed(message *domain.Message) {
+	errCode := apperror.ErrCodeRecipientSuppressed
+	errMessage := apperror.MsgRecipientSuppressed
+
+	message.Status = domain.StatusSuppressed
+	message.LastErrorCode = &errCode
+	message.LastErrorMessage = &errMessage
+	message.NextAttemptAt = nil
+	message.LeaseOwner = nil
+	message.LeaseExpiresAt = nil
+}
+
 // GetByID retrieves a message by ID
 func (s *messageService) GetByID(ctx context.Context, id uint) (*domain.Message, error) {
 	message, err := s.repo.GetByID(ctx, id)
@@ -66,9 +263,9 @@ func (s *messageService) GetByID(ctx context.Context, id uint) (*domain.Message,
 	return message, nil
 }
 
-// List retrieves all messages with pagination
-func (s *messageService) List(ctx context.Context, limit, offset int) ([]*domain.Message, error) {
-	messages, err := s.repo.List(ctx, limit, offset)
+// List retrieves the messages matching the filter with pagination
+func (s *messageService) List(ctx context.Context, filter domain.MessageFilter, limit, offset int) ([]*domain.Message, error) {
+	messages, err := s.repo.ListFiltered(ctx, filter, limit, offset)
 	if err != nil {
 		return nil, apperror.ErrMessageListFailed.WithError(err)
 	}
@@ -76,6 +273,15 @@ func (s *messageService) List(ctx context.Context, limit, offset int) ([]*domain
 	return messages, nil
 }
 
+// ListPage retrieves a page of the messages matching the filter with cursor pagination
+func (s *messageService) ListPage(ctx context.Context, filter domain.MessageFilter, page domain.MessagePageQuery) (*domain.MessagePage, error) {
+	result, err := s.repo.ListPage(ctx, filter, page)
+	if err != nil {
+		return nil, apperror.ErrMessageListFailed.WithError(err)
+	}
+	return result, nil
+}
+
 // ListSentMessages retrieves only sent messages with pagination
 func (s *messageService) ListSentMessages(ctx context.Context, limit, offset int) ([]*domain.Message, error) {
 	messages, err := s.repo.GetSentMessages(ctx, limit, offset)
@@ -86,6 +292,24 @@ func (s *messageService) ListSentMessages(ctx context.Context, limit, offset int
 	return messages, nil
 }
 
+// ListFailedMessages retrieves only failed messages with pagination
+func (s *messageService) ListFailedMessages(ctx context.Context, limit, offset int) ([]*domain.Message, error) {
+	messages, err := s.repo.GetFailedMessages(ctx, limit, offset)
+	if err != nil {
+		return nil, apperror.ErrMessageListFailed.WithError(err)
+	}
+
+	return messages, nil
+}
+
+// Export streams every message matching the filter to fn, oldest first, without loading them all
+func (s *messageService) Export(ctx context.Context, filter domain.MessageFilter, fn func(*domain.Message) error) error {
+	if err := s.repo.Stream(ctx, filter, fn); err != nil {
+		return apperror.ErrMessageExportFailed.WithError(err)
+	}
+	return nil
+}
+
 // GetPendingMessages retrieves pending messages
 func (s *messageService) GetPendingMessages(ctx context.Context, limit int) ([]*domain.Message, error) {
 	messages, err := s.repo.GetPendingMessages(ctx, limit)
@@ -95,25 +319,132 @@ func (s *messageService) GetPendingMessages(ctx context.Context, limit int) ([]*
 	return messages, nil
 }
 
-// SetSent marks a message as sent
-func (s *messageService) SetSent(ctx context.Context, id uint, messageID string) error {
-	message, err := s.repo.GetByID(ctx, id)
+// ClaimPendingMessages leases due pending messages to the given owner for processing
+func (s *messageService) ClaimPendingMessages(ctx context.Context, owner string, limit int, reserved map[domain.MessagePriority]int, leaseDuration time.Duration) ([]*domain.Message, error) {
+	messages, err := s.repo.ClaimPendingMessages(ctx, owner, limit, reserved, leaseDuration)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return apperror.ErrMessageNotFound
-		}
-		return apperror.ErrMessageUpdateFailed.WithError(err)
+		return nil, apperror.ErrMessageClaimFailed.WithError(err)
 	}
+	return messages, nil
+}
 
+// MarkUnknown holds a message whose webhook call outcome is unknown for review,
+// it reports false when another instance holds its lease
+func (s *messageService) MarkUnknown(ctx context.Context, id uint, owner string) (bool, error) {
+	marked, err := s.repo.MarkUnknown(ctx, id, owner)
+	if err != nil {
+		return false, apperror.ErrMessageMarkUnknownFailed.WithError(err)
+	}
+	return marked, nil
+}
+
+// ReleaseExpiredLeases returns messages stranded by a crashed instance to pending
+func (s *messageService) ReleaseExpiredLeases(ctx context.Context) (int64, error) {
+	released, err := s.repo.ReleaseExpiredLeases(ctx)
+	if err != nil {
+		return 0, apperror.ErrLeaseReleaseFailed.WithError(err)
+	}
+	return released, nil
+}
+
+// ReleaseLease returns a claimed message to pending so it is picked up again without losing an attempt
+func (s *messageService) ReleaseLease(ctx context.Context, id uint, owner string) error {
+	if err := s.repo.ReleaseLease(ctx, id, owner); err != nil {
+		return apperror.ErrLeaseReleaseFailed.WithError(err)
+	}
+	return nil
+}
+
+// ExpireMessage marks a message claimed by the owner as expired without sending it
+func (s *messageService) ExpireMessage(ctx context.Context, id uint, owner string) error {
+	return s.updateLeased(ctx, id, owner, map[string]interface{}{
+		"status":           domain.StatusExpired,
+		"next_attempt_at":  nil,
+		"lease_owner":      nil,
+		"lease_expires_at": nil,
+	}, apperror.ErrMessageExpireFailed)
+}
+
+// SuppressMessage marks a message claimed by the owner as suppressed without sending it
+func (s *messageService) SuppressMessage(ctx context.Context, id uint, owner string) error {
+	return s.updateLeased(ctx, id, owner, map[string]interface{}{
+		"status":             domain.StatusSuppressed,
+		"last_error_code":    apperror.ErrCodeRecipientSuppressed,
+		"last_error_message": apperror.MsgRecipientSuppressed,
+		"next_attempt_at":    nil,
+		"lease_owner":        nil,
+		"lease_expires_at":   nil,
+	}, apperror.ErrMessageSuppressFailed)
+}
+
+// ExpirePendingMessages marks pending messages whose validity has elapsed as expired
+func (s *messageService) ExpirePendingMessages(ctx context.Context) (int64, error) {
+	expired, err := s.repo.ExpirePendingMessages(ctx)
+	if err != nil {
+		return 0, apperror.ErrMessageExpireFailed.WithError(err)
+	}
+	return expired, nil
+}
+
+// SetSent marks a message claimed by the owner as sent and records the provider that accepted it
+func (s *messageService) SetSent(ctx context.Context, id uint, owner, messageID, provider string) error {
 	now := time.Now()
-	message.Status = domain.StatusSent
-	message.MessageID = &messageID
-	message.SentAt = &now
+	fields := map[string]interface{}{
+		"status":           domain.StatusSent,
+		"message_id":       messageID,
+		"sent_at":          now,
+		"attempt_count":    gorm.Expr("attempt_count + 1"),
+		"last_attempt_at":  now,
+		"lease_owner":      nil,
+		"lease_expires_at": nil,
+	}
+	if provider != "" {
+		fields["provider"] = provider
+	}
 
-	if err := s.repo.Update(ctx, message); err != nil {
-		return apperror.ErrMessageUpdateFailed.WithError(err)
+	return s.updateLeased(ctx, id, owner, fields, apperror.ErrMessageUpdateFailed)
+}
+
+// RecordFailedAttempt stores a failed delivery attempt and keeps the message pending until nextAttemptAt
+func (s *messageService) RecordFailedAttempt(ctx context.Context, id uint, owner, errCode, errMessage string, nextAttemptAt time.Time) error {
+	return s.recordFailure(ctx, id, owner, errCode, errMessage, domain.StatusPending, &nextAttemptAt)
+}
+
+// SetFailed stores the last delivery attempt and marks the message as permanently failed
+func (s *messageService) SetFailed(ctx context.Context, id uint, owner, errCode, errMessage string) error {
+	return s.recordFailure(ctx, id, owner, errCode, errMessage, domain.StatusFailed, nil)
+}
+
+// recordFailure increments the attempt counter and stores the last error with the given status
+func (s *messageService) recordFailure(ctx context.Context, id uint, owner, errCode, errMessage string, status domain.MessageStatus, nextAttemptAt *time.Time) error {
+	now := time.Now()
+	fields := map[string]interface{}{
+		"status":             status,
+		"attempt_count":      gorm.Expr("attempt_count + 1"),
+		"last_attempt_at":    now,
+		"last_error_code":    errCode,
+		"last_error_message": errMessage,
+		"next_attempt_at":    nextAttemptAt,
+		"lease_owner":        nil,
+		"lease_expires_at":   nil,
+	}
+	if status == domain.StatusFailed {
+		fields["failed_at"] = now
 	}
 
+	return s.updateLeased(ctx, id, owner, fields, apperror.ErrMessageUpdateFailed)
+}
+
+// updateLeased writes the outcome of a claimed message only while the owner still holds its lease.
+// An instance whose lease expired must not overwrite a message another instance has claimed since.
+func (s *messageService) updateLeased(ctx context.Context, id uint, owner string, fields map[string]interface{}, failed *customerror.CustomError) error {
+	updated, err := s.repo.UpdateLeased(ctx, id, owner, fields)
+	if err != nil {
+		return failed.WithError(err)
+	}
+	if !updated {
+		return apperror.ErrMessageLeaseLost
+	}
 	return nil
 }
 
@@ -127,24 +458,133 @@ func (s *messageService) Update(ctx context.Context, id uint, req dto.UpdateMess
 		return nil, apperror.ErrMessageUpdateFailed.WithError(err)
 	}
 
+	if message.Status == domain.StatusUnknown {
+		return s.resolveUnknown(ctx, message, req)
+	}
+
+	// A message can only change before the sender picks it up
+	if message.Status != domain.StatusPending {
+		return nil, apperror.ErrMessageNotPending
+	}
+	if err := validateSendAt(req.SendAt); err != nil {
+		return nil, err
+	}
+	// The deadline is stored as an absolute time, a reschedule past it would only send an expired message
+	if req.SendAt != nil && message.ExpiresAt != nil && !message.ExpiresAt.After(*req.SendAt) {
+		return nil, apperror.ErrExpiresAtInvalid
+	}
+
 	// Update only provided fields
+	phoneChanged := req.PhoneNumber != nil && *req.PhoneNumber != message.PhoneNumber
 	if req.PhoneNumber != nil {
 		message.PhoneNumber = *req.PhoneNumber
 	}
 	if req.Content != nil {
+		info, err := s.analyzeContent(*req.Content)
+		if err != nil {
+			return nil, err
+		}
 		message.Content = *req.Content
+		message.Encoding = string(info.Encoding)
+		message.Segments = info.Segments
 	}
 	if req.Status != nil {
 		message.Status = *req.Status
 	}
+	if req.SendAt != nil {
+		message.SendAt = req.SendAt
+	}
+	// A new recipient goes through the same suppression rule as a created message
+	if phoneChanged && message.Status == domain.StatusPending {
+		if err := s.checkSuppression(ctx, message); err != nil {
+			return nil, err
+		}
+	}
+
+	// The sender may claim the message between the read above and this write
+	updated, err := s.repo.UpdatePending(ctx, message)
+	if err != nil {
+		return nil, apperror.ErrMessageUpdateFailed.WithError(err)
+	}
+	if !updated {
+		return nil, apperror.ErrMessageNotPending
+	}
+
+	return message, nil
+}
+
+// resolveUnknown lets an operator decide on a message whose last send has no known outcome:
+// pending sends it again with the same idempotency key, cancelled drops it
+func (s *messageService) resolveUnknown(ctx context.Context, message *domain.Message, req dto.UpdateMessageRequest) (*domain.Message, error) {
+	if req.Status == nil || req.PhoneNumber != nil || req.Content != nil || req.SendAt != nil {
+		return nil, apperror.ErrMessageUnknown
+	}
 
-	if err := s.repo.Update(ctx, message); err != nil {
+	resolved, err := s.repo.ResolveUnknown(ctx, message.ID, *req.Status)
+	if err != nil {
 		return nil, apperror.ErrMessageUpdateFailed.WithError(err)
 	}
+	if !resolved {
+		return nil, apperror.ErrMessageNotPending
+	}
 
+	message.Status = *req.Status
+	message.NextAttemptAt = nil
 	return message, nil
 }
 
+// resolveExpiresAt computes the validity deadline from expiresAt or ttl, which is counted from sendAt when scheduled
+func resolveExpiresAt(req dto.CreateMessageRequest) (*time.Time, error) {
+	if req.ExpiresAt != nil && req.TTL != nil {
+		return nil, apperror.ErrExpiresAtInvalid
+	}
+
+	start := time.Now()
+	if req.SendAt != nil && req.SendAt.After(start) {
+		start = *req.SendAt
+	}
+
+	if req.TTL != nil {
+		expiresAt := start.Add(time.Duration(*req.TTL) * time.Second)
+		return &expiresAt, nil
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(start) {
+		return nil, apperror.ErrExpiresAtInvalid
+	}
+	return req.ExpiresAt, nil
+}
+
+// resolveContent returns the request content, rendering the referenced template when one is given
+func (s *messageService) resolveContent(ctx context.Context, req dto.CreateMessageRequest) (string, error) {
+	if req.TemplateID == nil {
+		return req.Content, nil
+	}
+	if s.templates == nil {
+		return "", apperror.ErrTemplateNotFound
+	}
+	return s.templates.Render(ctx, *req.TemplateID, req.Variables)
+}
+
+// analyzeContent detects the encoding of the content and rejects messages longer than the segment limit
+func (s *messageService) analyzeContent(content string) (smscontent.Info, error) {
+	info := smscontent.Analyze(content)
+	if info.Segments > s.maxSegments {
+		return info, apperror.ErrMessageTooLong.WithError(
+			fmt.Errorf("%d %s segments, at most %d allowed", info.Segments, info.Encoding, s.maxSegments),
+		)
+	}
+	return info, nil
+}
+
+// validateSendAt rejects a schedule time in the past beyond the tolerance
+func validateSendAt(sendAt *time.Time) error {
+	if sendAt != nil && sendAt.Before(time.Now().Add(-sendAtTolerance)) {
+		return apperror.ErrSendAtInPast
+	}
+	return nil
+}
+
 // Delete deletes a message
 func (s *messageService) Delete(ctx context.Context, id uint) error {
 	if err := s.repo.Delete(ctx, id); err != nil {
@@ -3,11 +3,14 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/srcndev/message-service/internal/apperror"
 	"github.com/srcndev/message-service/internal/domain"
 	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/pkg/customerror"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
@@ -23,6 +26,11 @@ func (m *MockMessageRepository) Create(ctx context.Context, message *domain.Mess
 	return args.Error(0)
 }
 
+func (m *MockMessageRepository) CreateBatch(ctx context.Context, messages []*domain.Message) error {
+	args := m.Called(ctx, messages)
+	return args.Error(0)
+}
+
 func (m *MockMessageRepository) GetByID(ctx context.Context, id uint) (*domain.Message, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -31,6 +39,14 @@ func (m *MockMessageRepository) GetByID(ctx context.Context, id uint) (*domain.M
 	return args.Get(0).(*domain.Message), args.Error(1)
 }
 
+func (m *MockMessageRepository) GetByMessageID(ctx context.Context, messageID string) (*domain.Message, error) {
+	args := m.Called(ctx, messageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+
 func (m *MockMessageRepository) List(ctx context.Context, limit, offset int) ([]*domain.Message, error) {
 	args := m.Called(ctx, limit, offset)
 	if args.Get(0) == nil {
@@ -39,6 +55,22 @@ func (m *MockMessageRepository) List(ctx context.Context, limit, offset int) ([]
 	return args.Get(0).([]*domain.Message), args.Error(1)
 }
 
+func (m *MockMessageRepository) ListFiltered(ctx context.Context, filter domain.MessageFilter, limit, offset int) ([]*domain.Message, error) {
+	args := m.Called(ctx, filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) ListPage(ctx context.Context, filter domain.MessageFilter, page domain.MessagePageQuery) (*domain.MessagePage, error) {
+	args := m.Called(ctx, filter, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MessagePage), args.Error(1)
+}
+
 func (m *MockMessageRepository) GetPendingMessages(ctx context.Context, limit int) ([]*domain.Message, error) {
 	args := m.Called(ctx, limit)
 	if args.Get(0) == nil {
@@ -47,6 +79,34 @@ func (m *MockMessageRepository) GetPendingMessages(ctx context.Context, limit in
 	return args.Get(0).([]*domain.Message), args.Error(1)
 }
 
+func (m *MockMessageRepository) MarkUnknown(ctx context.Context, id uint, owner string) (bool, error) {
+	args := m.Called(ctx, id, owner)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMessageRepository) ClaimPendingMessages(ctx context.Context, owner string, limit int, reserved map[domain.MessagePriority]int, leaseDuration time.Duration) ([]*domain.Message, error) {
+	args := m.Called(ctx, owner, limit, reserved, leaseDuration)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) ExpirePendingMessages(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMessageRepository) ReleaseExpiredLeases(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockMessageRepository) ReleaseLease(ctx context.Context, id uint, owner string) error {
+	args := m.Called(ctx, id, owner)
+	return args.Error(0)
+}
+
 func (m *MockMessageRepository) GetSentMessages(ctx context.Context, limit, offset int) ([]*domain.Message, error) {
 	args := m.Called(ctx, limit, offset)
 	if args.Get(0) == nil {
@@ -55,6 +115,47 @@ func (m *MockMessageRepository) GetSentMessages(ctx context.Context, limit, offs
 	return args.Get(0).([]*domain.Message), args.Error(1)
 }
 
+func (m *MockMessageRepository) GetFailedMessages(ctx context.Context, limit, offset int) ([]*domain.Message, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+
+// Stream feeds the messages given to Return to fn, then returns the error
+func (m *MockMessageRepository) Stream(ctx context.Context, filter domain.MessageFilter, fn func(*domain.Message) error) error {
+	args := m.Called(ctx, filter)
+	if messages, ok := args.Get(0).([]*domain.Message); ok {
+		for _, message := range messages {
+			if err := fn(message); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockMessageRepository) UpdatePending(ctx context.Context, message *domain.Message) (bool, error) {
+	args := m.Called(ctx, message)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMessageRepository) ResolveUnknown(ctx context.Context, id uint, status domain.MessageStatus) (bool, error) {
+	args := m.Called(ctx, id, status)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMessageRepository) ApplyReceipt(ctx context.Context, id uint, status domain.MessageStatus, receiptAt time.Time, errorCode *string) (bool, error) {
+	args := m.Called(ctx, id, status, receiptAt, errorCode)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMessageRepository) UpdateLeased(ctx context.Context, id uint, owner string, fields map[string]interface{}) (bool, error) {
+	args := m.Called(ctx, id, owner, fields)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockMessageRepository) Update(ctx context.Context, message *domain.Message) error {
 	args := m.Called(ctx, message)
 	return args.Error(0)
@@ -77,7 +178,10 @@ func TestMessageService_Create_Success(t *testing.T) {
 	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(msg *domain.Message) bool {
 		return msg.PhoneNumber == req.PhoneNumber &&
 			msg.Content == req.Content &&
-			msg.Status == domain.StatusPending
+			msg.Status == domain.StatusPending &&
+			msg.Priority == domain.PriorityNormal &&
+			msg.Encoding == "gsm7" &&
+			msg.Segments == 1
 	})).Return(nil)
 
 	result, err := service.Create(context.Background(), req)
@@ -90,6 +194,314 @@ func TestMessageService_Create_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestMessageService_Create_Priority(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	req := dto.CreateMessageRequest{
+		PhoneNumber: "+905551234567",
+		Content:     "Your code is 1234",
+		Priority:    domain.PriorityHigh,
+	}
+
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(msg *domain.Message) bool {
+		return msg.Priority == domain.PriorityHigh
+	})).Return(nil)
+
+	_, err := service.Create(context.Background(), req)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_Create_LongMessage(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	// Non-GSM characters switch the whole message to UCS-2 with 67 characters per concatenated segment
+	req := dto.CreateMessageRequest{
+		PhoneNumber: "+905551234567",
+		Content:     "Sayın müşterimiz, " + strings.Repeat("a", 100),
+	}
+
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(msg *domain.Message) bool {
+		return msg.Encoding == "ucs2" && msg.Segments == 2
+	})).Return(nil)
+
+	_, err := service.Create(context.Background(), req)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_Create_TooManySegments(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo, WithMaxSegments(2))
+
+	req := dto.CreateMessageRequest{
+		PhoneNumber: "+905551234567",
+		Content:     strings.Repeat("a", 3*153),
+	}
+
+	result, err := service.Create(context.Background(), req)
+
+	var customErr *customerror.CustomError
+	assert.ErrorAs(t, err, &customErr)
+	assert.Equal(t, apperror.ErrCodeMessageTooLong, customErr.Code)
+	assert.Nil(t, result)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestMessageService_Create_FromTemplate(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	mockTemplates := new(MockTemplateRepository)
+	service := NewMessageService(mockRepo, WithTemplates(NewTemplateService(mockTemplates)))
+
+	templateID := uint(1)
+	req := dto.CreateMessageRequest{
+		PhoneNumber: "+905551234567",
+		TemplateID:  &templateID,
+		Variables:   map[string]string{"name": "Ayşe", "code": "123456"},
+	}
+
+	mockTemplates.On("GetByID", mock.Anything, uint(1)).Return(otpTemplate(), nil)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(msg *domain.Message) bool {
+		return msg.Content == "Hi Ayşe, your code is 123456. Do not share 123456." &&
+			*msg.TemplateID == 1 &&
+			msg.Encoding == "ucs2"
+	})).Return(nil)
+
+	_, err := service.Create(context.Background(), req)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_Create_RenderedTooLong(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	mockTemplates := new(MockTemplateRepository)
+	service := NewMessageService(mockRepo, WithMaxSegments(1), WithTemplates(NewTemplateService(mockTemplates)))
+
+	templateID := uint(1)
+	req := dto.CreateMessageRequest{
+		PhoneNumber: "+905551234567",
+		TemplateID:  &templateID,
+		Variables:   map[string]string{"name": strings.Repeat("a", 200), "code": "123456"},
+	}
+
+	mockTemplates.On("GetByID", mock.Anything, uint(1)).Return(otpTemplate(), nil)
+
+	_, err := service.Create(context.Background(), req)
+
+	var customErr *customerror.CustomError
+	assert.ErrorAs(t, err, &customErr)
+	assert.Equal(t, apperror.ErrCodeMessageTooLong, customErr.Code)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestMessageService_CreateBatch_PartialFailure(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	past := time.Now().Add(-time.Hour)
+	reqs := []dto.CreateMessageRequest{
+		{PhoneNumber: "+905551111111", Content: "One"},
+		{PhoneNumber: "+905552222222", Content: "Past", SendAt: &past},
+		{PhoneNumber: "+905553333333", Content: "Three", Priority: domain.PriorityHigh},
+	}
+
+	mockRepo.On("CreateBatch", mock.Anything, mock.MatchedBy(func(messages []*domain.Message) bool {
+		return len(messages) == 2 && messages[0].Content == "One" && messages[1].Priority == domain.PriorityHigh
+	})).Return(nil)
+
+	results, err := service.CreateBatch(context.Background(), reqs)
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, "One", results[0].Message.Content)
+	assert.Equal(t, apperror.ErrSendAtInPast, results[1].Err)
+	assert.Nil(t, results[1].Message)
+	assert.Equal(t, "Three", results[2].Message.Content)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_Create_SuppressedRecipient(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	mockSuppressions := new(MockSuppressionRepository)
+	service := NewMessageService(mockRepo, WithSuppressions(NewSuppressionService(mockSuppressions, nil), false))
+
+	mockSuppressions.On("Exists", mock.Anything, "+905551111111").Return(true, nil)
+
+	message, err := service.Create(context.Background(), dto.CreateMessageRequest{PhoneNumber: "+905551111111", Content: "Hello"})
+
+	assert.Nil(t, message)
+	assert.Equal(t, apperror.ErrRecipientSuppressed, err)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestMessageService_CreateBatch_AutoSuppress(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	mockSuppressions := new(MockSuppressionRepository)
+	service := NewMessageService(mockRepo, WithSuppressions(NewSuppressionService(mockSuppressions, nil), true))
+
+	mockSuppressions.On("Exists", mock.Anything, "+905551111111").Return(true, nil)
+	mockSuppressions.On("Exists", mock.Anything, "+905552222222").Return(false, nil)
+	mockRepo.On("CreateBatch", mock.Anything, mock.MatchedBy(func(messages []*domain.Message) bool {
+		return len(messages) == 2 &&
+			messages[0].Status == domain.StatusSuppressed && *messages[0].LastErrorCode == apperror.ErrCodeRecipientSuppressed &&
+			messages[1].Status == domain.StatusPending
+	})).Return(nil)
+
+	results, err := service.CreateBatch(context.Background(), []dto.CreateMessageRequest{
+		{PhoneNumber: "+905551111111", Content: "Hello"},
+		{PhoneNumber: "+905552222222", Content: "Hello"},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, domain.StatusSuppressed, results[0].Message.Status)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_CreateReply(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	mockSuppressions := new(MockSuppressionRepository)
+	service := NewMessageService(mockRepo, WithSuppressions(NewSuppressionService(mockSuppressions, nil), false))
+
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(m *domain.Message) bool {
+		return m.KeywordReply && m.Priority == domain.PriorityHigh && m.Status == domain.StatusPending && m.Segments == 1
+	})).Return(nil)
+
+	message, err := service.CreateReply(context.Background(), "+905551111111", "You are unsubscribed")
+
+	assert.NoError(t, err)
+	assert.True(t, message.KeywordReply)
+	mockSuppressions.AssertNotCalled(t, "Exists", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_CreateBatch_AllInvalid(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo, WithMaxSegments(1))
+
+	results, err := service.CreateBatch(context.Background(), []dto.CreateMessageRequest{
+		{PhoneNumber: "+905551111111", Content: strings.Repeat("a", 161)},
+	})
+
+	assert.NoError(t, err)
+	assert.Error(t, results[0].Err)
+	mockRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+}
+
+func TestMessageService_CreateBatch_InsertError(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(errors.New("db error"))
+
+	results, err := service.CreateBatch(context.Background(), []dto.CreateMessageRequest{
+		{PhoneNumber: "+905551111111", Content: "One"},
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, results)
+}
+
+func TestMessageService_Export_WrapsError(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	filter := domain.MessageFilter{Status: domain.StatusSent}
+	mockRepo.On("Stream", mock.Anything, filter).Return([]*domain.Message{{ID: 1}}, errors.New("connection reset"))
+
+	var exported []uint
+	err := service.Export(context.Background(), filter, func(message *domain.Message) error {
+		exported = append(exported, message.ID)
+		return nil
+	})
+
+	var appErr *customerror.CustomError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, apperror.ErrCodeMessageExportFailed, appErr.Code)
+	assert.Equal(t, []uint{1}, exported)
+}
+
+func TestMessageService_Create_Scheduled(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	sendAt := time.Now().Add(time.Hour)
+	req := dto.CreateMessageRequest{
+		PhoneNumber: "+905551234567",
+		Content:     "Test message",
+		SendAt:      &sendAt,
+	}
+
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(msg *domain.Message) bool {
+		return msg.SendAt != nil && msg.SendAt.Equal(sendAt) && msg.Status == domain.StatusPending
+	})).Return(nil)
+
+	_, err := service.Create(context.Background(), req)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_Create_SendAtTolerance(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	// Slightly in the past is accepted as clock skew
+	skewed := time.Now().Add(-10 * time.Second)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := service.Create(context.Background(), dto.CreateMessageRequest{PhoneNumber: "+905551234567", Content: "Test", SendAt: &skewed})
+	assert.NoError(t, err)
+
+	// Far in the past is rejected without touching the database
+	past := time.Now().Add(-time.Hour)
+	_, err = service.Create(context.Background(), dto.CreateMessageRequest{PhoneNumber: "+905551234567", Content: "Test", SendAt: &past})
+	assert.ErrorIs(t, err, apperror.ErrSendAtInPast)
+	mockRepo.AssertNumberOfCalls(t, "Create", 1)
+}
+
+func TestMessageService_Create_TTLFromSendAt(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	sendAt := time.Now().Add(time.Hour)
+	ttl := 300
+	expected := sendAt.Add(5 * time.Minute)
+
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(msg *domain.Message) bool {
+		return msg.ExpiresAt != nil && msg.ExpiresAt.Equal(expected)
+	})).Return(nil)
+
+	_, err := service.Create(context.Background(), dto.CreateMessageRequest{PhoneNumber: "+905551234567", Content: "Test", SendAt: &sendAt, TTL: &ttl})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_Create_ExpiresAtInvalid(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	sendAt := time.Now().Add(time.Hour)
+	beforeSendAt := time.Now().Add(30 * time.Minute)
+	ttl := 60
+
+	// Expires before it would be sent
+	_, err := service.Create(context.Background(), dto.CreateMessageRequest{PhoneNumber: "+905551234567", Content: "Test", SendAt: &sendAt, ExpiresAt: &beforeSendAt})
+	assert.ErrorIs(t, err, apperror.ErrExpiresAtInvalid)
+
+	// expiresAt and ttl are mutually exclusive
+	_, err = service.Create(context.Background(), dto.CreateMessageRequest{PhoneNumber: "+905551234567", Content: "Test", ExpiresAt: &sendAt, TTL: &ttl})
+	assert.ErrorIs(t, err, apperror.ErrExpiresAtInvalid)
+
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
 func TestMessageService_Create_Error(t *testing.T) {
 	mockRepo := new(MockMessageRepository)
 	service := NewMessageService(mockRepo)
@@ -170,9 +582,9 @@ func TestMessageService_List_Success(t *testing.T) {
 		{ID: 2, PhoneNumber: "+905552222222", Content: "Message 2", Status: domain.StatusSent},
 	}
 
-	mockRepo.On("List", mock.Anything, 10, 0).Return(expectedMessages, nil)
+	mockRepo.On("ListFiltered", mock.Anything, domain.MessageFilter{}, 10, 0).Return(expectedMessages, nil)
 
-	result, err := service.List(context.Background(), 10, 0)
+	result, err := service.List(context.Background(), domain.MessageFilter{}, 10, 0)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -186,9 +598,39 @@ func TestMessageService_List_Error(t *testing.T) {
 	service := NewMessageService(mockRepo)
 
 	dbError := errors.New("database error")
-	mockRepo.On("List", mock.Anything, 10, 0).Return(nil, dbError)
+	mockRepo.On("ListFiltered", mock.Anything, domain.MessageFilter{}, 10, 0).Return(nil, dbError)
+
+	result, err := service.List(context.Background(), domain.MessageFilter{}, 10, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "MESSAGE_LIST_FAILED")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_ListPage_Success(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	page := domain.MessagePageQuery{SortBy: domain.SortByCreatedAt, Limit: 10}
+	expected := &domain.MessagePage{Messages: []*domain.Message{{ID: 1}}, HasNext: true}
+	mockRepo.On("ListPage", mock.Anything, domain.MessageFilter{}, page).Return(expected, nil)
+
+	result, err := service.ListPage(context.Background(), domain.MessageFilter{}, page)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_ListPage_Error(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
 
-	result, err := service.List(context.Background(), 10, 0)
+	page := domain.MessagePageQuery{SortBy: domain.SortByCreatedAt, Limit: 10}
+	mockRepo.On("ListPage", mock.Anything, domain.MessageFilter{}, page).Return(nil, errors.New("database error"))
+
+	result, err := service.ListPage(context.Background(), domain.MessageFilter{}, page)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -285,42 +727,195 @@ func TestMessageService_GetPendingMessages_Error(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
-func TestMessageService_SetSent_Success(t *testing.T) {
+func TestMessageService_ClaimPendingMessages_Success(t *testing.T) {
 	mockRepo := new(MockMessageRepository)
 	service := NewMessageService(mockRepo)
 
-	existingMsg := &domain.Message{
-		ID:          1,
-		PhoneNumber: "+905551234567",
-		Content:     "Test message",
-		Status:      domain.StatusPending,
+	owner := "instance-1"
+	expectedMessages := []*domain.Message{
+		{ID: 1, PhoneNumber: "+905551111111", Content: "Message 1", Status: domain.StatusProcessing, LeaseOwner: &owner},
 	}
 
-	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(existingMsg, nil)
-	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(msg *domain.Message) bool {
-		return msg.ID == 1 &&
-			msg.Status == domain.StatusSent &&
-			msg.MessageID != nil &&
-			*msg.MessageID == "webhook-msg-id" &&
-			msg.SentAt != nil
-	})).Return(nil)
+	mockRepo.On("ClaimPendingMessages", mock.Anything, owner, 2, mock.Anything, time.Minute).Return(expectedMessages, nil)
 
-	err := service.SetSent(context.Background(), 1, "webhook-msg-id")
+	result, err := service.ClaimPendingMessages(context.Background(), owner, 2, nil, time.Minute)
 
 	assert.NoError(t, err)
+	assert.Len(t, result, 1)
 	mockRepo.AssertExpectations(t)
 }
 
-func TestMessageService_SetSent_NotFound(t *testing.T) {
+func TestMessageService_ClaimPendingMessages_Error(t *testing.T) {
 	mockRepo := new(MockMessageRepository)
 	service := NewMessageService(mockRepo)
 
-	mockRepo.On("GetByID", mock.Anything, uint(999)).Return(nil, gorm.ErrRecordNotFound)
+	dbError := errors.New("database error")
+	mockRepo.On("ClaimPendingMessages", mock.Anything, "instance-1", 2, mock.Anything, time.Minute).Return(nil, dbError)
 
-	err := service.SetSent(context.Background(), 999, "webhook-msg-id")
+	result, err := service.ClaimPendingMessages(context.Background(), "instance-1", 2, nil, time.Minute)
 
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "MESSAGE_NOT_FOUND")
+	assert.Nil(t, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_MarkUnknown_Success(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("MarkUnknown", mock.Anything, uint(1), "instance-1").Return(true, nil)
+
+	marked, err := service.MarkUnknown(context.Background(), 1, "instance-1")
+
+	assert.NoError(t, err)
+	assert.True(t, marked)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_MarkUnknown_Error(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("MarkUnknown", mock.Anything, uint(1), "instance-1").Return(false, errors.New("database error"))
+
+	marked, err := service.MarkUnknown(context.Background(), 1, "instance-1")
+
+	assert.False(t, marked)
+	assert.Contains(t, err.Error(), apperror.ErrCodeMessageMarkUnknownFailed)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_ReleaseExpiredLeases_Success(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("ReleaseExpiredLeases", mock.Anything).Return(int64(2), nil)
+
+	released, err := service.ReleaseExpiredLeases(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), released)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_ReleaseExpiredLeases_Error(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("ReleaseExpiredLeases", mock.Anything).Return(int64(0), errors.New("database error"))
+
+	released, err := service.ReleaseExpiredLeases(context.Background())
+
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), released)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_ReleaseLease_Success(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("ReleaseLease", mock.Anything, uint(1), "instance-1").Return(nil)
+
+	err := service.ReleaseLease(context.Background(), 1, "instance-1")
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_ReleaseLease_Error(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("ReleaseLease", mock.Anything, uint(1), "instance-1").Return(errors.New("database error"))
+
+	err := service.ReleaseLease(context.Background(), 1, "instance-1")
+
+	assert.Error(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_ExpireMessage_Success(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("UpdateLeased", mock.Anything, uint(1), "instance-1", mock.MatchedBy(func(fields map[string]interface{}) bool {
+		return fields["status"] == domain.StatusExpired && fields["lease_owner"] == nil
+	})).Return(true, nil)
+
+	err := service.ExpireMessage(context.Background(), 1, "instance-1")
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SuppressMessage_Success(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("UpdateLeased", mock.Anything, uint(1), "instance-1", mock.MatchedBy(func(fields map[string]interface{}) bool {
+		return fields["status"] == domain.StatusSuppressed && fields["lease_owner"] == nil &&
+			fields["last_error_code"] == apperror.ErrCodeRecipientSuppressed
+	})).Return(true, nil)
+
+	err := service.SuppressMessage(context.Background(), 1, "instance-1")
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_ExpirePendingMessages_Success(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("ExpirePendingMessages", mock.Anything).Return(int64(4), nil)
+
+	expired, err := service.ExpirePendingMessages(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), expired)
+}
+
+func TestMessageService_ExpirePendingMessages_Error(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("ExpirePendingMessages", mock.Anything).Return(int64(0), errors.New("database error"))
+
+	_, err := service.ExpirePendingMessages(context.Background())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), apperror.ErrCodeMessageExpireFailed)
+}
+
+func TestMessageService_SetSent_Success(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("UpdateLeased", mock.Anything, uint(1), "instance-1", mock.MatchedBy(func(fields map[string]interface{}) bool {
+		return fields["status"] == domain.StatusSent &&
+			fields["message_id"] == "webhook-msg-id" &&
+			fields["provider"] == "primary" &&
+			fields["sent_at"] != nil &&
+			fields["attempt_count"] != nil
+	})).Return(true, nil)
+
+	err := service.SetSent(context.Background(), 1, "instance-1", "webhook-msg-id", "primary")
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SetSent_LeaseLost(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	// The lease expired and another instance claimed the message in the meantime
+	mockRepo.On("UpdateLeased", mock.Anything, uint(1), "instance-1", mock.Anything).Return(false, nil)
+
+	err := service.SetSent(context.Background(), 1, "instance-1", "webhook-msg-id", "primary")
+
+	assert.Equal(t, apperror.ErrMessageLeaseLost, err)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -328,18 +923,107 @@ func TestMessageService_SetSent_UpdateError(t *testing.T) {
 	mockRepo := new(MockMessageRepository)
 	service := NewMessageService(mockRepo)
 
-	existingMsg := &domain.Message{
-		ID:          1,
-		PhoneNumber: "+905551234567",
-		Content:     "Test",
-		Status:      domain.StatusPending,
+	mockRepo.On("UpdateLeased", mock.Anything, uint(1), "instance-1", mock.Anything).Return(false, errors.New("database error"))
+
+	err := service.SetSent(context.Background(), 1, "instance-1", "webhook-msg-id", "primary")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "MESSAGE_UPDATE_FAILED")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_ListFailedMessages_Success(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	failedAt := time.Now()
+	expectedMessages := []*domain.Message{
+		{ID: 1, PhoneNumber: "+905551111111", Content: "Message 1", Status: domain.StatusFailed, AttemptCount: 5, FailedAt: &failedAt},
 	}
 
-	dbError := errors.New("database error")
-	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(existingMsg, nil)
-	mockRepo.On("Update", mock.Anything, mock.Anything).Return(dbError)
+	mockRepo.On("GetFailedMessages", mock.Anything, 10, 0).Return(expectedMessages, nil)
+
+	result, err := service.ListFailedMessages(context.Background(), 10, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, domain.StatusFailed, result[0].Status)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_ListFailedMessages_Error(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("GetFailedMessages", mock.Anything, 10, 0).Return(nil, errors.New("database error"))
 
-	err := service.SetSent(context.Background(), 1, "webhook-msg-id")
+	result, err := service.ListFailedMessages(context.Background(), 10, 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "MESSAGE_LIST_FAILED")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_RecordFailedAttempt_Success(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	nextAttemptAt := time.Now().Add(time.Minute)
+
+	mockRepo.On("UpdateLeased", mock.Anything, uint(1), "instance-1", mock.MatchedBy(func(fields map[string]interface{}) bool {
+		next, _ := fields["next_attempt_at"].(*time.Time)
+		_, failed := fields["failed_at"]
+		return fields["status"] == domain.StatusPending &&
+			fields["last_error_code"] == "WEBHOOK_SERVER_ERROR" &&
+			fields["last_error_message"] == "status: 503" &&
+			next != nil && next.Equal(nextAttemptAt) &&
+			!failed
+	})).Return(true, nil)
+
+	err := service.RecordFailedAttempt(context.Background(), 1, "instance-1", "WEBHOOK_SERVER_ERROR", "status: 503", nextAttemptAt)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SetFailed_Success(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("UpdateLeased", mock.Anything, uint(1), "instance-1", mock.MatchedBy(func(fields map[string]interface{}) bool {
+		next, _ := fields["next_attempt_at"].(*time.Time)
+		return fields["status"] == domain.StatusFailed &&
+			fields["failed_at"] != nil &&
+			next == nil &&
+			fields["last_error_code"] == "WEBHOOK_INVALID_REQUEST"
+	})).Return(true, nil)
+
+	err := service.SetFailed(context.Background(), 1, "instance-1", "WEBHOOK_INVALID_REQUEST", "invalid number")
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SetFailed_LeaseLost(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("UpdateLeased", mock.Anything, uint(1), "instance-1", mock.Anything).Return(false, nil)
+
+	err := service.SetFailed(context.Background(), 1, "instance-1", "WEBHOOK_SERVER_ERROR", "status: 503")
+
+	assert.Equal(t, apperror.ErrMessageLeaseLost, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_SetFailed_UpdateError(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	mockRepo.On("UpdateLeased", mock.Anything, uint(1), "instance-1", mock.Anything).Return(false, errors.New("database error"))
+
+	err := service.SetFailed(context.Background(), 1, "instance-1", "WEBHOOK_SERVER_ERROR", "status: 503")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "MESSAGE_UPDATE_FAILED")
@@ -359,7 +1043,7 @@ func TestMessageService_Update_Success(t *testing.T) {
 
 	newPhone := "+905559999999"
 	newContent := "New content"
-	newStatus := domain.StatusSent
+	newStatus := domain.StatusCancelled
 
 	updateReq := dto.UpdateMessageRequest{
 		PhoneNumber: &newPhone,
@@ -368,12 +1052,14 @@ func TestMessageService_Update_Success(t *testing.T) {
 	}
 
 	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(existingMsg, nil)
-	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(msg *domain.Message) bool {
+	mockRepo.On("UpdatePending", mock.Anything, mock.MatchedBy(func(msg *domain.Message) bool {
 		return msg.ID == 1 &&
 			msg.PhoneNumber == newPhone &&
 			msg.Content == newContent &&
+			msg.Encoding == "gsm7" &&
+			msg.Segments == 1 &&
 			msg.Status == newStatus
-	})).Return(nil)
+	})).Return(true, nil)
 
 	result, err := service.Update(context.Background(), 1, updateReq)
 
@@ -402,12 +1088,12 @@ func TestMessageService_Update_PartialUpdate(t *testing.T) {
 	}
 
 	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(existingMsg, nil)
-	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(msg *domain.Message) bool {
+	mockRepo.On("UpdatePending", mock.Anything, mock.MatchedBy(func(msg *domain.Message) bool {
 		return msg.ID == 1 &&
 			msg.PhoneNumber == existingMsg.PhoneNumber && // Unchanged
 			msg.Content == newContent && // Changed
 			msg.Status == existingMsg.Status // Unchanged
-	})).Return(nil)
+	})).Return(true, nil)
 
 	result, err := service.Update(context.Background(), 1, updateReq)
 
@@ -437,6 +1123,245 @@ func TestMessageService_Update_NotFound(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestMessageService_Update_Reschedule(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	existingMsg := &domain.Message{ID: 1, Status: domain.StatusPending}
+	sendAt := time.Now().Add(time.Hour)
+
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(existingMsg, nil)
+	mockRepo.On("UpdatePending", mock.Anything, mock.MatchedBy(func(msg *domain.Message) bool {
+		return msg.SendAt != nil && msg.SendAt.Equal(sendAt)
+	})).Return(true, nil)
+
+	result, err := service.Update(context.Background(), 1, dto.UpdateMessageRequest{SendAt: &sendAt})
+
+	assert.NoError(t, err)
+	assert.Equal(t, sendAt, *result.SendAt)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_Update_Cancel(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	existingMsg := &domain.Message{ID: 1, Status: domain.StatusPending}
+	cancelled := domain.StatusCancelled
+
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(existingMsg, nil)
+	mockRepo.On("UpdatePending", mock.Anything, mock.MatchedBy(func(msg *domain.Message) bool {
+		return msg.Status == domain.StatusCancelled
+	})).Return(true, nil)
+
+	result, err := service.Update(context.Background(), 1, dto.UpdateMessageRequest{Status: &cancelled})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusCancelled, result.Status)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_Update_CancelAfterPickup(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	existingMsg := &domain.Message{ID: 1, Status: domain.StatusProcessing}
+	cancelled := domain.StatusCancelled
+
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(existingMsg, nil)
+
+	_, err := service.Update(context.Background(), 1, dto.UpdateMessageRequest{Status: &cancelled})
+
+	assert.ErrorIs(t, err, apperror.ErrMessageNotPending)
+	mockRepo.AssertNotCalled(t, "UpdatePending", mock.Anything, mock.Anything)
+}
+
+func TestMessageService_Update_EditAfterSend(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	existingMsg := &domain.Message{ID: 1, Content: "Old content", Status: domain.StatusSent}
+	newContent := "New content"
+
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(existingMsg, nil)
+
+	_, err := service.Update(context.Background(), 1, dto.UpdateMessageRequest{Content: &newContent})
+
+	assert.ErrorIs(t, err, apperror.ErrMessageNotPending)
+	assert.Equal(t, "Old content", existingMsg.Content)
+	mockRepo.AssertNotCalled(t, "UpdatePending", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestMessageService_Update_RescheduleRacesWithSender(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	existingMsg := &domain.Message{ID: 1, Status: domain.StatusPending}
+	sendAt := time.Now().Add(time.Hour)
+
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(existingMsg, nil)
+	mockRepo.On("UpdatePending", mock.Anything, mock.Anything).Return(false, nil)
+
+	_, err := service.Update(context.Background(), 1, dto.UpdateMessageRequest{SendAt: &sendAt})
+
+	assert.ErrorIs(t, err, apperror.ErrMessageNotPending)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMessageService_Update_RescheduleInPast(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	existingMsg := &domain.Message{ID: 1, Status: domain.StatusPending}
+	sendAt := time.Now().Add(-time.Hour)
+
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(existingMsg, nil)
+
+	_, err := service.Update(context.Background(), 1, dto.UpdateMessageRequest{SendAt: &sendAt})
+
+	assert.ErrorIs(t, err, apperror.ErrSendAtInPast)
+}
+
+func TestMessageService_Update_RescheduleAfterExpiry(t *testing.T) {
+	mockRepo := new(MockMessageRepository)
+	service := NewMessageService(mockRepo)
+
+	expiresAt := time.Now().Add(time.Hour)
+	existingMsg := &domain.Message{ID: 1, Status: domain.StatusPending, ExpiresAt: &expiresAt}
+	sendAt := expiresAt.Add(time.Hour)
+
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(existingMsg, nil)
+
+	_, err := service.Update(context.Background(), 1, dto.UpdateMessageRequest{SendAt: &sendAt})
+
+	assert.ErrorIs(t, err, apperror.ErrExpiresAtInvalid)
+	mockRepo.AssertNotCalled(t, "UpdatePending", mock.Anything, mock.Anything)
+}
+
+func TestMessageService_Update_SuppressedRecipient(t *testing.T) {
+	phone := "+905551111111"
+
+	t.Run("rejected", func(t *testing.T) {
+		mockRepo := new(MockMessageRepository)
+		mockSuppressions := new(MockSuppressionRepository)
+		service := NewMessageService(mockRepo, WithSuppressions(NewSuppressionService(mockSuppressions, nil), false))
+
+		mockRepo.On("GetByID", mock.Anything, uint(1)).Return(&domain.Message{ID: 1, PhoneNumber: "+905552222222", Status: domain.StatusPending}, nil)
+		mockSuppressions.On("Exists", mock.Anything, phone).Return(true, nil)
+
+		_, err := service.Update(context.Background(), 1, dto.UpdateMessageRequest{PhoneNumber: &phone})
+
+		assert.Equal(t, apperror.ErrRecipientSuppressed, err)
+		mockRepo.AssertNotCalled(t, "UpdatePending", mock.Anything, mock.Anything)
+	})
+
+	t.Run("auto-suppressed", func(t *testing.T) {
+		mockRepo := new(MockMessageRepository)
+		mockSuppressions := new(MockSuppressionRepository)
+		service := NewMessageService(mockRepo, WithSuppressions(NewSuppressionService(mockSuppressions, nil), true))
+
+		mockRepo.On("GetByID", mock.Anything, uint(1)).Return(&domain.Message{ID: 1, PhoneNumber: "+905552222222", Status: domain.StatusPending}, nil)
+		mockSuppressions.On("Exists", mock.Anything, phone).Return(true, nil)
+		mockRepo.On("UpdatePending", mock.Anything, mock.MatchedBy(func(msg *domain.Message) bool {
+			return msg.PhoneNumber == phone && msg.Status == domain.StatusSuppressed
+		})).Return(true, nil)
+
+		result, err := service.Update(context.Background(), 1, dto.UpdateMessageRequest{PhoneNumber: &phone})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusSuppressed, result.Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unchanged number is not checked", func(t *testing.T) {
+		mockRepo := new(MockMessageRepository)
+		mockSuppressions := new(MockSuppressionRepository)
+		service := NewMessageService(mockRepo, WithSuppressions(NewSuppressionService(mockSuppressions, nil), false))
+
+		mockRepo.On("GetByID", mock.Anything, uint(1)).Return(&domain.Message{ID: 1, PhoneNumber: phone, Status: domain.StatusPending}, nil)
+		mockRepo.On("UpdatePending", mock.Anything, mock.Anything).Return(true, nil)
+
+		_, err := service.Update(context.Background(), 1, dto.UpdateMessageRequest{PhoneNumber: &phone})
+
+		assert.NoError(t, err)
+		mockSuppressions.AssertNotCalled(t, "Exists", mock.Anything, mock.Anything)
+	})
+}
+
+func TestMessageService_Update_ResolveUnknown(t *testing.T) {
+	pending := domain.StatusPending
+	cancelled := domain.StatusCancelled
+	content := "Edited"
+
+	tests := []struct {
+		name      string
+		req       dto.UpdateMessageRequest
+		mockSetup func(*MockMessageRepository)
+		expected  domain.MessageStatus
+		wantErr   error
+	}{
+		{
+			name: "sent again",
+			req:  dto.UpdateMessageRequest{Status: &pending},
+			mockSetup: func(m *MockMessageRepository) {
+				m.On("ResolveUnknown", mock.Anything, uint(1), domain.StatusPending).Return(true, nil)
+			},
+			expected: domain.StatusPending,
+		},
+		{
+			name: "cancelled",
+			req:  dto.UpdateMessageRequest{Status: &cancelled},
+			mockSetup: func(m *MockMessageRepository) {
+				m.On("ResolveUnknown", mock.Anything, uint(1), domain.StatusCancelled).Return(true, nil)
+			},
+			expected: domain.StatusCancelled,
+		},
+		{
+			name:      "status required",
+			req:       dto.UpdateMessageRequest{Content: &content},
+			mockSetup: func(m *MockMessageRepository) {},
+			wantErr:   apperror.ErrMessageUnknown,
+		},
+		{
+			name:      "only the status changes",
+			req:       dto.UpdateMessageRequest{Status: &pending, Content: &content},
+			mockSetup: func(m *MockMessageRepository) {},
+			wantErr:   apperror.ErrMessageUnknown,
+		},
+		{
+			name: "resolved concurrently",
+			req:  dto.UpdateMessageRequest{Status: &pending},
+			mockSetup: func(m *MockMessageRepository) {
+				m.On("ResolveUnknown", mock.Anything, uint(1), domain.StatusPending).Return(false, nil)
+			},
+			wantErr: apperror.ErrMessageNotPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockMessageRepository)
+			service := NewMessageService(mockRepo)
+
+			mockRepo.On("GetByID", mock.Anything, uint(1)).Return(&domain.Message{ID: 1, Status: domain.StatusUnknown}, nil)
+			tt.mockSetup(mockRepo)
+
+			result, err := service.Update(context.Background(), 1, tt.req)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result.Status)
+			mockRepo.AssertExpectations(t)
+			mockRepo.AssertNotCalled(t, "UpdatePending", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestMessageService_Delete_Success(t *testing.T) {
 	mockRepo := new(MockMessageRepository)
 	service := NewMessageService(mockRepo)
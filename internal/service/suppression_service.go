@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/internal/repository"
+	"github.com/srcndev/message-service/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// SuppressionService defines the business logic interface for the recipient suppression list
+type SuppressionService interface {
+	Create(ctx context.Context, req dto.CreateSuppressionRequest) (*domain.Suppression, error)
+	GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.Suppression, error)
+	List(ctx context.Context, limit, offset int) ([]*domain.Suppression, error)
+	Delete(ctx context.Context, phoneNumber string) error
+
+	// IsSuppressed reports whether no message may be sent to the phone number
+	IsSuppressed(ctx context.Context, phoneNumber string) (bool, error)
+}
+
+type suppressionService struct {
+	repo      repository.SuppressionRepository
+	cacheRepo repository.SuppressionCacheRepository
+}
+
+// Compile-time interface compliance check
+var _ SuppressionService = (*suppressionService)(nil)
+
+// NewSuppressionService creates a new suppression service, cacheRepo may be nil when Redis is disabled
+func NewSuppressionService(repo repository.SuppressionRepository, cacheRepo repository.SuppressionCacheRepository) SuppressionService {
+	return &suppressionService{
+		repo:      repo,
+		cacheRepo: cacheRepo,
+	}
+}
+
+// Create adds a phone number to the suppression list
+func (s *suppressionService) Create(ctx context.Context, req dto.CreateSuppressionRequest) (*domain.Suppression, error) {
+	reason := req.Reason
+	if reason == "" {
+		reason = domain.SuppressionReasonManual
+	}
+
+	suppression := &domain.Suppression{
+		PhoneNumber: req.PhoneNumber,
+		Reason:      reason,
+		Note:        req.Note,
+	}
+
+	created, err := s.repo.Create(ctx, suppression)
+	if err != nil {
+		return nil, apperror.ErrSuppressionCreateFailed.WithError(err)
+	}
+	if !created {
+		return nil, apperror.ErrSuppressionExists
+	}
+
+	s.cache(ctx, suppression.PhoneNumber, true)
+	return suppression, nil
+}
+
+// GetByPhoneNumber retrieves the suppression of a phone number
+func (s *suppressionService) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.Suppression, error) {
+	suppression, err := s.repo.GetByPhoneNumber(ctx, phoneNumber)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.ErrSuppressionNotFound
+		}
+		return nil, apperror.ErrSuppressionListFailed.WithError(err)
+	}
+
+	return suppression, nil
+}
+
+// List retrieves suppressions with pagination
+func (s *suppressionService) List(ctx context.Context, limit, offset int) ([]*domain.Suppression, error) {
+	suppressions, err := s.repo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, apperror.ErrSuppressionListFailed.WithError(err)
+	}
+
+	return suppressions, nil
+}
+
+// Delete removes a phone number from the suppression list, messages created while it was suppressed stay suppressed
+func (s *suppressionService) Delete(ctx context.Context, phoneNumber string) error {
+	deleted, err := s.repo.Delete(ctx, phoneNumber)
+	if err != nil {
+		return apperror.ErrSuppressionDeleteFailed.WithError(err)
+	}
+	if !deleted {
+		return apperror.ErrSuppressionNotFound
+	}
+
+	s.cache(ctx, phoneNumber, false)
+	return nil
+}
+
+// IsSuppressed checks the Redis cache first when enabled, a cache failure falls back to the database
+func (s *suppressionService) IsSuppressed(ctx context.Context, phoneNumber string) (bool, error) {
+	if s.cacheRepo != nil {
+		suppressed, found, err := s.cacheRepo.Get(ctx, phoneNumber)
+		if err != nil {
+			logger.Error("Failed to read suppression of %s from Redis: %v", phoneNumber, err)
+		} else if found {
+			return suppressed, nil
+		}
+	}
+
+	suppressed, err := s.repo.Exists(ctx, phoneNumber)
+	if err != nil {
+		return false, apperror.ErrSuppressionLookupFailed.WithError(err)
+	}
+
+	s.cache(ctx, phoneNumber, suppressed)
+	return suppressed, nil
+}
+
+// cache stores a lookup in Redis, failures are only logged since the database stays the source of truth
+func (s *suppressionService) cache(ctx context.Context, phoneNumber string, suppressed bool) {
+	if s.cacheRepo == nil {
+		return
+	}
+	if err := s.cacheRepo.Set(ctx, phoneNumber, suppressed); err != nil {
+		logger.Error("Failed to cache suppression of %s to Redis: %v", phoneNumber, err)
+	}
+}
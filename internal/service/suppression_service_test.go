@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockSuppressionRepository is a mock implementation of SuppressionRepository
+type MockSuppressionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSuppressionRepository) Create(ctx context.Context, suppression *domain.Suppression) (bool, error) {
+	args := m.Called(ctx, suppression)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSuppressionRepository) GetByPhoneNumber(ctx context.Context, phoneNumber string) (*domain.Suppression, error) {
+	args := m.Called(ctx, phoneNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Suppression), args.Error(1)
+}
+
+func (m *MockSuppressionRepository) List(ctx context.Context, limit, offset int) ([]*domain.Suppression, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Suppression), args.Error(1)
+}
+
+func (m *MockSuppressionRepository) Delete(ctx context.Context, phoneNumber string) (bool, error) {
+	args := m.Called(ctx, phoneNumber)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSuppressionRepository) Exists(ctx context.Context, phoneNumber string) (bool, error) {
+	args := m.Called(ctx, phoneNumber)
+	return args.Bool(0), args.Error(1)
+}
+
+// MockSuppressionCacheRepository is a mock implementation of SuppressionCacheRepository
+type MockSuppressionCacheRepository struct {
+	mock.Mock
+}
+
+func (m *MockSuppressionCacheRepository) Get(ctx context.Context, phoneNumber string) (bool, bool, error) {
+	args := m.Called(ctx, phoneNumber)
+	return args.Bool(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockSuppressionCacheRepository) Set(ctx context.Context, phoneNumber string, suppressed bool) error {
+	args := m.Called(ctx, phoneNumber, suppressed)
+	return args.Error(0)
+}
+
+func TestSuppressionService_Create_Success(t *testing.T) {
+	mockRepo := new(MockSuppressionRepository)
+	mockCache := new(MockSuppressionCacheRepository)
+	service := NewSuppressionService(mockRepo, mockCache)
+
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *domain.Suppression) bool {
+		return s.PhoneNumber == "+905551111111" && s.Reason == domain.SuppressionReasonManual
+	})).Return(true, nil)
+	mockCache.On("Set", mock.Anything, "+905551111111", true).Return(nil)
+
+	suppression, err := service.Create(context.Background(), dto.CreateSuppressionRequest{PhoneNumber: "+905551111111"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.SuppressionReasonManual, suppression.Reason)
+	mockRepo.AssertExpectations(t)
+	mockCache.AssertExpectations(t)
+}
+
+func TestSuppressionService_Create_AlreadySuppressed(t *testing.T) {
+	mockRepo := new(MockSuppressionRepository)
+	service := NewSuppressionService(mockRepo, nil)
+
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(false, nil)
+
+	suppression, err := service.Create(context.Background(), dto.CreateSuppressionRequest{
+		PhoneNumber: "+905551111111",
+		Reason:      domain.SuppressionReasonOptOut,
+	})
+
+	assert.Nil(t, suppression)
+	assert.Equal(t, apperror.ErrSuppressionExists, err)
+}
+
+func TestSuppressionService_Create_Error(t *testing.T) {
+	mockRepo := new(MockSuppressionRepository)
+	service := NewSuppressionService(mockRepo, nil)
+
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(false, errors.New("database error"))
+
+	_, err := service.Create(context.Background(), dto.CreateSuppressionRequest{PhoneNumber: "+905551111111"})
+
+	assert.Contains(t, err.Error(), apperror.ErrCodeSuppressionCreateFailed)
+}
+
+func TestSuppressionService_GetByPhoneNumber_NotFound(t *testing.T) {
+	mockRepo := new(MockSuppressionRepository)
+	service := NewSuppressionService(mockRepo, nil)
+
+	mockRepo.On("GetByPhoneNumber", mock.Anything, "+905551111111").Return(nil, gorm.ErrRecordNotFound)
+
+	suppression, err := service.GetByPhoneNumber(context.Background(), "+905551111111")
+
+	assert.Nil(t, suppression)
+	assert.Equal(t, apperror.ErrSuppressionNotFound, err)
+}
+
+func TestSuppressionService_List_Success(t *testing.T) {
+	mockRepo := new(MockSuppressionRepository)
+	service := NewSuppressionService(mockRepo, nil)
+
+	mockRepo.On("List", mock.Anything, 10, 0).Return([]*domain.Suppression{{ID: 1, PhoneNumber: "+905551111111"}}, nil)
+
+	suppressions, err := service.List(context.Background(), 10, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, suppressions, 1)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSuppressionService_Delete(t *testing.T) {
+	t.Run("success - cache updated", func(t *testing.T) {
+		mockRepo := new(MockSuppressionRepository)
+		mockCache := new(MockSuppressionCacheRepository)
+		service := NewSuppressionService(mockRepo, mockCache)
+
+		mockRepo.On("Delete", mock.Anything, "+905551111111").Return(true, nil)
+		mockCache.On("Set", mock.Anything, "+905551111111", false).Return(nil)
+
+		err := service.Delete(context.Background(), "+905551111111")
+
+		assert.NoError(t, err)
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("error - not suppressed", func(t *testing.T) {
+		mockRepo := new(MockSuppressionRepository)
+		mockCache := new(MockSuppressionCacheRepository)
+		service := NewSuppressionService(mockRepo, mockCache)
+
+		mockRepo.On("Delete", mock.Anything, "+905551111111").Return(false, nil)
+
+		err := service.Delete(context.Background(), "+905551111111")
+
+		assert.Equal(t, apperror.ErrSuppressionNotFound, err)
+		mockCache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSuppressionService_IsSuppressed(t *testing.T) {
+	tests := []struct {
+		name      string
+		mockSetup func(*MockSuppressionRepository, *MockSuppressionCacheRepository)
+		expected  bool
+	}{
+		{
+			name: "cache hit skips the database",
+			mockSetup: func(repo *MockSuppressionRepository, cache *MockSuppressionCacheRepository) {
+				cache.On("Get", mock.Anything, "+905551111111").Return(true, true, nil)
+			},
+			expected: true,
+		},
+		{
+			name: "cache miss reads the database and caches the result",
+			mockSetup: func(repo *MockSuppressionRepository, cache *MockSuppressionCacheRepository) {
+				cache.On("Get", mock.Anything, "+905551111111").Return(false, false, nil)
+				repo.On("Exists", mock.Anything, "+905551111111").Return(false, nil)
+				cache.On("Set", mock.Anything, "+905551111111", false).Return(nil)
+			},
+			expected: false,
+		},
+		{
+			name: "cache failure falls back to the database",
+			mockSetup: func(repo *MockSuppressionRepository, cache *MockSuppressionCacheRepository) {
+				cache.On("Get", mock.Anything, "+905551111111").Return(false, false, errors.New("redis down"))
+				repo.On("Exists", mock.Anything, "+905551111111").Return(true, nil)
+				cache.On("Set", mock.Anything, "+905551111111", true).Return(errors.New("redis down"))
+			},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockSuppressionRepository)
+			mockCache := new(MockSuppressionCacheRepository)
+			tt.mockSetup(mockRepo, mockCache)
+			service := NewSuppressionService(mockRepo, mockCache)
+
+			suppressed, err := service.IsSuppressed(context.Background(), "+905551111111")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, suppressed)
+			mockRepo.AssertExpectations(t)
+			mockCache.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSuppressionService_IsSuppressed_Error(t *testing.T) {
+	mockRepo := new(MockSuppressionRepository)
+	service := NewSuppressionService(mockRepo, nil)
+
+	mockRepo.On("Exists", mock.Anything, "+905551111111").Return(false, errors.New("database error"))
+
+	_, err := service.IsSuppressed(context.Background(), "+905551111111")
+
+	assert.Contains(t, err.Error(), apperror.ErrCodeSuppressionLookupFailed)
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/internal/repository"
+	"gorm.io/gorm"
+)
+
+// TemplateService defines the business logic interface for message templates
+type TemplateService interface {
+	Create(ctx context.Context, req dto.CreateTemplateRequest) (*domain.Template, error)
+	GetByID(ctx context.Context, id uint) (*domain.Template, error)
+	List(ctx context.Context, limit, offset int) ([]*domain.Template, error)
+	Update(ctx context.Context, id uint, req dto.UpdateTemplateRequest) (*domain.Template, error)
+	Delete(ctx context.Context, id uint) error
+
+	// Render substitutes the variables into the template content
+	Render(ctx context.Context, id uint, variables map[string]string) (string, error)
+}
+
+type templateService struct {
+	repo repository.TemplateRepository
+}
+
+// Compile-time interface compliance check
+var _ TemplateService = (*templateService)(nil)
+
+// NewTemplateService creates a new template service
+func NewTemplateService(repo repository.TemplateRepository) TemplateService {
+	return &templateService{
+		repo: repo,
+	}
+}
+
+// Create creates a new template
+func (s *templateService) Create(ctx context.Context, req dto.CreateTemplateRequest) (*domain.Template, error) {
+	template := &domain.Template{
+		Name:    req.Name,
+		Content: req.Content,
+	}
+
+	if err := s.repo.Create(ctx, template); err != nil {
+		return nil, apperror.ErrTemplateCreateFailed.WithError(err)
+	}
+
+	return template, nil
+}
+
+// GetByID retrieves a template by ID
+func (s *templateService) GetByID(ctx context.Context, id uint) (*domain.Template, error) {
+	template, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.ErrTemplateNotFound
+		}
+		return nil, apperror.ErrTemplateListFailed.WithError(err)
+	}
+
+	return template, nil
+}
+
+// List retrieves templates with pagination
+func (s *templateService) List(ctx context.Context, limit, offset int) ([]*domain.Template, error) {
+	templates, err := s.repo.List(ctx, limit, offset)
+	if err != nil {
+		return nil, apperror.ErrTemplateListFailed.WithError(err)
+	}
+
+	return templates, nil
+}
+
+// Update updates an existing template, messages already created from it keep their rendered content
+func (s *templateService) Update(ctx context.Context, id uint, req dto.UpdateTemplateRequest) (*domain.Template, error) {
+	template, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.ErrTemplateNotFound
+		}
+		return nil, apperror.ErrTemplateUpdateFailed.WithError(err)
+	}
+
+	if req.Name != nil {
+		template.Name = *req.Name
+	}
+	if req.Content != nil {
+		template.Content = *req.Content
+	}
+
+	if err := s.repo.Update(ctx, template); err != nil {
+		return nil, apperror.ErrTemplateUpdateFailed.WithError(err)
+	}
+
+	return template, nil
+}
+
+// Delete soft deletes a template
+func (s *templateService) Delete(ctx context.Context, id uint) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperror.ErrTemplateNotFound
+		}
+		return apperror.ErrTemplateDeleteFailed.WithError(err)
+	}
+	return nil
+}
+
+// Render substitutes the variables into the template content, every placeholder must have a value
+func (s *templateService) Render(ctx context.Context, id uint, variables map[string]string) (string, error) {
+	template, err := s.GetByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	content, missing := template.Render(variables)
+	if len(missing) > 0 {
+		return "", apperror.ErrTemplateVariableMissing.WithError(fmt.Errorf("missing %s", strings.Join(missing, ", ")))
+	}
+
+	return content, nil
+}
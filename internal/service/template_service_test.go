@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/srcndev/message-service/internal/apperror"
+	"github.com/srcndev/message-service/internal/domain"
+	"github.com/srcndev/message-service/internal/dto"
+	"github.com/srcndev/message-service/pkg/customerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockTemplateRepository is a mock implementation of TemplateRepository
+type MockTemplateRepository struct {
+	mock.Mock
+}
+
+func (m *MockTemplateRepository) Create(ctx context.Context, template *domain.Template) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockTemplateRepository) GetByID(ctx context.Context, id uint) (*domain.Template, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Template), args.Error(1)
+}
+
+func (m *MockTemplateRepository) List(ctx context.Context, limit, offset int) ([]*domain.Template, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Template), args.Error(1)
+}
+
+func (m *MockTemplateRepository) Update(ctx context.Context, template *domain.Template) error {
+	args := m.Called(ctx, template)
+	return args.Error(0)
+}
+
+func (m *MockTemplateRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func otpTemplate() *domain.Template {
+	return &domain.Template{ID: 1, Name: "otp", Content: "Hi {{name}}, your code is {{ code }}. Do not share {{code}}."}
+}
+
+func TestTemplateService_Create_Success(t *testing.T) {
+	mockRepo := new(MockTemplateRepository)
+	service := NewTemplateService(mockRepo)
+
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(template *domain.Template) bool {
+		return template.Name == "otp" && template.Content == "Your code is {{code}}"
+	})).Return(nil)
+
+	template, err := service.Create(context.Background(), dto.CreateTemplateRequest{Name: "otp", Content: "Your code is {{code}}"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"code"}, template.Variables())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTemplateService_GetByID_NotFound(t *testing.T) {
+	mockRepo := new(MockTemplateRepository)
+	service := NewTemplateService(mockRepo)
+
+	mockRepo.On("GetByID", mock.Anything, uint(99)).Return(nil, gorm.ErrRecordNotFound)
+
+	template, err := service.GetByID(context.Background(), 99)
+
+	assert.Equal(t, apperror.ErrTemplateNotFound, err)
+	assert.Nil(t, template)
+}
+
+func TestTemplateService_Update_PartialUpdate(t *testing.T) {
+	mockRepo := new(MockTemplateRepository)
+	service := NewTemplateService(mockRepo)
+
+	newContent := "Your code is {{code}}"
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(otpTemplate(), nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(template *domain.Template) bool {
+		return template.Name == "otp" && template.Content == newContent
+	})).Return(nil)
+
+	_, err := service.Update(context.Background(), 1, dto.UpdateTemplateRequest{Content: &newContent})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestTemplateService_Delete_Error(t *testing.T) {
+	mockRepo := new(MockTemplateRepository)
+	service := NewTemplateService(mockRepo)
+
+	mockRepo.On("Delete", mock.Anything, uint(1)).Return(errors.New("db error"))
+
+	err := service.Delete(context.Background(), 1)
+
+	var customErr *customerror.CustomError
+	assert.ErrorAs(t, err, &customErr)
+	assert.Equal(t, apperror.ErrCodeTemplateDeleteFailed, customErr.Code)
+}
+
+func TestTemplateService_Render_Success(t *testing.T) {
+	mockRepo := new(MockTemplateRepository)
+	service := NewTemplateService(mockRepo)
+
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(otpTemplate(), nil)
+
+	// Every occurrence is replaced and unused variables are ignored
+	content, err := service.Render(context.Background(), 1, map[string]string{"name": "Ayşe", "code": "123456", "extra": "x"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Hi Ayşe, your code is 123456. Do not share 123456.", content)
+}
+
+func TestTemplateService_Render_MissingVariables(t *testing.T) {
+	mockRepo := new(MockTemplateRepository)
+	service := NewTemplateService(mockRepo)
+
+	mockRepo.On("GetByID", mock.Anything, uint(1)).Return(otpTemplate(), nil)
+
+	content, err := service.Render(context.Background(), 1, map[string]string{})
+
+	var customErr *customerror.CustomError
+	assert.ErrorAs(t, err, &customErr)
+	assert.Equal(t, apperror.ErrCodeTemplateVariableMissing, customErr.Code)
+	assert.Contains(t, err.Error(), "missing name, code")
+	assert.Empty(t, content)
+}
+
+func TestTemplateService_InterfaceCompliance(t *testing.T) {
+	var _ TemplateService = (*templateService)(nil)
+}
@@ -0,0 +1,80 @@
+package backoff
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Default policy values
+const (
+	DefaultBase       = 1 * time.Second
+	DefaultMax        = 1 * time.Minute
+	DefaultMultiplier = 2.0
+	DefaultJitter     = 0.2
+)
+
+// Policy describes an exponential backoff with jitter
+type Policy struct {
+	// Base is the delay before the first retry
+	Base time.Duration
+
+	// Max caps the delay regardless of the attempt number
+	Max time.Duration
+
+	// Multiplier grows the delay after every attempt
+	Multiplier float64
+
+	// Jitter is the fraction (0-1) of the delay that is randomized
+	Jitter float64
+}
+
+// NewPolicy creates a policy, falling back to defaults for invalid values
+func NewPolicy(base, max time.Duration, multiplier float64) Policy {
+	p := Policy{
+		Base:       base,
+		Max:        max,
+		Multiplier: multiplier,
+		Jitter:     DefaultJitter,
+	}
+
+	if p.Base <= 0 {
+		p.Base = DefaultBase
+	}
+	if p.Max < p.Base {
+		p.Max = p.Base
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = DefaultMultiplier
+	}
+
+	return p
+}
+
+// Next returns the delay before the given attempt (1-based) with jitter applied
+func (p Policy) Next(attempt int) time.Duration {
+	delay := p.Delay(attempt)
+	if p.Jitter <= 0 || delay <= 0 {
+		return delay
+	}
+
+	jitter := math.Min(p.Jitter, 1)
+	spread := float64(delay) * jitter
+
+	// Randomize the last part of the delay so retries from many messages spread out
+	return time.Duration(float64(delay) - spread + rand.Float64()*spread)
+}
+
+// Delay returns the capped exponential delay before the given attempt (1-based) without jitter
+func (p Policy) Delay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := float64(p.Base) * math.Pow(p.Multiplier, float64(attempt-1))
+	if p.Max > 0 && delay > float64(p.Max) {
+		return p.Max
+	}
+
+	return time.Duration(delay)
+}
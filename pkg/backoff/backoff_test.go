@@ -0,0 +1,60 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPolicy_Defaults(t *testing.T) {
+	p := NewPolicy(0, 0, 0)
+
+	assert.Equal(t, DefaultBase, p.Base)
+	assert.Equal(t, DefaultBase, p.Max)
+	assert.Equal(t, DefaultMultiplier, p.Multiplier)
+	assert.Equal(t, DefaultJitter, p.Jitter)
+}
+
+func TestNewPolicy_MaxBelowBase(t *testing.T) {
+	p := NewPolicy(10*time.Second, 5*time.Second, 2)
+
+	assert.Equal(t, 10*time.Second, p.Max)
+}
+
+func TestPolicy_Delay(t *testing.T) {
+	p := Policy{Base: time.Second, Max: 10 * time.Second, Multiplier: 2}
+
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{0, 1 * time.Second},
+		{1, 1 * time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second},
+		{50, 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, p.Delay(tt.attempt), "attempt %d", tt.attempt)
+	}
+}
+
+func TestPolicy_Next_WithoutJitter(t *testing.T) {
+	p := Policy{Base: time.Second, Max: time.Minute, Multiplier: 3}
+
+	assert.Equal(t, 9*time.Second, p.Next(3))
+}
+
+func TestPolicy_Next_JitterWithinBounds(t *testing.T) {
+	p := Policy{Base: time.Second, Max: time.Minute, Multiplier: 2, Jitter: 0.5}
+
+	for i := 0; i < 100; i++ {
+		delay := p.Next(3)
+		assert.GreaterOrEqual(t, delay, 2*time.Second)
+		assert.LessOrEqual(t, delay, 4*time.Second)
+	}
+}
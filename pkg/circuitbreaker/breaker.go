@@ -0,0 +1,144 @@
+package circuitbreaker
+
+import (
+	"sync"
+	"time"
+)
+
+// Default breaker settings
+const (
+	defaultFailureThreshold = 5
+	defaultCoolDown         = 30 * time.Second
+)
+
+// Breaker defines the circuit breaker interface
+type Breaker interface {
+	// Allow returns ErrOpen when the call must be rejected
+	Allow() error
+	// Success records a successful call
+	Success()
+	// Failure records a failed call
+	Failure()
+	// Release records a call whose outcome says nothing about the endpoint (e.g. cancelled)
+	Release()
+	// State returns the current state
+	State() State
+	// Snapshot returns the current state with failure details
+	Snapshot() Snapshot
+}
+
+// breaker is the private implementation of Breaker interface
+type breaker struct {
+	failureThreshold int
+	coolDown         time.Duration
+	now              func() time.Time
+
+	mu            sync.Mutex
+	state         State
+	failures      int
+	openedAt      time.Time
+	probeInFlight bool
+}
+
+// Compile-time interface compliance check
+var _ Breaker = (*breaker)(nil)
+
+// NewBreaker creates a new closed circuit breaker
+func NewBreaker(cfg Config) Breaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaultFailureThreshold
+	}
+	if cfg.CoolDown <= 0 {
+		cfg.CoolDown = defaultCoolDown
+	}
+
+	return &breaker{
+		failureThreshold: cfg.FailureThreshold,
+		coolDown:         cfg.CoolDown,
+		now:              time.Now,
+		state:            StateClosed,
+	}
+}
+
+// Allow returns ErrOpen when the call must be rejected
+func (b *breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.currentState() {
+	case StateOpen:
+		return ErrOpen
+	case StateHalfOpen:
+		// Only one probe at a time, the rest are rejected until it reports back
+		if b.probeInFlight {
+			return ErrOpen
+		}
+		b.state = StateHalfOpen
+		b.probeInFlight = true
+	}
+	return nil
+}
+
+// Success records a successful call and closes the breaker
+func (b *breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = StateClosed
+	b.failures = 0
+	b.probeInFlight = false
+}
+
+// Failure records a failed call and opens the breaker at the threshold or on a failed probe
+func (b *breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.probeInFlight || b.failures >= b.failureThreshold {
+		b.state = StateOpen
+		b.openedAt = b.now()
+	}
+	b.probeInFlight = false
+}
+
+// Release frees the probe slot without changing the failure count
+func (b *breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probeInFlight = false
+}
+
+// State returns the current state
+func (b *breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.currentState()
+}
+
+// Snapshot returns the current state with failure details
+func (b *breaker) Snapshot() Snapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	snapshot := Snapshot{
+		State:               b.currentState(),
+		ConsecutiveFailures: b.failures,
+	}
+	if snapshot.State != StateClosed {
+		openedAt := b.openedAt
+		retryAt := b.openedAt.Add(b.coolDown)
+		snapshot.OpenedAt = &openedAt
+		snapshot.RetryAt = &retryAt
+	}
+	return snapshot
+}
+
+// currentState moves an open breaker to half-open once the cool-down has elapsed (caller holds mu)
+func (b *breaker) currentState() State {
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.coolDown {
+		return StateHalfOpen
+	}
+	return b.state
+}
@@ -0,0 +1,116 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestBreaker returns a breaker driven by a manual clock
+func newTestBreaker(threshold int, coolDown time.Duration, now *time.Time) *breaker {
+	b := NewBreaker(Config{FailureThreshold: threshold, CoolDown: coolDown}).(*breaker)
+	b.now = func() time.Time { return *now }
+	return b
+}
+
+func TestNewBreaker_Defaults(t *testing.T) {
+	b := NewBreaker(Config{}).(*breaker)
+
+	assert.Equal(t, defaultFailureThreshold, b.failureThreshold)
+	assert.Equal(t, defaultCoolDown, b.coolDown)
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_OpensAtThreshold(t *testing.T) {
+	now := time.Now()
+	b := newTestBreaker(3, time.Minute, &now)
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, b.Allow())
+		b.Failure()
+	}
+	assert.Equal(t, StateClosed, b.State())
+
+	b.Failure()
+	assert.Equal(t, StateOpen, b.State())
+	assert.True(t, IsOpen(b.Allow()))
+}
+
+func TestBreaker_SuccessResetsFailures(t *testing.T) {
+	now := time.Now()
+	b := newTestBreaker(2, time.Minute, &now)
+
+	b.Failure()
+	b.Success()
+	b.Failure()
+
+	assert.Equal(t, StateClosed, b.State())
+	assert.Equal(t, 1, b.Snapshot().ConsecutiveFailures)
+}
+
+func TestBreaker_HalfOpenAfterCoolDown(t *testing.T) {
+	now := time.Now()
+	b := newTestBreaker(1, time.Minute, &now)
+
+	b.Failure()
+	assert.Equal(t, StateOpen, b.State())
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, StateHalfOpen, b.State())
+
+	// Only one probe is allowed
+	assert.NoError(t, b.Allow())
+	assert.True(t, IsOpen(b.Allow()))
+}
+
+func TestBreaker_ProbeSuccessCloses(t *testing.T) {
+	now := time.Now()
+	b := newTestBreaker(1, time.Minute, &now)
+
+	b.Failure()
+	now = now.Add(time.Minute)
+	assert.NoError(t, b.Allow())
+	b.Success()
+
+	assert.Equal(t, StateClosed, b.State())
+	assert.NoError(t, b.Allow())
+}
+
+func TestBreaker_ProbeFailureReopens(t *testing.T) {
+	now := time.Now()
+	b := newTestBreaker(3, time.Minute, &now)
+
+	for i := 0; i < 3; i++ {
+		b.Failure()
+	}
+	now = now.Add(time.Minute)
+	assert.NoError(t, b.Allow())
+	b.Failure()
+
+	assert.Equal(t, StateOpen, b.State())
+	snapshot := b.Snapshot()
+	assert.Equal(t, now, *snapshot.OpenedAt)
+	assert.Equal(t, now.Add(time.Minute), *snapshot.RetryAt)
+}
+
+func TestBreaker_ReleaseFreesProbe(t *testing.T) {
+	now := time.Now()
+	b := newTestBreaker(1, time.Minute, &now)
+
+	b.Failure()
+	now = now.Add(time.Minute)
+	assert.NoError(t, b.Allow())
+	b.Release()
+
+	assert.Equal(t, StateHalfOpen, b.State())
+	assert.NoError(t, b.Allow())
+}
+
+func TestIsOpen(t *testing.T) {
+	assert.True(t, IsOpen(ErrOpen))
+	assert.True(t, IsOpen(ErrOpen.WithError(errors.New("wrapped"))))
+	assert.False(t, IsOpen(errors.New("other")))
+	assert.False(t, IsOpen(nil))
+}
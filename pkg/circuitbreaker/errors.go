@@ -0,0 +1,33 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/srcndev/message-service/pkg/customerror"
+)
+
+// Error codes
+const (
+	ErrCodeCircuitOpen = "CIRCUIT_OPEN"
+)
+
+// Error messages
+const (
+	MsgCircuitOpen = "Circuit breaker is open, call rejected"
+)
+
+// Predefined errors
+var (
+	ErrOpen = customerror.NewCustomError(
+		ErrCodeCircuitOpen,
+		MsgCircuitOpen,
+		http.StatusServiceUnavailable,
+	)
+)
+
+// IsOpen reports whether err was caused by an open breaker rejecting the call
+func IsOpen(err error) bool {
+	var customErr *customerror.CustomError
+	return errors.As(err, &customErr) && customErr.Code == ErrCodeCircuitOpen
+}
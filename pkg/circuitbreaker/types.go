@@ -0,0 +1,29 @@
+package circuitbreaker
+
+import "time"
+
+// State represents the breaker state
+type State string
+
+const (
+	StateClosed   State = "closed"    // Calls pass through, failures are counted
+	StateOpen     State = "open"      // Calls are rejected until the cool-down elapses
+	StateHalfOpen State = "half-open" // A single probe call decides whether to close or reopen
+)
+
+// Config holds circuit breaker settings
+type Config struct {
+	// FailureThreshold is the number of consecutive failures that opens the breaker
+	FailureThreshold int
+
+	// CoolDown is how long the breaker stays open before allowing a probe
+	CoolDown time.Duration
+}
+
+// Snapshot is a point-in-time view of the breaker
+type Snapshot struct {
+	State               State      `json:"state"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	OpenedAt            *time.Time `json:"openedAt,omitempty"`
+	RetryAt             *time.Time `json:"retryAt,omitempty"`
+}
@@ -0,0 +1,26 @@
+package correlation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithID_FromContext(t *testing.T) {
+	ctx := WithID(context.Background(), "abc-123")
+
+	assert.Equal(t, "abc-123", FromContext(ctx))
+}
+
+func TestFromContext_Missing(t *testing.T) {
+	assert.Empty(t, FromContext(context.Background()))
+}
+
+func TestNewID(t *testing.T) {
+	first := NewID()
+	second := NewID()
+
+	assert.Len(t, first, 32)
+	assert.NotEqual(t, first, second)
+}
@@ -7,6 +7,7 @@ type CustomResponse struct {
 	Success bool        `json:"success"`
 	Data    interface{} `json:"data,omitempty"`
 	Error   *ErrorInfo  `json:"error,omitempty"`
+	Meta    *Meta       `json:"meta,omitempty"`
 }
 
 // ErrorInfo represents error details
@@ -15,6 +16,16 @@ type ErrorInfo struct {
 	Message string `json:"message"`
 }
 
+// Meta represents the pagination state of a list response
+type Meta struct {
+	Limit      int    `json:"limit" example:"10"`
+	HasNext    bool   `json:"hasNext" example:"true"`
+	HasPrev    bool   `json:"hasPrev" example:"false"`
+	NextCursor string `json:"nextCursor,omitempty" example:"eyJrIjoiY3JlYXRlZF9hdCJ9"` // Pass as cursor to get the next page
+	PrevCursor string `json:"prevCursor,omitempty" example:"eyJrIjoiY3JlYXRlZF9hdCJ9"` // Pass as cursor to get the previous page
+	Total      *int64 `json:"total,omitempty" example:"42"`                            // Only when requested
+}
+
 // Success sends a successful response
 func Success(c *gin.Context, statusCode int, data interface{}) {
 	c.JSON(statusCode, CustomResponse{
@@ -23,6 +34,27 @@ func Success(c *gin.Context, statusCode int, data interface{}) {
 	})
 }
 
+// SuccessWithMeta sends a successful list response with its pagination state
+func SuccessWithMeta(c *gin.Context, statusCode int, data interface{}, meta *Meta) {
+	c.JSON(statusCode, CustomResponse{
+		Success: true,
+		Data:    data,
+		Meta:    meta,
+	})
+}
+
+// ErrorWithData sends an error response together with the data produced before the error
+func ErrorWithData(c *gin.Context, statusCode int, code, message string, data interface{}) {
+	c.JSON(statusCode, CustomResponse{
+		Success: false,
+		Data:    data,
+		Error: &ErrorInfo{
+			Code:    code,
+			Message: message,
+		},
+	})
+}
+
 // Error sends an error response
 func Error(c *gin.Context, statusCode int, code, message string) {
 	c.JSON(statusCode, CustomResponse{
@@ -386,3 +386,40 @@ func TestError_WithVariousStatusCodes(t *testing.T) {
 		})
 	}
 }
+
+func TestSuccessWithMeta(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	total := int64(42)
+	SuccessWithMeta(c, http.StatusOK, []string{"item1"}, &Meta{Limit: 1, HasNext: true, NextCursor: "abc", Total: &total})
+
+	var got map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, map[string]interface{}{
+		"limit":      float64(1),
+		"hasNext":    true,
+		"hasPrev":    false,
+		"nextCursor": "abc",
+		"total":      float64(42),
+	}, got["meta"])
+}
+
+func TestErrorWithData(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	ErrorWithData(c, http.StatusInternalServerError, "IMPORT_FAILED", "Import failed", map[string]int{"created": 100})
+
+	var got map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, false, got["success"])
+	assert.Equal(t, map[string]interface{}{"created": float64(100)}, got["data"])
+	assert.Equal(t, map[string]interface{}{"code": "IMPORT_FAILED", "message": "Import failed"}, got["error"])
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/srcndev/message-service/config"
 	"github.com/srcndev/message-service/internal/domain"
+	"github.com/srcndev/message-service/pkg/idempotency"
 	applogger "github.com/srcndev/message-service/pkg/logger"
 )
 
@@ -53,7 +54,12 @@ func NewPostgresDB(cfg *config.Config) (*gorm.DB, error) {
 
 // AutoMigrate runs database migrations for all models
 func AutoMigrate(db *gorm.DB) error {
-	if err := db.AutoMigrate(&domain.Message{}); err != nil {
+	// The trigram index behind message content search needs pg_trgm
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		return ErrDatabaseMigrationFailed.WithError(err)
+	}
+
+	if err := db.AutoMigrate(&domain.Message{}, &domain.Template{}, &domain.SendIntent{}, &domain.Suppression{}, &domain.InboundMessage{}, &idempotency.PostgresRecord{}); err != nil {
 		return ErrDatabaseMigrationFailed.WithError(err)
 	}
 
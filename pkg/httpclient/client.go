@@ -8,6 +8,8 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"github.com/srcndev/message-service/pkg/backoff"
 )
 
 type Client interface {
@@ -35,7 +37,8 @@ type client struct {
 	httpClient     *http.Client
 	defaultHeaders map[string]string
 	maxRetries     int
-	retryDelay     time.Duration
+	retry          RetryPolicy
+	now            func() time.Time
 }
 
 // Compile-time interface compliance check
@@ -55,43 +58,54 @@ func NewHTTPClient(cfg Config) Client {
 		cfg.RetryDelay = 1 * time.Second
 	}
 
+	if cfg.Retry.RetryableStatusCodes == nil {
+		cfg.Retry.RetryableStatusCodes = defaultRetryableStatusCodes
+	}
+
+	if cfg.Retry.Backoff.Base <= 0 {
+		cfg.Retry.Backoff = backoff.NewPolicy(cfg.RetryDelay, defaultMaxRetryDelay, backoff.DefaultMultiplier)
+	}
+
 	return &client{
 		httpClient: &http.Client{
-			Timeout: cfg.Timeout,
+			Timeout:   cfg.Timeout,
+			Transport: chain(http.DefaultTransport, cfg.Interceptors),
 		},
 		defaultHeaders: cfg.DefaultHeaders,
 		maxRetries:     cfg.MaxRetries,
-		retryDelay:     cfg.RetryDelay,
+		retry:          cfg.Retry,
+		now:            time.Now,
 	}
 }
 
-// Do executes an HTTP request with retry logic
+// Do executes an HTTP request with retry logic.
+// Transport errors and retryable status codes are retried with backoff; once retries are
+// exhausted the last response (or error) is returned so callers still see the status code.
 func (c *client) Do(ctx context.Context, req *Request) (*Response, error) {
 	if err := c.validateRequest(req); err != nil {
 		return nil, err
 	}
 
-	var lastErr error
-	attempts := c.maxRetries + 1
+	startedAt := c.now()
+	retryable := c.canRetry(req)
 
-	for i := 0; i < attempts; i++ {
-		if i > 0 {
-			select {
-			case <-time.After(c.retryDelay):
-			case <-ctx.Done():
-				return nil, ErrTimeout.WithError(ctx.Err())
-			}
+	for attempt := 1; ; attempt++ {
+		resp, err := c.doRequest(ctx, req)
+		if !retryable || attempt > c.maxRetries || ctx.Err() != nil || !c.shouldRetry(resp, err) {
+			return resp, err
 		}
 
-		resp, err := c.doRequest(ctx, req)
-		if err == nil {
-			return resp, nil
+		delay := c.retryDelay(attempt, resp)
+		if c.retry.MaxElapsedTime > 0 && c.now().Add(delay).Sub(startedAt) > c.retry.MaxElapsedTime {
+			return resp, err
 		}
 
-		lastErr = err
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ErrTimeout.WithError(ctx.Err())
+		}
 	}
-
-	return nil, lastErr
 }
 
 // doRequest executes a single HTTP request
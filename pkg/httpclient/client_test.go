@@ -0,0 +1,200 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/srcndev/message-service/pkg/backoff"
+	"github.com/stretchr/testify/assert"
+)
+
+// fastRetry keeps test retries in the millisecond range
+var fastRetry = backoff.Policy{Base: time.Millisecond, Max: 5 * time.Millisecond, Multiplier: 2}
+
+// newStatusServer responds with the given status codes in order, repeating the last one
+func newStatusServer(t *testing.T, statuses ...int) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1))
+		if n > len(statuses) {
+			n = len(statuses)
+		}
+		w.WriteHeader(statuses[n-1])
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestClient_Do_RetriesRetryableStatus(t *testing.T) {
+	server, calls := newStatusServer(t, http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK)
+	c := NewHTTPClient(Config{MaxRetries: 3, Retry: RetryPolicy{Backoff: fastRetry}})
+
+	resp, err := c.Get(context.Background(), server.URL, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestClient_Do_ReturnsLastResponseWhenRetriesExhausted(t *testing.T) {
+	server, calls := newStatusServer(t, http.StatusServiceUnavailable)
+	c := NewHTTPClient(Config{MaxRetries: 2, Retry: RetryPolicy{Backoff: fastRetry}})
+
+	resp, err := c.Get(context.Background(), server.URL, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestClient_Do_DoesNotRetryClientErrors(t *testing.T) {
+	server, calls := newStatusServer(t, http.StatusBadRequest)
+	c := NewHTTPClient(Config{MaxRetries: 3, Retry: RetryPolicy{Backoff: fastRetry}})
+
+	resp, err := c.Get(context.Background(), server.URL, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestClient_Do_CustomRetryableStatusCodes(t *testing.T) {
+	server, calls := newStatusServer(t, http.StatusInternalServerError, http.StatusOK)
+	c := NewHTTPClient(Config{MaxRetries: 1, Retry: RetryPolicy{
+		Backoff:              fastRetry,
+		RetryableStatusCodes: []int{http.StatusInternalServerError},
+	}})
+
+	resp, err := c.Get(context.Background(), server.URL, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestClient_Do_NonIdempotentRequiresOptIn(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        RetryPolicy
+		idempotent    bool
+		expectedCalls int32
+	}{
+		{name: "POST not retried by default", policy: RetryPolicy{Backoff: fastRetry}, expectedCalls: 1},
+		{name: "POST retried when request opts in", policy: RetryPolicy{Backoff: fastRetry}, idempotent: true, expectedCalls: 3},
+		{name: "POST retried when policy opts in", policy: RetryPolicy{Backoff: fastRetry, RetryNonIdempotent: true}, expectedCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := newStatusServer(t, http.StatusServiceUnavailable)
+			c := NewHTTPClient(Config{MaxRetries: 2, Retry: tt.policy})
+
+			_, err := c.Do(context.Background(), &Request{
+				Method:     http.MethodPost,
+				URL:        server.URL,
+				Body:       map[string]string{"to": "+905551234567"},
+				Idempotent: tt.idempotent,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCalls, atomic.LoadInt32(calls))
+		})
+	}
+}
+
+func TestClient_Do_RetriesTransportErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	c := NewHTTPClient(Config{MaxRetries: 2, Retry: RetryPolicy{Backoff: fastRetry}})
+
+	resp, err := c.Get(context.Background(), url, nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+	assert.Contains(t, err.Error(), ErrCodeHTTPTimeout)
+}
+
+func TestClient_Do_StopsAtMaxElapsedTime(t *testing.T) {
+	server, calls := newStatusServer(t, http.StatusServiceUnavailable)
+	c := NewHTTPClient(Config{MaxRetries: 10, Retry: RetryPolicy{
+		Backoff:        backoff.Policy{Base: 100 * time.Millisecond, Max: 100 * time.Millisecond, Multiplier: 1},
+		MaxElapsedTime: 250 * time.Millisecond,
+	}})
+
+	resp, err := c.Get(context.Background(), server.URL, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestClient_Do_HonorsRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := NewHTTPClient(Config{MaxRetries: 1, Retry: RetryPolicy{Backoff: fastRetry}})
+
+	start := time.Now()
+	resp, err := c.Get(context.Background(), server.URL, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestClient_Do_ContextCancelledDuringBackoff(t *testing.T) {
+	server, _ := newStatusServer(t, http.StatusServiceUnavailable)
+	c := NewHTTPClient(Config{MaxRetries: 3, Retry: RetryPolicy{
+		Backoff: backoff.Policy{Base: time.Second, Max: time.Second, Multiplier: 1},
+	}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	resp, err := c.Get(ctx, server.URL, nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "empty", value: "", ok: false},
+		{name: "seconds", value: "120", expected: 2 * time.Minute, ok: true},
+		{name: "negative seconds", value: "-1", ok: false},
+		{name: "http date", value: "Wed, 01 Jan 2025 12:00:30 GMT", expected: 30 * time.Second, ok: true},
+		{name: "http date in the past", value: "Wed, 01 Jan 2025 11:00:00 GMT", expected: 0, ok: true},
+		{name: "garbage", value: "soon", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, ok := parseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, delay)
+		})
+	}
+}
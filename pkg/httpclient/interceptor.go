@@ -0,0 +1,112 @@
+package httpclient
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/srcndev/message-service/pkg/correlation"
+	"github.com/srcndev/message-service/pkg/logger"
+)
+
+// Interceptor wraps the transport of outgoing requests to observe or modify them
+type Interceptor func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to http.RoundTripper
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// LatencyObserver receives the outcome and duration of every outgoing request
+type LatencyObserver func(req *http.Request, statusCode int, duration time.Duration, err error)
+
+// redactedValue replaces sensitive header values in logs
+const redactedValue = "[REDACTED]"
+
+// defaultRedactedHeaders are always redacted by the logging interceptor
+var defaultRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// chain wraps the transport with interceptors, the first interceptor runs outermost
+func chain(transport http.RoundTripper, interceptors []Interceptor) http.RoundTripper {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		transport = interceptors[i](transport)
+	}
+	return transport
+}
+
+// LoggingInterceptor logs outgoing requests and their responses, redacting auth headers
+// and any additional headers given (e.g. API key headers)
+func LoggingInterceptor(redactHeaders ...string) Interceptor {
+	redact := make(map[string]bool)
+	for _, header := range append(defaultRedactedHeaders, redactHeaders...) {
+		redact[http.CanonicalHeaderKey(header)] = true
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			logger.Debug("HTTP %s %s headers=%s", req.Method, req.URL.Redacted(), formatHeaders(req.Header, redact))
+
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				logger.Error("HTTP %s %s failed: %v", req.Method, req.URL.Redacted(), err)
+				return nil, err
+			}
+
+			logger.Debug("HTTP %s %s -> %d", req.Method, req.URL.Redacted(), resp.StatusCode)
+			return resp, nil
+		})
+	}
+}
+
+// TimingInterceptor reports the latency of every outgoing request to the observer
+func TimingInterceptor(observe LatencyObserver) Interceptor {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+
+			statusCode := 0
+			if resp != nil {
+				statusCode = resp.StatusCode
+			}
+			observe(req, statusCode, time.Since(start), err)
+
+			return resp, err
+		})
+	}
+}
+
+// CorrelationIDInterceptor propagates the correlation ID from the request context as a header
+func CorrelationIDInterceptor() Interceptor {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			id := correlation.FromContext(req.Context())
+			if id == "" || req.Header.Get(correlation.Header) != "" {
+				return next.RoundTrip(req)
+			}
+
+			// RoundTrippers must not modify the caller's request
+			req = req.Clone(req.Context())
+			req.Header.Set(correlation.Header, id)
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// formatHeaders renders headers for logging with sensitive values redacted
+func formatHeaders(headers http.Header, redact map[string]bool) string {
+	parts := make([]string, 0, len(headers))
+	for key, values := range headers {
+		value := strings.Join(values, ",")
+		if redact[http.CanonicalHeaderKey(key)] {
+			value = redactedValue
+		}
+		parts = append(parts, key+"="+value)
+	}
+	sort.Strings(parts)
+	return "{" + strings.Join(parts, ", ") + "}"
+}
@@ -0,0 +1,124 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/srcndev/message-service/pkg/correlation"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingInterceptor appends its name to the order slice when a request passes through
+func recordingInterceptor(name string, order *[]string) Interceptor {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			*order = append(*order, name)
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// newHeaderServer responds 200 and captures the headers of the last request
+func newHeaderServer(t *testing.T) (*httptest.Server, *http.Header) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &received
+}
+
+func TestClient_Interceptors_RunInOrder(t *testing.T) {
+	server, _ := newHeaderServer(t)
+	var order []string
+	c := NewHTTPClient(Config{Interceptors: []Interceptor{
+		recordingInterceptor("first", &order),
+		recordingInterceptor("second", &order),
+	}})
+
+	_, err := c.Get(context.Background(), server.URL, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, order)
+}
+
+func TestCorrelationIDInterceptor_PropagatesContextID(t *testing.T) {
+	server, received := newHeaderServer(t)
+	c := NewHTTPClient(Config{Interceptors: []Interceptor{CorrelationIDInterceptor()}})
+	ctx := correlation.WithID(context.Background(), "abc123")
+
+	_, err := c.Get(ctx, server.URL, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "abc123", received.Get(correlation.Header))
+}
+
+func TestCorrelationIDInterceptor_KeepsExplicitHeader(t *testing.T) {
+	server, received := newHeaderServer(t)
+	c := NewHTTPClient(Config{Interceptors: []Interceptor{CorrelationIDInterceptor()}})
+	ctx := correlation.WithID(context.Background(), "from-context")
+
+	_, err := c.Get(ctx, server.URL, map[string]string{correlation.Header: "explicit"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "explicit", received.Get(correlation.Header))
+}
+
+func TestCorrelationIDInterceptor_NoIDInContext(t *testing.T) {
+	server, received := newHeaderServer(t)
+	c := NewHTTPClient(Config{Interceptors: []Interceptor{CorrelationIDInterceptor()}})
+
+	_, err := c.Get(context.Background(), server.URL, nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, received.Get(correlation.Header))
+}
+
+func TestTimingInterceptor_ObservesEveryAttempt(t *testing.T) {
+	server, _ := newStatusServer(t, http.StatusServiceUnavailable, http.StatusOK)
+	var statuses []int
+	observer := func(req *http.Request, statusCode int, duration time.Duration, err error) {
+		statuses = append(statuses, statusCode)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, duration, time.Duration(0))
+	}
+	c := NewHTTPClient(Config{
+		MaxRetries:   1,
+		Retry:        RetryPolicy{Backoff: fastRetry},
+		Interceptors: []Interceptor{TimingInterceptor(observer)},
+	})
+
+	_, err := c.Get(context.Background(), server.URL, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int{http.StatusServiceUnavailable, http.StatusOK}, statuses)
+}
+
+func TestLoggingInterceptor_PassesResponseThrough(t *testing.T) {
+	server, received := newHeaderServer(t)
+	c := NewHTTPClient(Config{Interceptors: []Interceptor{LoggingInterceptor("x-api-key")}})
+
+	resp, err := c.Get(context.Background(), server.URL, map[string]string{"x-api-key": "secret"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "secret", received.Get("x-api-key"))
+}
+
+func TestFormatHeaders_RedactsSensitiveHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer token")
+	headers.Set("X-Api-Key", "secret")
+	headers.Set("Content-Type", "application/json")
+	redact := map[string]bool{"Authorization": true, "X-Api-Key": true}
+
+	got := formatHeaders(headers, redact)
+
+	assert.Equal(t, "{Authorization=[REDACTED], Content-Type=application/json, X-Api-Key=[REDACTED]}", got)
+	assert.NotContains(t, got, "secret")
+	assert.NotContains(t, got, "token")
+}
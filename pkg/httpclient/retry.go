@@ -0,0 +1,69 @@
+package httpclient
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// defaultMaxRetryDelay caps the backoff between attempts of a single request
+const defaultMaxRetryDelay = 30 * time.Second
+
+// defaultRetryableStatusCodes are transient responses worth retrying
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// canRetry reports whether the request may be sent more than once
+func (c *client) canRetry(req *Request) bool {
+	switch req.Method {
+	case http.MethodPost, http.MethodPatch:
+		return req.Idempotent || c.retry.RetryNonIdempotent
+	}
+	return true
+}
+
+// shouldRetry reports whether the outcome of an attempt is transient
+func (c *client) shouldRetry(resp *Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return slices.Contains(c.retry.RetryableStatusCodes, resp.StatusCode)
+}
+
+// retryDelay returns the wait before the next attempt, preferring the server's Retry-After
+func (c *client) retryDelay(attempt int, resp *Response) time.Duration {
+	if resp != nil {
+		if delay, ok := parseRetryAfter(http.Header(resp.Headers).Get("Retry-After"), c.now()); ok {
+			return delay
+		}
+	}
+	return c.retry.Backoff.Next(attempt)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		if delay := at.Sub(now); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
@@ -2,6 +2,8 @@ package httpclient
 
 import (
 	"time"
+
+	"github.com/srcndev/message-service/pkg/backoff"
 )
 
 // Request represents an HTTP request
@@ -10,6 +12,9 @@ type Request struct {
 	URL     string
 	Headers map[string]string
 	Body    any
+
+	// Idempotent opts a non-idempotent method (POST, PATCH) into retries for this request
+	Idempotent bool
 }
 
 // Response represents an HTTP response
@@ -25,4 +30,23 @@ type Config struct {
 	MaxRetries     int
 	RetryDelay     time.Duration
 	DefaultHeaders map[string]string
+	Retry          RetryPolicy
+
+	// Interceptors wrap the transport of every attempt, the first one runs outermost
+	Interceptors []Interceptor
+}
+
+// RetryPolicy controls which failures are retried and how long to wait between attempts
+type RetryPolicy struct {
+	// RetryableStatusCodes are response codes that are retried (default: 429, 502, 503, 504)
+	RetryableStatusCodes []int
+
+	// Backoff computes the delay between attempts (default: exponential from RetryDelay)
+	Backoff backoff.Policy
+
+	// MaxElapsedTime stops retrying once the next attempt would start after this budget (0 = no limit)
+	MaxElapsedTime time.Duration
+
+	// RetryNonIdempotent retries POST and PATCH requests without a per-request opt-in
+	RetryNonIdempotent bool
 }
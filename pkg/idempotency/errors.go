@@ -0,0 +1,58 @@
+package idempotency
+
+import (
+	"net/http"
+
+	"github.com/srcndev/message-service/pkg/customerror"
+)
+
+// Error codes
+const (
+	ErrCodeKeyInvalid        = "IDEMPOTENCY_KEY_INVALID"
+	ErrCodeKeyMismatch       = "IDEMPOTENCY_KEY_MISMATCH"
+	ErrCodeRequestInProgress = "IDEMPOTENCY_REQUEST_IN_PROGRESS"
+	ErrCodeRequestTooLarge   = "IDEMPOTENCY_REQUEST_TOO_LARGE"
+	ErrCodeStoreUnavailable  = "IDEMPOTENCY_STORE_UNAVAILABLE"
+)
+
+// Error messages
+const (
+	MsgKeyInvalid        = "Idempotency key must be between 1 and 255 characters"
+	MsgKeyMismatch       = "Idempotency key was already used with a different request"
+	MsgRequestInProgress = "A request with this idempotency key is still being processed"
+	MsgRequestTooLarge   = "Request body is too large to be sent with an idempotency key"
+	MsgStoreUnavailable  = "Idempotency store is unavailable"
+)
+
+// Predefined errors
+var (
+	ErrKeyInvalid = customerror.NewCustomError(
+		ErrCodeKeyInvalid,
+		MsgKeyInvalid,
+		http.StatusBadRequest,
+	)
+
+	ErrKeyMismatch = customerror.NewCustomError(
+		ErrCodeKeyMismatch,
+		MsgKeyMismatch,
+		http.StatusUnprocessableEntity,
+	)
+
+	ErrRequestInProgress = customerror.NewCustomError(
+		ErrCodeRequestInProgress,
+		MsgRequestInProgress,
+		http.StatusConflict,
+	)
+
+	ErrRequestTooLarge = customerror.NewCustomError(
+		ErrCodeRequestTooLarge,
+		MsgRequestTooLarge,
+		http.StatusRequestEntityTooLarge,
+	)
+
+	ErrStoreUnavailable = customerror.NewCustomError(
+		ErrCodeStoreUnavailable,
+		MsgStoreUnavailable,
+		http.StatusServiceUnavailable,
+	)
+)
@@ -0,0 +1,140 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/srcndev/message-service/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// purgeInterval is how often expired records are deleted from the table
+const purgeInterval = time.Hour
+
+// PostgresRecord is the table row of an idempotency key, migrated with the application models
+type PostgresRecord struct {
+	Key         string    `gorm:"primaryKey;size:64"`
+	Fingerprint string    `gorm:"size:64;not null"`
+	Completed   bool      `gorm:"not null;default:false"`
+	StatusCode  int       `gorm:"not null;default:0"`
+	ContentType string    `gorm:"size:255"`
+	Body        []byte    `gorm:"type:bytea"`
+	ExpiresAt   time.Time `gorm:"not null;index"`
+	CreatedAt   time.Time
+}
+
+// TableName specifies the table name for GORM
+func (PostgresRecord) TableName() string {
+	return "idempotency_keys"
+}
+
+// postgresStore keeps records in Postgres, expired rows are taken over and purged periodically
+type postgresStore struct {
+	db  *gorm.DB
+	now func() time.Time
+
+	mu         sync.Mutex
+	lastPurged time.Time
+}
+
+// Compile-time interface compliance check
+var _ Store = (*postgresStore)(nil)
+
+// NewPostgresStore creates a store that keeps records in the idempotency_keys table
+func NewPostgresStore(db *gorm.DB) Store {
+	return &postgresStore{
+		db:         db,
+		now:        time.Now,
+		lastPurged: time.Now(),
+	}
+}
+
+// Reserve inserts the reservation, or takes over the row when it has expired, and reads the row otherwise
+func (s *postgresStore) Reserve(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*Record, error) {
+	now := s.now()
+	s.purgeExpired(ctx, now)
+
+	row := PostgresRecord{
+		Key:         key,
+		Fingerprint: fingerprint,
+		ExpiresAt:   now.Add(lockTTL),
+		CreatedAt:   now,
+	}
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"fingerprint", "completed", "status_code", "content_type", "body", "expires_at", "created_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Lt{Column: clause.Column{Table: row.TableName(), Name: "expires_at"}, Value: now},
+		}},
+	}).Create(&row)
+	if result.Error != nil {
+		return nil, ErrStoreUnavailable.WithError(result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil, nil
+	}
+
+	var existing PostgresRecord
+	err := s.db.WithContext(ctx).Where("key = ?", key).Take(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Released between the insert and the read, the client may retry right away
+		return &Record{Fingerprint: fingerprint}, nil
+	}
+	if err != nil {
+		return nil, ErrStoreUnavailable.WithError(err)
+	}
+
+	return &Record{
+		Fingerprint: existing.Fingerprint,
+		Completed:   existing.Completed,
+		StatusCode:  existing.StatusCode,
+		ContentType: existing.ContentType,
+		Body:        existing.Body,
+	}, nil
+}
+
+// Complete stores the response on the reserved row
+func (s *postgresStore) Complete(ctx context.Context, key string, record Record, ttl time.Duration) error {
+	err := s.db.WithContext(ctx).Model(&PostgresRecord{}).Where("key = ?", key).Updates(map[string]interface{}{
+		"completed":    true,
+		"status_code":  record.StatusCode,
+		"content_type": record.ContentType,
+		"body":         record.Body,
+		"expires_at":   s.now().Add(ttl),
+	}).Error
+	if err != nil {
+		return ErrStoreUnavailable.WithError(err)
+	}
+	return nil
+}
+
+// Release deletes the row
+func (s *postgresStore) Release(ctx context.Context, key string) error {
+	if err := s.db.WithContext(ctx).Where("key = ?", key).Delete(&PostgresRecord{}).Error; err != nil {
+		return ErrStoreUnavailable.WithError(err)
+	}
+	return nil
+}
+
+// purgeExpired deletes expired rows at most once per purgeInterval, failures are retried on the next interval
+func (s *postgresStore) purgeExpired(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	if now.Sub(s.lastPurged) < purgeInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastPurged = now
+	s.mu.Unlock()
+
+	result := s.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&PostgresRecord{})
+	if result.Error != nil {
+		logger.Error("Failed to purge expired idempotency keys: %v", result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		logger.Debug("Purged %d expired idempotency keys", result.RowsAffected)
+	}
+}
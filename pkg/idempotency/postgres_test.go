@@ -0,0 +1,132 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupPostgresStore(t *testing.T) (*postgresStore, sqlmock.Sqlmock, func()) {
+	sqlDB, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB, DriverName: "postgres"}), &gorm.Config{})
+	assert.NoError(t, err)
+
+	now := time.Now()
+	store := NewPostgresStore(db).(*postgresStore)
+	store.now = func() time.Time { return now }
+
+	return store, mock, func() { sqlDB.Close() }
+}
+
+func TestPostgresStore_Reserve_FreeKey(t *testing.T) {
+	store, mock, cleanup := setupPostgresStore(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "idempotency_keys"`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	record, err := store.Reserve(context.Background(), "key", "fp", time.Minute)
+
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_Reserve_ExistingKey(t *testing.T) {
+	store, mock, cleanup := setupPostgresStore(t)
+	defer cleanup()
+
+	// The row has not expired, so the conflict leaves it untouched and it is read back
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`ON CONFLICT ("key") DO UPDATE SET`) + `.*` + regexp.QuoteMeta(`WHERE "idempotency_keys"."expires_at" < $9`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "idempotency_keys" WHERE key = $1 LIMIT $2`)).
+		WithArgs("key", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "fingerprint", "completed", "status_code", "content_type", "body"}).
+			AddRow("key", "fp", true, 201, "application/json", []byte(`{}`)))
+
+	record, err := store.Reserve(context.Background(), "key", "fp", time.Minute)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Record{Fingerprint: "fp", Completed: true, StatusCode: 201, ContentType: "application/json", Body: []byte(`{}`)}, record)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_Reserve_PurgesExpired(t *testing.T) {
+	store, mock, cleanup := setupPostgresStore(t)
+	defer cleanup()
+
+	store.lastPurged = store.now().Add(-purgeInterval)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "idempotency_keys" WHERE expires_at < $1`)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "idempotency_keys"`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	_, err := store.Reserve(context.Background(), "key", "fp", time.Minute)
+
+	assert.NoError(t, err)
+	assert.Equal(t, store.now(), store.lastPurged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_Reserve_Error(t *testing.T) {
+	store, mock, cleanup := setupPostgresStore(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "idempotency_keys"`)).
+		WillReturnError(errors.New("connection refused"))
+	mock.ExpectRollback()
+
+	_, err := store.Reserve(context.Background(), "key", "fp", time.Minute)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrCodeStoreUnavailable)
+}
+
+func TestPostgresStore_Complete(t *testing.T) {
+	store, mock, cleanup := setupPostgresStore(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "idempotency_keys" SET "body"=$1,"completed"=$2,"content_type"=$3,"expires_at"=$4,"status_code"=$5 WHERE key = $6`)).
+		WithArgs([]byte(`{}`), true, "application/json", store.now().Add(time.Hour), 201, "key").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := store.Complete(context.Background(), "key", Record{StatusCode: 201, ContentType: "application/json", Body: []byte(`{}`)}, time.Hour)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresStore_Release(t *testing.T) {
+	store, mock, cleanup := setupPostgresStore(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "idempotency_keys" WHERE key = $1`)).
+		WithArgs("key").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, store.Release(context.Background(), "key"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
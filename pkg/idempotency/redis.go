@@ -0,0 +1,84 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/srcndev/message-service/pkg/redis"
+)
+
+// reserveScript stores the reservation unless the key exists and returns the existing record otherwise
+const reserveScript = `
+if redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+	return false
+end
+return redis.call('GET', KEYS[1])
+`
+
+// redisStore keeps records in Redis, expiry is left to key TTLs
+type redisStore struct {
+	client    redis.Client
+	keyPrefix string
+}
+
+// Compile-time interface compliance check
+var _ Store = (*redisStore)(nil)
+
+// NewRedisStore creates a store that keeps records in Redis
+func NewRedisStore(client redis.Client, keyPrefix string) Store {
+	return &redisStore{
+		client:    client,
+		keyPrefix: keyPrefix,
+	}
+}
+
+// Reserve sets the key if it is free, atomically with reading the record that holds it
+func (s *redisStore) Reserve(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*Record, error) {
+	value, err := json.Marshal(Record{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, ErrStoreUnavailable.WithError(err)
+	}
+
+	result, err := s.client.Eval(ctx, reserveScript, []string{s.keyPrefix + key}, string(value), lockTTL.Milliseconds())
+	if err != nil {
+		return nil, ErrStoreUnavailable.WithError(err)
+	}
+	if result == nil {
+		return nil, nil
+	}
+
+	existing, ok := result.(string)
+	if !ok {
+		return nil, ErrStoreUnavailable.WithError(fmt.Errorf("unexpected script result: %v", result))
+	}
+
+	var record Record
+	if err := json.Unmarshal([]byte(existing), &record); err != nil {
+		return nil, ErrStoreUnavailable.WithError(err)
+	}
+	return &record, nil
+}
+
+// Complete overwrites the reservation with the response
+func (s *redisStore) Complete(ctx context.Context, key string, record Record, ttl time.Duration) error {
+	record.Completed = true
+	value, err := json.Marshal(record)
+	if err != nil {
+		return ErrStoreUnavailable.WithError(err)
+	}
+
+	if err := s.client.Set(ctx, s.keyPrefix+key, string(value), ttl); err != nil {
+		return ErrStoreUnavailable.WithError(err)
+	}
+	return nil
+}
+
+// Release deletes the key
+func (s *redisStore) Release(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, s.keyPrefix+key); err != nil {
+		return ErrStoreUnavailable.WithError(err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// testRedisClient implements redis.Client for testing
+type testRedisClient struct {
+	rdb *goredis.Client
+}
+
+func (c *testRedisClient) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return c.rdb.Set(ctx, key, value, expiration).Err()
+}
+
+func (c *testRedisClient) Get(ctx context.Context, key string) (string, error) {
+	return c.rdb.Get(ctx, key).Result()
+}
+
+func (c *testRedisClient) Del(ctx context.Context, keys ...string) error {
+	return c.rdb.Del(ctx, keys...).Err()
+}
+
+func (c *testRedisClient) Exists(ctx context.Context, keys ...string) (int64, error) {
+	return c.rdb.Exists(ctx, keys...).Result()
+}
+
+// Eval returns a nil result for a nil reply like the real client
+func (c *testRedisClient) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	result, err := c.rdb.Eval(ctx, script, keys, args...).Result()
+	if err == goredis.Nil {
+		return nil, nil
+	}
+	return result, err
+}
+
+func (c *testRedisClient) Close() error {
+	return c.rdb.Close()
+}
+
+func (c *testRedisClient) Ping(ctx context.Context) error {
+	return c.rdb.Ping(ctx).Err()
+}
+
+func setupRedisStore(t *testing.T) (*miniredis.Miniredis, Store) {
+	mr := miniredis.RunT(t)
+	client := &testRedisClient{rdb: goredis.NewClient(&goredis.Options{Addr: mr.Addr(), MaxRetries: -1})}
+	return mr, NewRedisStore(client, "test:")
+}
+
+func TestRedisStore_Reserve_FreeKey(t *testing.T) {
+	mr, store := setupRedisStore(t)
+
+	record, err := store.Reserve(context.Background(), "key", "fp", time.Minute)
+
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+	assert.True(t, mr.Exists("test:key"))
+	assert.Equal(t, time.Minute, mr.TTL("test:key"))
+}
+
+func TestRedisStore_Reserve_InProgress(t *testing.T) {
+	_, store := setupRedisStore(t)
+	ctx := context.Background()
+
+	_, err := store.Reserve(ctx, "key", "fp", time.Minute)
+	assert.NoError(t, err)
+
+	record, err := store.Reserve(ctx, "key", "other", time.Minute)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Record{Fingerprint: "fp"}, record)
+}
+
+func TestRedisStore_Complete_Replays(t *testing.T) {
+	mr, store := setupRedisStore(t)
+	ctx := context.Background()
+
+	_, err := store.Reserve(ctx, "key", "fp", time.Minute)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Complete(ctx, "key", Record{
+		Fingerprint: "fp",
+		StatusCode:  201,
+		ContentType: "application/json",
+		Body:        []byte(`{"success":true}`),
+	}, 24*time.Hour))
+
+	record, err := store.Reserve(ctx, "key", "fp", time.Minute)
+
+	assert.NoError(t, err)
+	assert.True(t, record.Completed)
+	assert.Equal(t, 201, record.StatusCode)
+	assert.Equal(t, "application/json", record.ContentType)
+	assert.Equal(t, `{"success":true}`, string(record.Body))
+	assert.Equal(t, 24*time.Hour, mr.TTL("test:key"))
+}
+
+func TestRedisStore_Release(t *testing.T) {
+	_, store := setupRedisStore(t)
+	ctx := context.Background()
+
+	_, err := store.Reserve(ctx, "key", "fp", time.Minute)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Release(ctx, "key"))
+
+	record, err := store.Reserve(ctx, "key", "fp", time.Minute)
+
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+}
+
+func TestRedisStore_Reserve_Unavailable(t *testing.T) {
+	mr, store := setupRedisStore(t)
+	mr.Close()
+
+	_, err := store.Reserve(context.Background(), "key", "fp", time.Minute)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrCodeStoreUnavailable)
+}
@@ -0,0 +1,33 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+)
+
+// Store keeps the responses of requests sent with an idempotency key
+type Store interface {
+	// Reserve claims key for a request with the given fingerprint until lockTTL passes.
+	// It returns nil when the key was free, otherwise the record already stored under key.
+	Reserve(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*Record, error)
+
+	// Complete stores the response of the request holding key, it is replayed until ttl passes
+	Complete(ctx context.Context, key string, record Record, ttl time.Duration) error
+
+	// Release frees key so the request can be sent again
+	Release(ctx context.Context, key string) error
+}
+
+// Record is the state of an idempotency key
+type Record struct {
+	// Fingerprint identifies the request the key was first used with
+	Fingerprint string `json:"fingerprint"`
+
+	// Completed is false while the first request is still running
+	Completed bool `json:"completed"`
+
+	// StatusCode, ContentType and Body are the stored response
+	StatusCode  int    `json:"statusCode,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/srcndev/message-service/pkg/correlation"
+)
+
+// CorrelationID is a middleware that reads or generates the request correlation ID,
+// stores it in the request context and echoes it in the response header
+func CorrelationID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(correlation.Header)
+		if id == "" {
+			id = correlation.NewID()
+		}
+
+		c.Request = c.Request.WithContext(correlation.WithID(c.Request.Context(), id))
+		c.Header(correlation.Header, id)
+
+		c.Next()
+	}
+}
@@ -14,20 +14,23 @@ func ErrorHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
 
-		// Check if there are any errors
-		if len(c.Errors) > 0 {
-			err := c.Errors.Last().Err
-
-			var appErr *customerror.CustomError
-			if errors.As(err, &appErr) {
-				logger.Error("[%s] %s - %s", appErr.Code, appErr.Message, c.Request.URL.Path)
-				customresponse.Error(c, appErr.GetStatusCode(), appErr.Code, appErr.Message)
-				return
-			}
-
-			// Fallback for unknown errors
-			logger.Error("[INTERNAL_ERROR] Unhandled error: %v - %s", err, c.Request.URL.Path)
-			customresponse.Error(c, 500, "INTERNAL_ERROR", "Internal server error")
+		// Check if there are any errors, a middleware closer to the handler may have answered already
+		if len(c.Errors) > 0 && !c.Writer.Written() {
+			writeError(c, c.Errors.Last().Err)
 		}
 	}
 }
+
+// writeError sends the error response of a handler error
+func writeError(c *gin.Context, err error) {
+	var appErr *customerror.CustomError
+	if errors.As(err, &appErr) {
+		logger.Error("[%s] %s - %s", appErr.Code, appErr.Message, c.Request.URL.Path)
+		customresponse.Error(c, appErr.GetStatusCode(), appErr.Code, appErr.Message)
+		return
+	}
+
+	// Fallback for unknown errors
+	logger.Error("[INTERNAL_ERROR] Unhandled error: %v - %s", err, c.Request.URL.Path)
+	customresponse.Error(c, 500, "INTERNAL_ERROR", "Internal server error")
+}
@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srcndev/message-service/pkg/idempotency"
+	"github.com/srcndev/message-service/pkg/logger"
+)
+
+// Idempotency headers
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"     // Key chosen by the client for a request and its retries
+	IdempotentReplayedHeader = "Idempotent-Replayed" // Set on responses replayed from the store
+)
+
+// Limits of requests sent with an idempotency key
+const (
+	maxIdempotencyKeyLength   = 255
+	maxIdempotentRequestBytes = 1 << 20
+)
+
+// IdempotencyConfig holds the settings of the idempotency middleware
+type IdempotencyConfig struct {
+	Store   idempotency.Store
+	TTL     time.Duration // How long a response is replayed for its key
+	LockTTL time.Duration // How long a key stays reserved while its first request runs
+
+	// ClientKey scopes keys per client so clients cannot replay each other's responses (default: client IP)
+	ClientKey func(c *gin.Context) string
+}
+
+// Idempotency is a middleware that stores the response of a POST request sent with an Idempotency-Key header
+// and replays it, with the same status, for retries of the request. Reusing a key with a different request
+// is rejected with 422 and a retry while the first request runs with 409. Client errors (4xx) are stored like
+// any other response, only a 5xx or a panic releases the key so the request can be retried with it.
+func Idempotency(cfg IdempotencyConfig) gin.HandlerFunc {
+	clientKey := cfg.ClientKey
+	if clientKey == nil {
+		clientKey = func(c *gin.Context) string { return c.ClientIP() }
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || c.Request.Method != http.MethodPost {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			abortWithError(c, idempotency.ErrKeyInvalid)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxIdempotentRequestBytes+1))
+		if err != nil {
+			abortWithError(c, idempotency.ErrRequestTooLarge.WithError(err))
+			return
+		}
+		if len(body) > maxIdempotentRequestBytes {
+			abortWithError(c, idempotency.ErrRequestTooLarge)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		storeKey := hashParts(clientKey(c), key)
+		fingerprint := hashParts(c.Request.Method, c.Request.URL.RequestURI(), string(body))
+
+		record, err := cfg.Store.Reserve(c.Request.Context(), storeKey, fingerprint, cfg.LockTTL)
+		if err != nil {
+			abortWithError(c, err)
+			return
+		}
+		if record != nil {
+			replay(c, record, fingerprint)
+			return
+		}
+
+		// The store is updated even when the client gave up waiting, that is the retry this is for
+		ctx := context.WithoutCancel(c.Request.Context())
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		// A panic skips the rest of this function and leaves stored false, releasing the key
+		stored := false
+		defer func() {
+			if stored {
+				return
+			}
+			if err := cfg.Store.Release(ctx, storeKey); err != nil {
+				logger.Error("Failed to release idempotency key: %v", err)
+			}
+		}()
+
+		c.Next()
+
+		// Write a handler error here so its response is stored, the error handler skips written responses
+		if len(c.Errors) > 0 && !recorder.Written() {
+			writeError(c, c.Errors.Last().Err)
+		}
+		if recorder.Status() >= http.StatusInternalServerError {
+			return
+		}
+
+		// A failed write keeps the reservation until LockTTL, releasing it would let a retry run twice
+		stored = true
+		err = cfg.Store.Complete(ctx, storeKey, idempotency.Record{
+			Fingerprint: fingerprint,
+			StatusCode:  recorder.Status(),
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		}, cfg.TTL)
+		if err != nil {
+			logger.Error("Failed to store idempotent response: %v", err)
+		}
+	}
+}
+
+// replay writes the stored response, or rejects the request when the key cannot be replayed
+func replay(c *gin.Context, record *idempotency.Record, fingerprint string) {
+	if record.Fingerprint != fingerprint {
+		abortWithError(c, idempotency.ErrKeyMismatch)
+		return
+	}
+	if !record.Completed {
+		abortWithError(c, idempotency.ErrRequestInProgress)
+		return
+	}
+
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Data(record.StatusCode, record.ContentType, record.Body)
+	c.Abort()
+}
+
+// abortWithError stops the chain and leaves the error to the error handler
+func abortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// hashParts returns the hex SHA-256 of the parts, separated so that their boundaries count
+func hashParts(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder copies the response body while it is written
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,237 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/srcndev/message-service/pkg/customerror"
+	"github.com/srcndev/message-service/pkg/customresponse"
+	"github.com/srcndev/message-service/pkg/idempotency"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStore implements idempotency.Store for testing
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]idempotency.Record
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: make(map[string]idempotency.Record)}
+}
+
+func (s *memoryStore) Reserve(_ context.Context, key, fingerprint string, _ time.Duration) (*idempotency.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if record, ok := s.records[key]; ok {
+		return &record, nil
+	}
+	s.records[key] = idempotency.Record{Fingerprint: fingerprint}
+	return nil, nil
+}
+
+func (s *memoryStore) Complete(_ context.Context, key string, record idempotency.Record, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record.Completed = true
+	s.records[key] = record
+	return nil
+}
+
+func (s *memoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+// setupIdempotentRouter serves POST /messages, which counts its calls, behind the idempotency middleware
+func setupIdempotentRouter(store idempotency.Store, calls *int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(gin.Recovery(), ErrorHandler())
+	group := router.Group("/api", Idempotency(IdempotencyConfig{
+		Store:     store,
+		TTL:       time.Hour,
+		LockTTL:   time.Minute,
+		ClientKey: func(c *gin.Context) string { return c.GetHeader("X-Client") },
+	}))
+
+	handle := func(c *gin.Context) {
+		*calls++
+		switch c.Query("fail") {
+		case "app":
+			c.Error(customerror.NewCustomError("MESSAGE_CREATE_FAILED", "Failed to create message", http.StatusInternalServerError))
+			return
+		case "server":
+			customresponse.Error(c, http.StatusServiceUnavailable, "UNAVAILABLE", "unavailable")
+			return
+		case "client":
+			c.Error(customerror.NewCustomError("RECIPIENT_SUPPRESSED", "Recipient is suppressed", http.StatusUnprocessableEntity))
+			return
+		case "panic":
+			panic("handler crashed")
+		}
+		customresponse.Success(c, http.StatusCreated, gin.H{"id": *calls})
+	}
+	group.POST("/messages", handle)
+	group.GET("/messages", handle)
+	return router
+}
+
+func sendIdempotent(router *gin.Engine, method, path, client, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Client", client)
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func assertErrorCode(t *testing.T, w *httptest.ResponseRecorder, status int, code string) {
+	var resp customresponse.CustomResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, status, w.Code)
+	assert.Equal(t, code, resp.Error.Code)
+}
+
+func TestIdempotency_ReplaysResponse(t *testing.T) {
+	calls := 0
+	router := setupIdempotentRouter(newMemoryStore(), &calls)
+
+	first := sendIdempotent(router, http.MethodPost, "/api/messages", "a", "key-1", `{"content":"hi"}`)
+	retry := sendIdempotent(router, http.MethodPost, "/api/messages", "a", "key-1", `{"content":"hi"}`)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, first.Header().Get("Content-Type"), retry.Header().Get("Content-Type"))
+	assert.JSONEq(t, first.Body.String(), retry.Body.String())
+}
+
+func TestIdempotency_DifferentRequestSameKey(t *testing.T) {
+	calls := 0
+	router := setupIdempotentRouter(newMemoryStore(), &calls)
+
+	sendIdempotent(router, http.MethodPost, "/api/messages", "a", "key-1", `{"content":"hi"}`)
+	w := sendIdempotent(router, http.MethodPost, "/api/messages", "a", "key-1", `{"content":"bye"}`)
+
+	assert.Equal(t, 1, calls)
+	assertErrorCode(t, w, http.StatusUnprocessableEntity, idempotency.ErrCodeKeyMismatch)
+}
+
+func TestIdempotency_RequestInProgress(t *testing.T) {
+	calls := 0
+	store := newMemoryStore()
+	router := setupIdempotentRouter(store, &calls)
+
+	// Hold the reservation as if the first request were still running
+	fingerprint := hashParts(http.MethodPost, "/api/messages", `{"content":"hi"}`)
+	_, err := store.Reserve(context.Background(), hashParts("a", "key-1"), fingerprint, time.Minute)
+	assert.NoError(t, err)
+
+	w := sendIdempotent(router, http.MethodPost, "/api/messages", "a", "key-1", `{"content":"hi"}`)
+
+	assert.Equal(t, 0, calls)
+	assertErrorCode(t, w, http.StatusConflict, idempotency.ErrCodeRequestInProgress)
+}
+
+func TestIdempotency_KeysAreScopedPerClient(t *testing.T) {
+	calls := 0
+	router := setupIdempotentRouter(newMemoryStore(), &calls)
+
+	sendIdempotent(router, http.MethodPost, "/api/messages", "a", "key-1", `{"content":"hi"}`)
+	w := sendIdempotent(router, http.MethodPost, "/api/messages", "b", "key-1", `{"content":"hi"}`)
+
+	assert.Equal(t, 2, calls)
+	assert.Empty(t, w.Header().Get(IdempotentReplayedHeader))
+}
+
+func TestIdempotency_FailedRequestReleasesKey(t *testing.T) {
+	for _, fail := range []string{"app", "server", "panic"} {
+		t.Run(fail, func(t *testing.T) {
+			calls := 0
+			router := setupIdempotentRouter(newMemoryStore(), &calls)
+
+			first := sendIdempotent(router, http.MethodPost, "/api/messages?fail="+fail, "a", "key-1", `{}`)
+			retry := sendIdempotent(router, http.MethodPost, "/api/messages?fail="+fail, "a", "key-1", `{}`)
+
+			assert.Equal(t, 2, calls)
+			assert.GreaterOrEqual(t, first.Code, http.StatusInternalServerError)
+			assert.Empty(t, retry.Header().Get(IdempotentReplayedHeader))
+		})
+	}
+}
+
+func TestIdempotency_ClientErrorIsReplayed(t *testing.T) {
+	calls := 0
+	router := setupIdempotentRouter(newMemoryStore(), &calls)
+
+	first := sendIdempotent(router, http.MethodPost, "/api/messages?fail=client", "a", "key-1", `{}`)
+	retry := sendIdempotent(router, http.MethodPost, "/api/messages?fail=client", "a", "key-1", `{}`)
+
+	assert.Equal(t, 1, calls)
+	assertErrorCode(t, first, http.StatusUnprocessableEntity, "RECIPIENT_SUPPRESSED")
+	assertErrorCode(t, retry, http.StatusUnprocessableEntity, "RECIPIENT_SUPPRESSED")
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get(IdempotentReplayedHeader))
+}
+
+func TestIdempotency_WithoutKey(t *testing.T) {
+	calls := 0
+	router := setupIdempotentRouter(newMemoryStore(), &calls)
+
+	sendIdempotent(router, http.MethodPost, "/api/messages", "a", "", `{}`)
+	sendIdempotent(router, http.MethodPost, "/api/messages", "a", "", `{}`)
+	sendIdempotent(router, http.MethodGet, "/api/messages", "a", "key-1", "")
+	sendIdempotent(router, http.MethodGet, "/api/messages", "a", "key-1", "")
+
+	assert.Equal(t, 4, calls)
+}
+
+func TestIdempotency_InvalidRequests(t *testing.T) {
+	calls := 0
+	router := setupIdempotentRouter(newMemoryStore(), &calls)
+
+	w := sendIdempotent(router, http.MethodPost, "/api/messages", "a", strings.Repeat("k", maxIdempotencyKeyLength+1), `{}`)
+	assertErrorCode(t, w, http.StatusBadRequest, idempotency.ErrCodeKeyInvalid)
+
+	w = sendIdempotent(router, http.MethodPost, "/api/messages", "a", "key-1", strings.Repeat(" ", maxIdempotentRequestBytes+1))
+	assertErrorCode(t, w, http.StatusRequestEntityTooLarge, idempotency.ErrCodeRequestTooLarge)
+
+	assert.Equal(t, 0, calls)
+}
+
+// failingStore is an idempotency store that cannot be reached
+type failingStore struct{ memoryStore }
+
+func (s *failingStore) Reserve(context.Context, string, string, time.Duration) (*idempotency.Record, error) {
+	return nil, idempotency.ErrStoreUnavailable.WithError(errors.New("connection refused"))
+}
+
+func TestIdempotency_StoreUnavailable(t *testing.T) {
+	calls := 0
+	router := setupIdempotentRouter(&failingStore{}, &calls)
+
+	w := sendIdempotent(router, http.MethodPost, "/api/messages", "a", "key-1", `{}`)
+
+	assert.Equal(t, 0, calls)
+	assertErrorCode(t, w, http.StatusServiceUnavailable, idempotency.ErrCodeStoreUnavailable)
+}
@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"net/http"
+
+	"github.com/srcndev/message-service/pkg/customerror"
+)
+
+// Error codes
+const (
+	ErrCodeNoProvider = "PROVIDER_NOT_FOUND"
+)
+
+// Error messages
+const (
+	MsgNoProvider = "No SMS provider is configured for the destination"
+)
+
+// Predefined errors
+var (
+	ErrNoProvider = customerror.NewCustomError(
+		ErrCodeNoProvider,
+		MsgNoProvider,
+		http.StatusUnprocessableEntity,
+	)
+)
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/srcndev/message-service/pkg/webhook"
+)
+
+// provider is the private implementation backed by a webhook client
+type provider struct {
+	name   string
+	client webhook.Client
+}
+
+// Compile-time interface compliance check
+var _ Provider = (*provider)(nil)
+
+// NewProvider creates a named provider that sends through the given webhook client
+func NewProvider(name string, client webhook.Client) Provider {
+	return &provider{
+		name:   name,
+		client: client,
+	}
+}
+
+// Name returns the provider name
+func (p *provider) Name() string {
+	return p.name
+}
+
+// SendMessage sends the message and records the provider name on the response
+func (p *provider) SendMessage(ctx context.Context, req *webhook.SendMessageRequest) (*webhook.SendMessageResponse, error) {
+	resp, err := p.client.SendMessage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Provider = p.name
+	return resp, nil
+}
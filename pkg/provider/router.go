@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"math/rand/v2"
+	"sort"
+
+	"github.com/srcndev/message-service/pkg/logger"
+	"github.com/srcndev/message-service/pkg/webhook"
+)
+
+// Router sends messages through the best provider for the destination and fails over on transient errors
+type Router interface {
+	webhook.Client
+
+	// Candidates returns the providers serving the phone number in the order they are tried
+	Candidates(phoneNumber string) []Provider
+}
+
+// router is the private implementation
+type router struct {
+	entries []Entry
+	intn    func(n int) int
+}
+
+// Compile-time interface compliance check
+var _ Router = (*router)(nil)
+
+// candidate is a provider serving a destination with its ordering keys
+type candidate struct {
+	entry    Entry
+	matchLen int
+}
+
+// NewRouter creates a router over the given providers
+//
+// Providers matching a longer destination prefix are tried before less specific and catch-all
+// providers, then lower priority values first. Providers tied on both are ordered randomly
+// in proportion to their weight, so traffic is split between them.
+func NewRouter(entries ...Entry) Router {
+	return &router{
+		entries: entries,
+		intn:    rand.IntN,
+	}
+}
+
+// SendMessage tries the candidates in order until one accepts the message
+func (r *router) SendMessage(ctx context.Context, req *webhook.SendMessageRequest) (*webhook.SendMessageResponse, error) {
+	if req == nil {
+		return nil, webhook.ErrInvalidRequest
+	}
+
+	candidates := r.Candidates(req.To)
+	if len(candidates) == 0 {
+		return nil, ErrNoProvider
+	}
+
+	var lastErr error
+	for i, p := range candidates {
+		resp, err := p.SendMessage(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+
+		// Only fail over when the provider provably never took the message, a timeout may have
+		// delivered it and a rejected message fails everywhere
+		if ctx.Err() != nil || !webhook.IsNotSent(err) {
+			return nil, err
+		}
+		if i < len(candidates)-1 {
+			logger.Error("Provider %s failed: %v (failing over to %s)", p.Name(), err, candidates[i+1].Name())
+		}
+	}
+
+	return nil, lastErr
+}
+
+// Candidates returns the providers serving the phone number in the order they are tried
+func (r *router) Candidates(phoneNumber string) []Provider {
+	matches := make([]candidate, 0, len(r.entries))
+	for _, entry := range r.entries {
+		if matchLen := entry.Route.match(phoneNumber); matchLen >= 0 {
+			matches = append(matches, candidate{entry: entry, matchLen: matchLen})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].matchLen != matches[j].matchLen {
+			return matches[i].matchLen > matches[j].matchLen
+		}
+		return matches[i].entry.Route.Priority < matches[j].entry.Route.Priority
+	})
+
+	providers := make([]Provider, 0, len(matches))
+	for start := 0; start < len(matches); {
+		end := start + 1
+		for end < len(matches) && sameTier(matches[start], matches[end]) {
+			end++
+		}
+		providers = append(providers, r.weightedOrder(matches[start:end])...)
+		start = end
+	}
+
+	return providers
+}
+
+// sameTier reports whether two candidates tie on prefix match and priority
+func sameTier(a, b candidate) bool {
+	return a.matchLen == b.matchLen && a.entry.Route.Priority == b.entry.Route.Priority
+}
+
+// weightedOrder orders tied candidates randomly, heavier weights tend to come first
+func (r *router) weightedOrder(tier []candidate) []Provider {
+	remaining := make([]candidate, len(tier))
+	copy(remaining, tier)
+
+	ordered := make([]Provider, 0, len(tier))
+	for len(remaining) > 0 {
+		total := 0
+		for _, c := range remaining {
+			total += c.entry.Route.weight()
+		}
+
+		pick := r.intn(total)
+		i := 0
+		for ; i < len(remaining)-1; i++ {
+			pick -= remaining[i].entry.Route.weight()
+			if pick < 0 {
+				break
+			}
+		}
+
+		ordered = append(ordered, remaining[i].entry.Provider)
+		remaining = append(remaining[:i], remaining[i+1:]...)
+	}
+
+	return ordered
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/srcndev/message-service/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockClient is a mock for webhook.Client
+type MockClient struct {
+	mock.Mock
+}
+
+func (m *MockClient) SendMessage(ctx context.Context, req *webhook.SendMessageRequest) (*webhook.SendMessageResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhook.SendMessageResponse), args.Error(1)
+}
+
+// names returns the provider names in order
+func names(providers []Provider) []string {
+	result := make([]string, len(providers))
+	for i, p := range providers {
+		result[i] = p.Name()
+	}
+	return result
+}
+
+func TestRouter_Candidates_PrefixBeforeCatchAll(t *testing.T) {
+	r := NewRouter(
+		Entry{Provider: NewProvider("global", new(MockClient))},
+		Entry{Provider: NewProvider("turkey", new(MockClient)), Route: Route{Prefixes: []string{"+90"}}},
+		Entry{Provider: NewProvider("istanbul", new(MockClient)), Route: Route{Prefixes: []string{"+90212"}}},
+		Entry{Provider: NewProvider("us", new(MockClient)), Route: Route{Prefixes: []string{"+1"}}},
+	)
+
+	assert.Equal(t, []string{"istanbul", "turkey", "global"}, names(r.Candidates("+902125551234")))
+	assert.Equal(t, []string{"turkey", "global"}, names(r.Candidates("+905551234567")))
+	assert.Equal(t, []string{"us", "global"}, names(r.Candidates("+15551234567")))
+}
+
+func TestRouter_Candidates_PriorityOrder(t *testing.T) {
+	r := NewRouter(
+		Entry{Provider: NewProvider("backup", new(MockClient)), Route: Route{Priority: 2}},
+		Entry{Provider: NewProvider("primary", new(MockClient)), Route: Route{Priority: 1}},
+	)
+
+	assert.Equal(t, []string{"primary", "backup"}, names(r.Candidates("+905551234567")))
+}
+
+func TestRouter_Candidates_WeightedSplit(t *testing.T) {
+	r := NewRouter(
+		Entry{Provider: NewProvider("cheap", new(MockClient)), Route: Route{Weight: 3}},
+		Entry{Provider: NewProvider("premium", new(MockClient)), Route: Route{Weight: 1}},
+	).(*router)
+
+	// Picks in [0,3) land on cheap, 3 lands on premium
+	r.intn = func(n int) int { return 0 }
+	assert.Equal(t, []string{"cheap", "premium"}, names(r.Candidates("+905551234567")))
+
+	r.intn = func(n int) int { return n - 1 }
+	assert.Equal(t, []string{"premium", "cheap"}, names(r.Candidates("+905551234567")))
+}
+
+func TestRouter_Candidates_NoMatch(t *testing.T) {
+	r := NewRouter(Entry{Provider: NewProvider("turkey", new(MockClient)), Route: Route{Prefixes: []string{"+90"}}})
+
+	assert.Empty(t, r.Candidates("+15551234567"))
+}
+
+func TestRouter_SendMessage_RecordsProvider(t *testing.T) {
+	client := new(MockClient)
+	r := NewRouter(Entry{Provider: NewProvider("primary", client)})
+	req := &webhook.SendMessageRequest{To: "+905551234567", Content: "Hello"}
+
+	client.On("SendMessage", mock.Anything, req).Return(&webhook.SendMessageResponse{MessageID: "id-1"}, nil)
+
+	resp, err := r.SendMessage(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "id-1", resp.MessageID)
+	assert.Equal(t, "primary", resp.Provider)
+}
+
+func TestRouter_SendMessage_FailsOverWhenNotSent(t *testing.T) {
+	primary := new(MockClient)
+	backup := new(MockClient)
+	r := NewRouter(
+		Entry{Provider: NewProvider("primary", primary), Route: Route{Priority: 1}},
+		Entry{Provider: NewProvider("backup", backup), Route: Route{Priority: 2}},
+	)
+	req := &webhook.SendMessageRequest{To: "+905551234567", Content: "Hello"}
+
+	primary.On("SendMessage", mock.Anything, req).Return(nil, webhook.ErrUnavailable)
+	backup.On("SendMessage", mock.Anything, req).Return(&webhook.SendMessageResponse{MessageID: "id-2"}, nil)
+
+	resp, err := r.SendMessage(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "backup", resp.Provider)
+	primary.AssertExpectations(t)
+	backup.AssertExpectations(t)
+}
+
+func TestRouter_SendMessage_AmbiguousErrorDoesNotFailOver(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "timeout", err: webhook.ErrTimeout.WithError(errors.New("deadline exceeded"))},
+		{name: "server error with body", err: webhook.ErrServerError.WithError(errors.New("status: 503"))},
+		{name: "connection reset", err: webhook.ErrConnectionFailed.WithError(syscall.ECONNRESET)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := new(MockClient)
+			backup := new(MockClient)
+			r := NewRouter(
+				Entry{Provider: NewProvider("primary", primary), Route: Route{Priority: 1}},
+				Entry{Provider: NewProvider("backup", backup), Route: Route{Priority: 2}},
+			)
+			req := &webhook.SendMessageRequest{To: "+905551234567", Content: "Hello"}
+
+			primary.On("SendMessage", mock.Anything, req).Return(nil, tt.err)
+
+			_, err := r.SendMessage(context.Background(), req)
+
+			assert.Equal(t, tt.err, err)
+			backup.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRouter_SendMessage_RejectedMessageDoesNotFailOver(t *testing.T) {
+	primary := new(MockClient)
+	backup := new(MockClient)
+	r := NewRouter(
+		Entry{Provider: NewProvider("primary", primary), Route: Route{Priority: 1}},
+		Entry{Provider: NewProvider("backup", backup), Route: Route{Priority: 2}},
+	)
+	req := &webhook.SendMessageRequest{To: "+905551234567", Content: "Hello"}
+
+	primary.On("SendMessage", mock.Anything, req).Return(nil, webhook.ErrInvalidRequest)
+
+	_, err := r.SendMessage(context.Background(), req)
+
+	assert.ErrorIs(t, err, webhook.ErrInvalidRequest)
+	backup.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+}
+
+func TestRouter_SendMessage_AllProvidersFail(t *testing.T) {
+	primary := new(MockClient)
+	backup := new(MockClient)
+	r := NewRouter(
+		Entry{Provider: NewProvider("primary", primary), Route: Route{Priority: 1}},
+		Entry{Provider: NewProvider("backup", backup), Route: Route{Priority: 2}},
+	)
+	req := &webhook.SendMessageRequest{To: "+905551234567", Content: "Hello"}
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	lastErr := webhook.ErrRateLimited
+
+	primary.On("SendMessage", mock.Anything, req).Return(nil, webhook.ErrConnectionFailed.WithError(refused))
+	backup.On("SendMessage", mock.Anything, req).Return(nil, lastErr)
+
+	_, err := r.SendMessage(context.Background(), req)
+
+	assert.Equal(t, lastErr, err)
+	primary.AssertExpectations(t)
+	backup.AssertExpectations(t)
+}
+
+func TestRouter_SendMessage_NoProvider(t *testing.T) {
+	r := NewRouter(Entry{Provider: NewProvider("turkey", new(MockClient)), Route: Route{Prefixes: []string{"+90"}}})
+
+	_, err := r.SendMessage(context.Background(), &webhook.SendMessageRequest{To: "+15551234567", Content: "Hello"})
+
+	assert.ErrorIs(t, err, ErrNoProvider)
+}
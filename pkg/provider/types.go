@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/srcndev/message-service/pkg/webhook"
+)
+
+// Provider is a named SMS gateway that messages can be sent through
+type Provider interface {
+	// Name identifies the provider, it is recorded on messages it accepted
+	Name() string
+
+	// SendMessage sends a message through the provider
+	SendMessage(ctx context.Context, req *webhook.SendMessageRequest) (*webhook.SendMessageResponse, error)
+}
+
+// Route decides which destinations a provider serves and in which order it is tried
+type Route struct {
+	// Prefixes are the destination prefixes served by the provider (empty = all destinations)
+	Prefixes []string
+
+	// Priority orders providers serving the same destination, lower values are tried first
+	Priority int
+
+	// Weight is the share of traffic among providers with the same prefix match and priority
+	Weight int
+}
+
+// Entry pairs a provider with its routing rule
+type Entry struct {
+	Provider Provider
+	Route    Route
+}
+
+// match returns the length of the longest prefix matching the phone number,
+// 0 for a catch-all route and -1 when the route does not serve the number
+func (r Route) match(phoneNumber string) int {
+	if len(r.Prefixes) == 0 {
+		return 0
+	}
+
+	best := -1
+	for _, prefix := range r.Prefixes {
+		if len(prefix) > best && len(phoneNumber) >= len(prefix) && phoneNumber[:len(prefix)] == prefix {
+			best = len(prefix)
+		}
+	}
+	return best
+}
+
+// weight returns the traffic share, at least 1
+func (r Route) weight() int {
+	if r.Weight < 1 {
+		return 1
+	}
+	return r.Weight
+}
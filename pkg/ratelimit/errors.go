@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/srcndev/message-service/pkg/customerror"
+)
+
+// Error codes
+const (
+	ErrCodeWaitCancelled      = "RATE_LIMIT_WAIT_CANCELLED"
+	ErrCodeLimiterUnavailable = "RATE_LIMITER_UNAVAILABLE"
+)
+
+// Error messages
+const (
+	MsgWaitCancelled      = "Waiting for rate limit token was cancelled"
+	MsgLimiterUnavailable = "Rate limiter backend is unavailable"
+)
+
+// Predefined errors
+var (
+	ErrWaitCancelled = customerror.NewCustomError(
+		ErrCodeWaitCancelled,
+		MsgWaitCancelled,
+		http.StatusServiceUnavailable,
+	)
+
+	ErrLimiterUnavailable = customerror.NewCustomError(
+		ErrCodeLimiterUnavailable,
+		MsgLimiterUnavailable,
+		http.StatusServiceUnavailable,
+	)
+)
+
+// IsWaitCancelled reports whether err was caused by the context ending while waiting for a token, the call was never made
+func IsWaitCancelled(err error) bool {
+	var customErr *customerror.CustomError
+	return errors.As(err, &customErr) && customErr.Code == ErrCodeWaitCancelled
+}
+
+// IsUnavailable reports whether err was caused by the limiter backend being unreachable, the call was never made
+func IsUnavailable(err error) bool {
+	var customErr *customerror.CustomError
+	return errors.As(err, &customErr) && customErr.Code == ErrCodeLimiterUnavailable
+}
@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// memoryLimiter keeps token buckets in process memory
+type memoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+// bucket is the state of a single token bucket
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Compile-time interface compliance check
+var _ Limiter = (*memoryLimiter)(nil)
+
+// NewMemoryLimiter creates a limiter whose quota is local to this process
+func NewMemoryLimiter() Limiter {
+	return &memoryLimiter{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Wait blocks until a token is available for the key or the context is done
+func (l *memoryLimiter) Wait(ctx context.Context, key string, limit Limit) error {
+	return wait(ctx, key, limit, l.reserve)
+}
+
+// reserve refills the bucket and takes a token, or returns the time until one is available
+func (l *memoryLimiter) reserve(_ context.Context, key string, limit Limit) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	burst := limit.burst()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(burst, b.tokens+elapsed*limit.Rate)
+		b.last = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, nil
+	}
+
+	missing := 1 - b.tokens
+	return time.Duration(math.Ceil(missing / limit.Rate * float64(time.Second))), nil
+}
@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestMemoryLimiter returns a memory limiter driven by a manual clock
+func newTestMemoryLimiter(now *time.Time) *memoryLimiter {
+	l := NewMemoryLimiter().(*memoryLimiter)
+	l.now = func() time.Time { return *now }
+	return l
+}
+
+func TestMemoryLimiter_Reserve_BurstThenWait(t *testing.T) {
+	now := time.Now()
+	l := newTestMemoryLimiter(&now)
+	limit := Limit{Rate: 2, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		delay, err := l.reserve(context.Background(), "key", limit)
+		assert.NoError(t, err)
+		assert.Zero(t, delay)
+	}
+
+	delay, err := l.reserve(context.Background(), "key", limit)
+	assert.NoError(t, err)
+	assert.Equal(t, 500*time.Millisecond, delay)
+}
+
+func TestMemoryLimiter_Reserve_Refills(t *testing.T) {
+	now := time.Now()
+	l := newTestMemoryLimiter(&now)
+	limit := Limit{Rate: 1, Burst: 1}
+
+	delay, _ := l.reserve(context.Background(), "key", limit)
+	assert.Zero(t, delay)
+
+	now = now.Add(time.Second)
+	delay, _ = l.reserve(context.Background(), "key", limit)
+	assert.Zero(t, delay)
+}
+
+func TestMemoryLimiter_Reserve_KeysAreIndependent(t *testing.T) {
+	now := time.Now()
+	l := newTestMemoryLimiter(&now)
+	limit := Limit{Rate: 1, Burst: 1}
+
+	delay, _ := l.reserve(context.Background(), "a", limit)
+	assert.Zero(t, delay)
+
+	delay, _ = l.reserve(context.Background(), "b", limit)
+	assert.Zero(t, delay)
+}
+
+func TestMemoryLimiter_Wait_Disabled(t *testing.T) {
+	l := NewMemoryLimiter()
+
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, l.Wait(context.Background(), "key", Limit{}))
+	}
+}
+
+func TestMemoryLimiter_Wait_Throttles(t *testing.T) {
+	l := NewMemoryLimiter()
+	limit := Limit{Rate: 20, Burst: 1}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, l.Wait(context.Background(), "key", limit))
+	}
+
+	// First token is free, the next two wait ~50ms each
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
+
+func TestMemoryLimiter_Wait_ContextCancelled(t *testing.T) {
+	l := NewMemoryLimiter()
+	limit := Limit{Rate: 0.1, Burst: 1}
+
+	assert.NoError(t, l.Wait(context.Background(), "key", limit))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := l.Wait(ctx, "key", limit)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrCodeWaitCancelled)
+	assert.True(t, IsWaitCancelled(err))
+}
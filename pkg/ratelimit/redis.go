@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/srcndev/message-service/pkg/redis"
+)
+
+// tokenBucketScript refills and takes a token atomically, returning the wait in milliseconds.
+// Redis server time is used so replicas with skewed clocks share one consistent bucket.
+const tokenBucketScript = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+local elapsed = math.max(0, now - ts)
+tokens = math.min(burst, tokens + elapsed * rate / 1000)
+
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+else
+	wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return wait
+`
+
+// redisLimiter keeps token buckets in Redis so several replicas share one quota
+type redisLimiter struct {
+	client    redis.Client
+	keyPrefix string
+}
+
+// Compile-time interface compliance check
+var _ Limiter = (*redisLimiter)(nil)
+
+// NewRedisLimiter creates a limiter whose quota is shared through Redis
+func NewRedisLimiter(client redis.Client, keyPrefix string) Limiter {
+	return &redisLimiter{
+		client:    client,
+		keyPrefix: keyPrefix,
+	}
+}
+
+// Wait blocks until a token is available for the key or the context is done
+func (l *redisLimiter) Wait(ctx context.Context, key string, limit Limit) error {
+	return wait(ctx, key, limit, l.reserve)
+}
+
+// reserve runs the token bucket script and converts its result to a wait duration
+func (l *redisLimiter) reserve(ctx context.Context, key string, limit Limit) (time.Duration, error) {
+	result, err := l.client.Eval(ctx, tokenBucketScript, []string{l.keyPrefix + key}, limit.Rate, limit.burst())
+	if err != nil {
+		return 0, ErrLimiterUnavailable.WithError(err)
+	}
+
+	waitMs, ok := result.(int64)
+	if !ok {
+		return 0, ErrLimiterUnavailable.WithError(fmt.Errorf("unexpected script result: %v", result))
+	}
+
+	return time.Duration(waitMs) * time.Millisecond, nil
+}
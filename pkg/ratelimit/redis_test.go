@@ -0,0 +1,112 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// testRedisClient implements redis.Client for testing
+type testRedisClient struct {
+	rdb *goredis.Client
+}
+
+func (c *testRedisClient) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return c.rdb.Set(ctx, key, value, expiration).Err()
+}
+
+func (c *testRedisClient) Get(ctx context.Context, key string) (string, error) {
+	return c.rdb.Get(ctx, key).Result()
+}
+
+func (c *testRedisClient) Del(ctx context.Context, keys ...string) error {
+	return c.rdb.Del(ctx, keys...).Err()
+}
+
+func (c *testRedisClient) Exists(ctx context.Context, keys ...string) (int64, error) {
+	return c.rdb.Exists(ctx, keys...).Result()
+}
+
+func (c *testRedisClient) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	return c.rdb.Eval(ctx, script, keys, args...).Result()
+}
+
+func (c *testRedisClient) Close() error {
+	return c.rdb.Close()
+}
+
+func (c *testRedisClient) Ping(ctx context.Context) error {
+	return c.rdb.Ping(ctx).Err()
+}
+
+func setupRedisLimiter(t *testing.T) (*miniredis.Miniredis, *redisLimiter) {
+	mr := miniredis.RunT(t)
+	client := &testRedisClient{rdb: goredis.NewClient(&goredis.Options{Addr: mr.Addr()})}
+	return mr, NewRedisLimiter(client, "test:").(*redisLimiter)
+}
+
+func TestRedisLimiter_Reserve_BurstThenWait(t *testing.T) {
+	mr, l := setupRedisLimiter(t)
+	defer mr.Close()
+
+	mr.SetTime(time.Now())
+	limit := Limit{Rate: 2, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		delay, err := l.reserve(context.Background(), "key", limit)
+		assert.NoError(t, err)
+		assert.Zero(t, delay)
+	}
+
+	delay, err := l.reserve(context.Background(), "key", limit)
+	assert.NoError(t, err)
+	assert.Equal(t, 500*time.Millisecond, delay)
+	assert.True(t, mr.Exists("test:key"))
+}
+
+func TestRedisLimiter_Reserve_SharedAcrossLimiters(t *testing.T) {
+	mr, l := setupRedisLimiter(t)
+	defer mr.Close()
+
+	mr.SetTime(time.Now())
+	other := NewRedisLimiter(l.client, "test:").(*redisLimiter)
+	limit := Limit{Rate: 1, Burst: 1}
+
+	delay, _ := l.reserve(context.Background(), "key", limit)
+	assert.Zero(t, delay)
+
+	// A second replica sees the token already taken
+	delay, _ = other.reserve(context.Background(), "key", limit)
+	assert.Equal(t, time.Second, delay)
+}
+
+func TestRedisLimiter_Reserve_Refills(t *testing.T) {
+	mr, l := setupRedisLimiter(t)
+	defer mr.Close()
+
+	now := time.Now()
+	mr.SetTime(now)
+	limit := Limit{Rate: 1, Burst: 1}
+
+	delay, _ := l.reserve(context.Background(), "key", limit)
+	assert.Zero(t, delay)
+
+	mr.SetTime(now.Add(time.Second))
+	delay, _ = l.reserve(context.Background(), "key", limit)
+	assert.Zero(t, delay)
+}
+
+func TestRedisLimiter_Reserve_Unavailable(t *testing.T) {
+	mr, l := setupRedisLimiter(t)
+	mr.SetError("connection refused")
+	defer mr.Close()
+
+	_, err := l.reserve(context.Background(), "key", Limit{Rate: 1, Burst: 1})
+
+	assert.Error(t, err)
+	assert.True(t, IsUnavailable(err))
+}
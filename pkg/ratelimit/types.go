@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limiter defines the token bucket rate limiter interface
+type Limiter interface {
+	// Wait blocks until a token is available for the key or the context is done
+	Wait(ctx context.Context, key string, limit Limit) error
+}
+
+// Limit describes a token bucket
+type Limit struct {
+	// Rate is the number of tokens refilled per second
+	Rate float64
+
+	// Burst is the bucket capacity
+	Burst int
+}
+
+// Enabled reports whether the limit throttles anything
+func (l Limit) Enabled() bool {
+	return l.Rate > 0
+}
+
+// burst returns the bucket capacity, at least one token
+func (l Limit) burst() float64 {
+	if l.Burst < 1 {
+		return 1
+	}
+	return float64(l.Burst)
+}
+
+// reserveFunc takes a token for the key and returns how long to wait when none is available
+type reserveFunc func(ctx context.Context, key string, limit Limit) (time.Duration, error)
+
+// wait retries reserve until a token is taken, sleeping for the reported delay in between
+func wait(ctx context.Context, key string, limit Limit, reserve reserveFunc) error {
+	if !limit.Enabled() {
+		return nil
+	}
+
+	for {
+		delay, err := reserve(ctx, key, limit)
+		if err != nil {
+			return err
+		}
+		if delay <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ErrWaitCancelled.WithError(ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
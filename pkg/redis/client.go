@@ -14,6 +14,7 @@ type Client interface {
 	Get(ctx context.Context, key string) (string, error)
 	Del(ctx context.Context, keys ...string) error
 	Exists(ctx context.Context, keys ...string) (int64, error)
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
 	Close() error
 	Ping(ctx context.Context) error
 }
@@ -82,6 +83,15 @@ func (c *client) Exists(ctx context.Context, keys ...string) (int64, error) {
 	return count, nil
 }
 
+// Eval runs a Lua script atomically on the Redis server
+func (c *client) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	result, err := c.rdb.Eval(ctx, script, keys, args...).Result()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("%w: %v", ErrRedisEvalFailed, err)
+	}
+	return result, nil
+}
+
 // Close closes the Redis connection
 func (c *client) Close() error {
 	if err := c.rdb.Close(); err != nil {
@@ -14,6 +14,7 @@ const (
 	ErrCodeRedisGetFailed        = "REDIS_GET_FAILED"
 	ErrCodeRedisDelFailed        = "REDIS_DEL_FAILED"
 	ErrCodeRedisKeyNotFound      = "REDIS_KEY_NOT_FOUND"
+	ErrCodeRedisEvalFailed       = "REDIS_EVAL_FAILED"
 )
 
 // Error messages
@@ -24,6 +25,7 @@ const (
 	MsgRedisGetFailed        = "Failed to get value from Redis"
 	MsgRedisDelFailed        = "Failed to delete key from Redis"
 	MsgRedisKeyNotFound      = "Key not found in Redis"
+	MsgRedisEvalFailed       = "Failed to run script in Redis"
 )
 
 // Predefined errors
@@ -63,4 +65,10 @@ var (
 		MsgRedisKeyNotFound,
 		http.StatusNotFound,
 	)
+
+	ErrRedisEvalFailed = customerror.NewCustomError(
+		ErrCodeRedisEvalFailed,
+		MsgRedisEvalFailed,
+		http.StatusInternalServerError,
+	)
 )
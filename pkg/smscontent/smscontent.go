@@ -0,0 +1,111 @@
+package smscontent
+
+import "unicode/utf16"
+
+// Encoding is the character set an SMS is sent with
+type Encoding string
+
+const (
+	EncodingGSM7 Encoding = "gsm7" // GSM 03.38 default alphabet, 7 bits per character
+	EncodingUCS2 Encoding = "ucs2" // UTF-16, used as soon as a character is outside the GSM alphabet
+)
+
+// Segment capacities in encoding units. A concatenated message loses part of every segment to the
+// User Data Header that lets the handset reassemble the parts.
+const (
+	GSM7SingleSegment = 160
+	GSM7MultiSegment  = 153
+	UCS2SingleSegment = 70
+	UCS2MultiSegment  = 67
+)
+
+// gsm7Basic is the GSM 03.38 default alphabet, each character takes one septet
+const gsm7Basic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// gsm7Extension holds characters sent as an escape plus a septet, each takes two septets
+const gsm7Extension = "\f^{}\\[~]|€"
+
+var (
+	basicSet     = runeSet(gsm7Basic)
+	extensionSet = runeSet(gsm7Extension)
+)
+
+// Info describes how a message is encoded and split into segments
+type Info struct {
+	// Encoding is the character set the message is sent with
+	Encoding Encoding
+
+	// Units is the length in septets (GSM-7) or UTF-16 code units (UCS-2)
+	Units int
+
+	// Segments is the number of SMS the message is delivered as
+	Segments int
+}
+
+// Analyze detects the encoding of the content and counts the segments it is split into
+func Analyze(content string) Info {
+	encoding := EncodingGSM7
+	for _, r := range content {
+		if !basicSet[r] && !extensionSet[r] {
+			encoding = EncodingUCS2
+			break
+		}
+	}
+
+	single, multi := GSM7SingleSegment, GSM7MultiSegment
+	if encoding == EncodingUCS2 {
+		single, multi = UCS2SingleSegment, UCS2MultiSegment
+	}
+
+	units := 0
+	for _, r := range content {
+		units += width(r, encoding)
+	}
+
+	info := Info{Encoding: encoding, Units: units}
+	if units == 0 {
+		return info
+	}
+	if units <= single {
+		info.Segments = 1
+		return info
+	}
+
+	// An escaped character or a surrogate pair is never split across two segments
+	info.Segments = 1
+	used := 0
+	for _, r := range content {
+		w := width(r, encoding)
+		if used+w > multi {
+			info.Segments++
+			used = 0
+		}
+		used += w
+	}
+
+	return info
+}
+
+// width returns the number of encoding units a character takes
+func width(r rune, encoding Encoding) int {
+	if encoding == EncodingUCS2 {
+		if utf16.RuneLen(r) == 2 {
+			return 2
+		}
+		return 1
+	}
+	if extensionSet[r] {
+		return 2
+	}
+	return 1
+}
+
+// runeSet builds a lookup table of the characters in s
+func runeSet(s string) map[rune]bool {
+	set := make(map[rune]bool)
+	for _, r := range s {
+		set[r] = true
+	}
+	return set
+}
@@ -0,0 +1,78 @@
+package smscontent
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected Info
+	}{
+		{
+			name:     "empty content",
+			content:  "",
+			expected: Info{Encoding: EncodingGSM7, Units: 0, Segments: 0},
+		},
+		{
+			name:     "plain text fits one GSM-7 segment",
+			content:  "Hello World",
+			expected: Info{Encoding: EncodingGSM7, Units: 11, Segments: 1},
+		},
+		{
+			name:     "160 GSM-7 characters fit one segment",
+			content:  strings.Repeat("a", 160),
+			expected: Info{Encoding: EncodingGSM7, Units: 160, Segments: 1},
+		},
+		{
+			name:     "161 GSM-7 characters need two segments",
+			content:  strings.Repeat("a", 161),
+			expected: Info{Encoding: EncodingGSM7, Units: 161, Segments: 2},
+		},
+		{
+			name:     "306 GSM-7 characters fill two concatenated segments",
+			content:  strings.Repeat("a", 306),
+			expected: Info{Encoding: EncodingGSM7, Units: 306, Segments: 2},
+		},
+		{
+			name:     "extension characters take two septets",
+			content:  "Price: 10€ {promo}",
+			expected: Info{Encoding: EncodingGSM7, Units: 21, Segments: 1},
+		},
+		{
+			name:     "escaped character is not split across segments",
+			content:  strings.Repeat("a", 152) + "€" + strings.Repeat("a", 152),
+			expected: Info{Encoding: EncodingGSM7, Units: 306, Segments: 3},
+		},
+		{
+			name:     "Turkish characters outside the GSM alphabet switch to UCS-2",
+			content:  "Doğrulama kodunuz: 1234",
+			expected: Info{Encoding: EncodingUCS2, Units: 23, Segments: 1},
+		},
+		{
+			name:     "71 UCS-2 characters need two segments",
+			content:  "ş" + strings.Repeat("a", 70),
+			expected: Info{Encoding: EncodingUCS2, Units: 71, Segments: 2},
+		},
+		{
+			name:     "emoji takes a surrogate pair",
+			content:  "Hi 👋",
+			expected: Info{Encoding: EncodingUCS2, Units: 5, Segments: 1},
+		},
+		{
+			name:     "surrogate pair is not split across segments",
+			content:  strings.Repeat("a", 66) + "👋" + strings.Repeat("a", 66),
+			expected: Info{Encoding: EncodingUCS2, Units: 134, Segments: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Analyze(tt.content))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/srcndev/message-service/pkg/circuitbreaker"
+	"github.com/srcndev/message-service/pkg/ratelimit"
+)
+
+// circuitBreakerClient rejects calls while the breaker is open and reports outcomes to it
+type circuitBreakerClient struct {
+	next    Client
+	breaker circuitbreaker.Breaker
+}
+
+// Compile-time interface compliance check
+var _ Client = (*circuitBreakerClient)(nil)
+
+// NewCircuitBreakerClient wraps a webhook client with a circuit breaker
+func NewCircuitBreakerClient(next Client, breaker circuitbreaker.Breaker) Client {
+	return &circuitBreakerClient{
+		next:    next,
+		breaker: breaker,
+	}
+}
+
+// SendMessage sends the message unless the breaker is open
+func (c *circuitBreakerClient) SendMessage(ctx context.Context, req *SendMessageRequest) (*SendMessageResponse, error) {
+	if err := c.breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.next.SendMessage(ctx, req)
+	switch {
+	case err == nil:
+		c.breaker.Success()
+	case ctx.Err() != nil, ratelimit.IsUnavailable(err):
+		// Cancelled by the caller or held back by the rate limiter, which says nothing about the endpoint
+		c.breaker.Release()
+	case IsTransient(err):
+		c.breaker.Failure()
+	default:
+		// The endpoint answered (e.g. rejected the request), so it is reachable
+		c.breaker.Success()
+	}
+
+	return resp, err
+}
@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/srcndev/message-service/pkg/circuitbreaker"
+	"github.com/srcndev/message-service/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCircuitBreakerClient_OpensAfterEndpointFailures(t *testing.T) {
+	next := new(MockClient)
+	breaker := circuitbreaker.NewBreaker(circuitbreaker.Config{FailureThreshold: 2, CoolDown: time.Minute})
+	c := NewCircuitBreakerClient(next, breaker)
+	req := &SendMessageRequest{To: "+905551234567", Content: "Hello"}
+
+	next.On("SendMessage", mock.Anything, req).Return(nil, ErrServerError.WithError(errors.New("status: 503"))).Twice()
+
+	for i := 0; i < 2; i++ {
+		_, err := c.SendMessage(context.Background(), req)
+		assert.Error(t, err)
+	}
+	assert.Equal(t, circuitbreaker.StateOpen, breaker.State())
+
+	// Rejected without calling the endpoint
+	_, err := c.SendMessage(context.Background(), req)
+	assert.True(t, circuitbreaker.IsOpen(err))
+	next.AssertNumberOfCalls(t, "SendMessage", 2)
+}
+
+func TestCircuitBreakerClient_ClientErrorsKeepBreakerClosed(t *testing.T) {
+	next := new(MockClient)
+	breaker := circuitbreaker.NewBreaker(circuitbreaker.Config{FailureThreshold: 1, CoolDown: time.Minute})
+	c := NewCircuitBreakerClient(next, breaker)
+	req := &SendMessageRequest{To: "+905551234567", Content: "Hello"}
+
+	next.On("SendMessage", mock.Anything, req).Return(nil, ErrUnauthorized)
+
+	_, err := c.SendMessage(context.Background(), req)
+
+	assert.Error(t, err)
+	assert.Equal(t, circuitbreaker.StateClosed, breaker.State())
+}
+
+func TestCircuitBreakerClient_LimiterUnavailableNotCounted(t *testing.T) {
+	next := new(MockClient)
+	breaker := circuitbreaker.NewBreaker(circuitbreaker.Config{FailureThreshold: 1, CoolDown: time.Minute})
+	c := NewCircuitBreakerClient(next, breaker)
+	req := &SendMessageRequest{To: "+905551234567", Content: "Hello"}
+
+	// The limiter sits inside the breaker, its outage never reached the endpoint
+	next.On("SendMessage", mock.Anything, req).Return(nil, ratelimit.ErrLimiterUnavailable.WithError(errors.New("connection refused")))
+
+	_, err := c.SendMessage(context.Background(), req)
+
+	assert.True(t, ratelimit.IsUnavailable(err))
+	assert.Equal(t, circuitbreaker.StateClosed, breaker.State())
+	assert.Equal(t, 0, breaker.Snapshot().ConsecutiveFailures)
+}
+
+func TestCircuitBreakerClient_SuccessCloses(t *testing.T) {
+	next := new(MockClient)
+	breaker := circuitbreaker.NewBreaker(circuitbreaker.Config{FailureThreshold: 2, CoolDown: time.Minute})
+	c := NewCircuitBreakerClient(next, breaker)
+	req := &SendMessageRequest{To: "+905551234567", Content: "Hello"}
+
+	next.On("SendMessage", mock.Anything, req).Return(nil, errors.New("connection reset")).Once()
+	next.On("SendMessage", mock.Anything, req).Return(&SendMessageResponse{MessageID: "id-1"}, nil).Once()
+
+	_, _ = c.SendMessage(context.Background(), req)
+	resp, err := c.SendMessage(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "id-1", resp.MessageID)
+	assert.Equal(t, 0, breaker.Snapshot().ConsecutiveFailures)
+}
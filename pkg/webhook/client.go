@@ -1,9 +1,11 @@
 package webhook
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/srcndev/message-service/pkg/httpclient"
@@ -31,18 +33,38 @@ type Config struct {
 	AuthKey    string
 	Timeout    time.Duration
 	MaxRetries int
+
+	// RetryMaxElapsed bounds the total time spent retrying a single message (0 = no limit)
+	RetryMaxElapsed time.Duration
+
+	// RetryNonIdempotent allows retrying the POST to the webhook, which may deliver a message twice
+	// unless the provider honors the idempotency key
+	RetryNonIdempotent bool
+
+	// Interceptors observe or modify every outgoing webhook request
+	Interceptors []httpclient.Interceptor
 }
 
+// IdempotencyKeyHeader carries the idempotency key of a message to the provider
+const IdempotencyKeyHeader = "Idempotency-Key"
+
 // SendMessageRequest represents the webhook request payload
 type SendMessageRequest struct {
 	To      string `json:"to"`
 	Content string `json:"content"`
+
+	// IdempotencyKey is sent as a header so a provider honoring it drops repeated calls for the same
+	// message. It is not part of the payload and does not make the call retryable on its own.
+	IdempotencyKey string `json:"-"`
 }
 
 // SendMessageResponse represents the webhook response
 type SendMessageResponse struct {
 	Message   string `json:"message"`
 	MessageID string `json:"messageId"`
+
+	// Provider is the name of the SMS provider that accepted the message, it is not part of the payload
+	Provider string `json:"-"`
 }
 
 // NewWebhookClient creates a new webhook client
@@ -54,6 +76,11 @@ func NewWebhookClient(cfg Config) Client {
 			"Content-Type":   "application/json",
 			"x-ins-auth-key": cfg.AuthKey,
 		},
+		Retry: httpclient.RetryPolicy{
+			MaxElapsedTime:     cfg.RetryMaxElapsed,
+			RetryNonIdempotent: cfg.RetryNonIdempotent,
+		},
+		Interceptors: cfg.Interceptors,
 	}
 
 	return &client{
@@ -77,8 +104,17 @@ func (c *client) SendMessage(ctx context.Context, req *SendMessageRequest) (*Sen
 		return nil, ErrEmptyContent
 	}
 
-	// Send HTTP request
-	resp, err := c.httpClient.Post(ctx, c.baseURL, req, nil)
+	// Send HTTP request, it is only retried when RetryNonIdempotent opts in
+	httpReq := &httpclient.Request{
+		Method: http.MethodPost,
+		URL:    c.baseURL,
+		Body:   req,
+	}
+	if req.IdempotencyKey != "" {
+		httpReq.Headers = map[string]string{IdempotencyKeyHeader: req.IdempotencyKey}
+	}
+
+	resp, err := c.httpClient.Do(ctx, httpReq)
 	if err != nil {
 		return nil, ErrConnectionFailed.WithError(err)
 	}
@@ -88,6 +124,15 @@ func (c *client) SendMessage(ctx context.Context, req *SendMessageRequest) (*Sen
 		return nil, ErrUnauthorized
 	}
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, ErrRateLimited
+	}
+
+	// A 503 without a body comes from a gateway that turned the call away before handling it
+	if resp.StatusCode == http.StatusServiceUnavailable && len(bytes.TrimSpace(resp.Body)) == 0 {
+		return nil, ErrUnavailable
+	}
+
 	if resp.StatusCode >= 500 {
 		return nil, ErrServerError.WithError(fmt.Errorf("status: %d", resp.StatusCode))
 	}
@@ -113,7 +113,7 @@ func TestClient_SendMessage_Success(t *testing.T) {
 			mockHTTP := new(MockHTTPClient)
 
 			responseBytes, _ := json.Marshal(tt.responseBody)
-			mockHTTP.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			mockHTTP.On("Do", mock.Anything, mock.Anything).
 				Return(&httpclient.Response{
 					StatusCode: tt.statusCode,
 					Body:       responseBytes,
@@ -136,6 +136,53 @@ func TestClient_SendMessage_Success(t *testing.T) {
 	}
 }
 
+func TestClient_SendMessage_IdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		idempotencyKey string
+		expectedHeader map[string]string
+	}{
+		{
+			name:           "key sent as header without making the call retryable",
+			idempotencyKey: "msg-1-1700000000000000",
+			expectedHeader: map[string]string{IdempotencyKeyHeader: "msg-1-1700000000000000"},
+		},
+		{
+			name: "no key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHTTP := new(MockHTTPClient)
+			mockHTTP.On("Do", mock.Anything, mock.MatchedBy(func(req *httpclient.Request) bool {
+				return req.Method == http.MethodPost &&
+					req.URL == "https://webhook.test" &&
+					assert.ObjectsAreEqual(tt.expectedHeader, req.Headers) &&
+					!req.Idempotent
+			})).Return(&httpclient.Response{
+				StatusCode: http.StatusAccepted,
+				Body:       []byte(`{"message":"Accepted","messageId":"id-1"}`),
+			}, nil)
+
+			client := &client{
+				httpClient: mockHTTP,
+				baseURL:    "https://webhook.test",
+				authKey:    "test-key",
+			}
+
+			_, err := client.SendMessage(context.Background(), &SendMessageRequest{
+				To:             "+905551234567",
+				Content:        "Test message",
+				IdempotencyKey: tt.idempotencyKey,
+			})
+
+			assert.NoError(t, err)
+			mockHTTP.AssertExpectations(t)
+		})
+	}
+}
+
 func TestClient_SendMessage_ValidationErrors(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -216,7 +263,7 @@ func TestClient_SendMessage_HTTPErrors(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockHTTP := new(MockHTTPClient)
 
-			mockHTTP.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			mockHTTP.On("Do", mock.Anything, mock.Anything).
 				Return(&httpclient.Response{
 					StatusCode: tt.statusCode,
 					Body:       []byte("{}"),
@@ -243,11 +290,62 @@ func TestClient_SendMessage_HTTPErrors(t *testing.T) {
 	}
 }
 
+func TestClient_SendMessage_NotSentStatuses(t *testing.T) {
+	tests := []struct {
+		name            string
+		statusCode      int
+		body            string
+		expectedErrCode string
+	}{
+		{
+			name:            "429 Too Many Requests",
+			statusCode:      http.StatusTooManyRequests,
+			body:            "{}",
+			expectedErrCode: "WEBHOOK_RATE_LIMITED",
+		},
+		{
+			name:            "503 Service Unavailable without body",
+			statusCode:      http.StatusServiceUnavailable,
+			expectedErrCode: "WEBHOOK_UNAVAILABLE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHTTP := new(MockHTTPClient)
+
+			mockHTTP.On("Do", mock.Anything, mock.Anything).
+				Return(&httpclient.Response{
+					StatusCode: tt.statusCode,
+					Body:       []byte(tt.body),
+				}, nil)
+
+			client := &client{
+				httpClient: mockHTTP,
+				baseURL:    "https://webhook.test",
+				authKey:    "test-key",
+			}
+
+			request := &SendMessageRequest{
+				To:      "+905551234567",
+				Content: "Test message",
+			}
+
+			result, err := client.SendMessage(context.Background(), request)
+
+			assert.Nil(t, result)
+			assert.Contains(t, err.Error(), tt.expectedErrCode)
+			assert.True(t, IsNotSent(err))
+			mockHTTP.AssertExpectations(t)
+		})
+	}
+}
+
 func TestClient_SendMessage_ConnectionError(t *testing.T) {
 	mockHTTP := new(MockHTTPClient)
 
 	connectionErr := errors.New("connection refused")
-	mockHTTP.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+	mockHTTP.On("Do", mock.Anything, mock.Anything).
 		Return(nil, connectionErr)
 
 	client := &client{
@@ -272,7 +370,7 @@ func TestClient_SendMessage_ConnectionError(t *testing.T) {
 func TestClient_SendMessage_InvalidJSON(t *testing.T) {
 	mockHTTP := new(MockHTTPClient)
 
-	mockHTTP.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+	mockHTTP.On("Do", mock.Anything, mock.Anything).
 		Return(&httpclient.Response{
 			StatusCode: http.StatusAccepted,
 			Body:       []byte("invalid json"),
@@ -346,7 +444,7 @@ func TestClient_SendMessage_All2xxSuccessCodes(t *testing.T) {
 			}
 			responseBytes, _ := json.Marshal(responseBody)
 
-			mockHTTP.On("Post", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			mockHTTP.On("Do", mock.Anything, mock.Anything).
 				Return(&httpclient.Response{
 					StatusCode: code,
 					Body:       responseBytes,
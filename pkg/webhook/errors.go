@@ -1,7 +1,9 @@
 package webhook
 
 import (
+	"errors"
 	"net/http"
+	"syscall"
 
 	"github.com/srcndev/message-service/pkg/customerror"
 )
@@ -14,6 +16,8 @@ const (
 	ErrCodeWebhookInvalidRequest   = "WEBHOOK_INVALID_REQUEST"
 	ErrCodeWebhookUnauthorized     = "WEBHOOK_UNAUTHORIZED"
 	ErrCodeWebhookServerError      = "WEBHOOK_SERVER_ERROR"
+	ErrCodeWebhookRateLimited      = "WEBHOOK_RATE_LIMITED"
+	ErrCodeWebhookUnavailable      = "WEBHOOK_UNAVAILABLE"
 	ErrCodeWebhookParsingResponse  = "WEBHOOK_PARSING_ERROR"
 	ErrCodeInvalidPhoneNumber      = "INVALID_PHONE_NUMBER"
 	ErrCodeEmptyContent            = "EMPTY_CONTENT"
@@ -27,6 +31,8 @@ const (
 	MsgWebhookInvalidRequest   = "Invalid webhook request"
 	MsgWebhookUnauthorized     = "Webhook authentication failed"
 	MsgWebhookServerError      = "Webhook server error"
+	MsgWebhookRateLimited      = "Webhook rate limit exceeded"
+	MsgWebhookUnavailable      = "Webhook service unavailable"
 	MsgWebhookParsingResponse  = "Failed to parse webhook response"
 	MsgInvalidPhoneNumber      = "Invalid phone number format"
 	MsgEmptyContent            = "Message content cannot be empty"
@@ -70,6 +76,18 @@ var (
 		http.StatusBadGateway,
 	)
 
+	ErrRateLimited = customerror.NewCustomError(
+		ErrCodeWebhookRateLimited,
+		MsgWebhookRateLimited,
+		http.StatusTooManyRequests,
+	)
+
+	ErrUnavailable = customerror.NewCustomError(
+		ErrCodeWebhookUnavailable,
+		MsgWebhookUnavailable,
+		http.StatusServiceUnavailable,
+	)
+
 	ErrParsingResponse = customerror.NewCustomError(
 		ErrCodeWebhookParsingResponse,
 		MsgWebhookParsingResponse,
@@ -88,3 +106,39 @@ var (
 		http.StatusBadRequest,
 	)
 )
+
+// IsTransient reports whether the error means the endpoint is temporarily unavailable
+// (connection failure, timeout or server error), so the call may succeed later or elsewhere
+func IsTransient(err error) bool {
+	var customErr *customerror.CustomError
+	if !errors.As(err, &customErr) {
+		return true
+	}
+
+	switch customErr.Code {
+	case ErrCodeWebhookConnectionFailed, ErrCodeWebhookTimeout, ErrCodeWebhookServerError,
+		ErrCodeWebhookRateLimited, ErrCodeWebhookUnavailable:
+		return true
+	}
+	return false
+}
+
+// IsNotSent reports whether the error proves the endpoint never took the message
+// (connection refused, rate limited, or unavailable without a response body).
+// Timeouts and other server errors are ambiguous, the message may have been delivered
+func IsNotSent(err error) bool {
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	var customErr *customerror.CustomError
+	if !errors.As(err, &customErr) {
+		return false
+	}
+
+	switch customErr.Code {
+	case ErrCodeWebhookRateLimited, ErrCodeWebhookUnavailable:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/srcndev/message-service/pkg/ratelimit"
+)
+
+// Rate limiter keys
+const (
+	rateLimitGlobalKey = "global"
+	rateLimitPrefixKey = "prefix:"
+)
+
+// RateLimitConfig holds outbound rate limits applied before each webhook call
+type RateLimitConfig struct {
+	// Global limits all outbound messages
+	Global ratelimit.Limit
+
+	// Prefixes limits messages per destination prefix (e.g. "+90"), the longest match wins
+	Prefixes map[string]ratelimit.Limit
+}
+
+// rateLimitedClient waits for rate limit tokens before delegating to the wrapped client
+type rateLimitedClient struct {
+	next     Client
+	limiter  ratelimit.Limiter
+	global   ratelimit.Limit
+	prefixes []string
+	limits   map[string]ratelimit.Limit
+}
+
+// Compile-time interface compliance check
+var _ Client = (*rateLimitedClient)(nil)
+
+// NewRateLimitedClient wraps a webhook client with global and per-prefix rate limits
+func NewRateLimitedClient(next Client, limiter ratelimit.Limiter, cfg RateLimitConfig) Client {
+	prefixes := make([]string, 0, len(cfg.Prefixes))
+	for prefix := range cfg.Prefixes {
+		prefixes = append(prefixes, prefix)
+	}
+	// Longest prefix first so "+9055" wins over "+90"
+	sort.Slice(prefixes, func(i, j int) bool {
+		return len(prefixes[i]) > len(prefixes[j])
+	})
+
+	return &rateLimitedClient{
+		next:     next,
+		limiter:  limiter,
+		global:   cfg.Global,
+		prefixes: prefixes,
+		limits:   cfg.Prefixes,
+	}
+}
+
+// SendMessage waits for the prefix and global quotas, then sends the message
+func (c *rateLimitedClient) SendMessage(ctx context.Context, req *SendMessageRequest) (*SendMessageResponse, error) {
+	if req != nil {
+		if prefix, ok := c.matchPrefix(req.To); ok {
+			if err := c.limiter.Wait(ctx, rateLimitPrefixKey+prefix, c.limits[prefix]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := c.limiter.Wait(ctx, rateLimitGlobalKey, c.global); err != nil {
+		return nil, err
+	}
+
+	return c.next.SendMessage(ctx, req)
+}
+
+// matchPrefix returns the longest configured prefix of the phone number
+func (c *rateLimitedClient) matchPrefix(phoneNumber string) (string, bool) {
+	for _, prefix := range c.prefixes {
+		if strings.HasPrefix(phoneNumber, prefix) {
+			return prefix, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/srcndev/message-service/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockClient is a mock for the wrapped webhook Client
+type MockClient struct {
+	mock.Mock
+}
+
+func (m *MockClient) SendMessage(ctx context.Context, req *SendMessageRequest) (*SendMessageResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*SendMessageResponse), args.Error(1)
+}
+
+// MockLimiter is a mock for ratelimit.Limiter
+type MockLimiter struct {
+	mock.Mock
+}
+
+func (m *MockLimiter) Wait(ctx context.Context, key string, limit ratelimit.Limit) error {
+	args := m.Called(ctx, key, limit)
+	return args.Error(0)
+}
+
+func TestRateLimitedClient_SendMessage_GlobalLimit(t *testing.T) {
+	next := new(MockClient)
+	limiter := new(MockLimiter)
+	global := ratelimit.Limit{Rate: 10, Burst: 1}
+
+	c := NewRateLimitedClient(next, limiter, RateLimitConfig{Global: global})
+	req := &SendMessageRequest{To: "+905551234567", Content: "Hello"}
+
+	limiter.On("Wait", mock.Anything, "global", global).Return(nil)
+	next.On("SendMessage", mock.Anything, req).Return(&SendMessageResponse{MessageID: "id-1"}, nil)
+
+	resp, err := c.SendMessage(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "id-1", resp.MessageID)
+	limiter.AssertExpectations(t)
+	next.AssertExpectations(t)
+}
+
+func TestRateLimitedClient_SendMessage_LongestPrefixWins(t *testing.T) {
+	next := new(MockClient)
+	limiter := new(MockLimiter)
+	turkey := ratelimit.Limit{Rate: 5, Burst: 1}
+	turkcell := ratelimit.Limit{Rate: 2, Burst: 1}
+
+	c := NewRateLimitedClient(next, limiter, RateLimitConfig{
+		Prefixes: map[string]ratelimit.Limit{"+90": turkey, "+90555": turkcell},
+	})
+	req := &SendMessageRequest{To: "+905551234567", Content: "Hello"}
+
+	limiter.On("Wait", mock.Anything, "prefix:+90555", turkcell).Return(nil)
+	limiter.On("Wait", mock.Anything, "global", ratelimit.Limit{}).Return(nil)
+	next.On("SendMessage", mock.Anything, req).Return(&SendMessageResponse{MessageID: "id-1"}, nil)
+
+	_, err := c.SendMessage(context.Background(), req)
+
+	assert.NoError(t, err)
+	limiter.AssertExpectations(t)
+	limiter.AssertNotCalled(t, "Wait", mock.Anything, "prefix:+90", mock.Anything)
+}
+
+func TestRateLimitedClient_SendMessage_WaitError(t *testing.T) {
+	next := new(MockClient)
+	limiter := new(MockLimiter)
+
+	c := NewRateLimitedClient(next, limiter, RateLimitConfig{Global: ratelimit.Limit{Rate: 1}})
+
+	limiter.On("Wait", mock.Anything, "global", mock.Anything).Return(errors.New("cancelled"))
+
+	resp, err := c.SendMessage(context.Background(), &SendMessageRequest{To: "+15551234567", Content: "Hello"})
+
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+	next.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/jaswdr/faker"
 	"github.com/srcndev/message-service/internal/domain"
 	"github.com/srcndev/message-service/pkg/logger"
+	"github.com/srcndev/message-service/pkg/smscontent"
 	"gorm.io/gorm"
 )
 
@@ -104,9 +105,12 @@ func (s *Seeder) seedPendingMessages(ctx context.Context, count int) error {
 			content = fmt.Sprintf(template, name, s.faker.RandomStringWithLength(6))
 		}
 
+		info := smscontent.Analyze(content)
 		messages[i] = domain.Message{
 			PhoneNumber: s.generatePhone(),
 			Content:     content,
+			Encoding:    string(info.Encoding),
+			Segments:    info.Segments,
 			Status:      domain.StatusPending,
 		}
 	}
@@ -122,9 +126,13 @@ func (s *Seeder) seedSentMessages(ctx context.Context, count int) error {
 		sentAt := time.Now().Add(-time.Duration(s.faker.IntBetween(1, 72)) * time.Hour)
 		messageID := s.faker.UUID().V4()
 
+		content := fmt.Sprintf("This is a sent message: %s", s.faker.Lorem().Sentence(10))
+		info := smscontent.Analyze(content)
 		messages[i] = domain.Message{
 			PhoneNumber: s.generatePhone(),
-			Content:     fmt.Sprintf("This is a sent message: %s", s.faker.Lorem().Sentence(10)),
+			Content:     content,
+			Encoding:    string(info.Encoding),
+			Segments:    info.Segments,
 			Status:      domain.StatusSent,
 			MessageID:   &messageID,
 			SentAt:      &sentAt,
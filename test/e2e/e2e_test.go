@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/srcndev/message-service/internal/repository"
 	"github.com/srcndev/message-service/internal/service"
 	"github.com/srcndev/message-service/pkg/customresponse"
+	"github.com/srcndev/message-service/pkg/database"
 	"github.com/srcndev/message-service/pkg/webhook"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -92,8 +94,8 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	})
 	require.NoError(t, err, "Failed to connect to test database")
 
-	// Auto migrate schema
-	err = db.AutoMigrate(&domain.Message{})
+	// Migrate the same schema the service runs on, extensions included
+	err = database.AutoMigrate(db)
 	require.NoError(t, err, "Failed to migrate schema")
 
 	return db
@@ -101,7 +103,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 
 // cleanupTestDB cleans up test data
 func cleanupTestDB(t *testing.T, db *gorm.DB) {
-	db.Exec("TRUNCATE TABLE messages RESTART IDENTITY CASCADE")
+	db.Exec("TRUNCATE TABLE messages, send_intents RESTART IDENTITY CASCADE")
 }
 
 // setupTestApp creates a complete application instance for testing
@@ -136,7 +138,7 @@ func setupTestApp(t *testing.T, webhookURL string) (*gin.Engine, *gorm.DB, handl
 
 	// Create handlers
 	messageHandler := handler.NewMessageHandler(messageService)
-	messageSenderHandler := handler.NewMessageSenderHandler(messageSenderJob)
+	messageSenderHandler := handler.NewMessageSenderHandler(messageSenderJob, nil)
 
 	// Setup router
 	router := gin.New()
@@ -374,10 +376,10 @@ func TestE2E_ValidationErrors(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name: "content too long",
+			name: "content exceeds max segments",
 			requestBody: dto.CreateMessageRequest{
 				PhoneNumber: "+905551111111",
-				Content:     string(make([]byte, 161)),
+				Content:     strings.Repeat("a", 7*153),
 			},
 			expectedStatus: http.StatusBadRequest,
 		},